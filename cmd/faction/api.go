@@ -7,8 +7,10 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/voidshard/faction/internal/auth"
 	"github.com/voidshard/faction/internal/db"
 	"github.com/voidshard/faction/internal/queue"
+	"github.com/voidshard/faction/internal/ratelimit"
 	"github.com/voidshard/faction/internal/search"
 	"github.com/voidshard/faction/internal/service/api"
 	"github.com/voidshard/faction/pkg/util/log"
@@ -19,6 +21,8 @@ const (
 )
 
 type optDatabase struct {
+	Db_Driver   string `long:"db-driver" env:"DB_DRIVER" description:"Database driver" choice:"mongo" choice:"sqlite" choice:"memory" default:"mongo"`
+	Db_Path     string `long:"db-path" env:"DB_PATH" description:"Database file (sqlite only)" default:"faction.db"`
 	Db_Host     string `long:"db-host" env:"DB_HOST" description:"Database host" default:"localhost"`
 	Db_Port     int    `long:"db-port" env:"DB_PORT" description:"Database port" default:"27017"`
 	Db_Username string `long:"db-username" env:"DB_USERNAME" description:"Database user" default:"admin"`
@@ -54,10 +58,17 @@ type optsAPI struct {
 	// MaxMessageAge is the maximum age of a message before it is considered stale
 	MaxMessageAge time.Duration `env:"MAX_MESSAGE_AGE" long:"max-message-age" description:"Maximum age of a message before it is considered stale" default:"10m"`
 	Port          int           `env:"PORT" long:"port" description:"Port to listen on" default:"5000"`
+	GrpcPort      int           `env:"GRPC_PORT" long:"grpc-port" description:"Port to serve gRPC on, 0 disables gRPC" default:"5001"`
 	FlushSearch   bool          `env:"FLUSH_SEARCH" long:"flush-search" description:"Wait for writes to searchbase before returning API writes (slow)"`
 
 	TimeoutRead  time.Duration `env:"TIMEOUT_READ" long:"timeout-read" description:"Read timeout" default:"60s"`
 	TimeoutWrite time.Duration `env:"TIMEOUT_WRITE" long:"timeout-write" description:"Write timeout" default:"60s"`
+
+	History int `env:"HISTORY" long:"history" description:"Revisions kept of each object, unless set by the world" default:"10"`
+
+	AuthConfig string `env:"AUTH_CONFIG" long:"auth-config" description:"YAML file configuring authentication & roles, if not set anyone may do anything"`
+
+	RateLimits []string `env:"RATE_LIMITS" env-delim:"," long:"rate-limit" description:"Requests per second (and burst) allowed from each client to a route, as route=rate:burst (ie. default=50:100, search=5:10)"`
 }
 
 func (c *optsAPI) Execute(args []string) error {
@@ -66,6 +77,24 @@ func (c *optsAPI) Execute(args []string) error {
 		log.SetGlobalLevel()
 	}
 
+	// setup auth, if configured
+	var authz *auth.Auth
+	if c.AuthConfig != "" {
+		var err error
+		authz, err = auth.Load(c.AuthConfig)
+		log.Info().Err(err).Str("config", c.AuthConfig).Msg("auth")
+		if err != nil {
+			return err
+		}
+	} else {
+		log.Warn().Msg("no auth config given, the API is open to anyone")
+	}
+
+	limits, err := ratelimit.Parse(c.RateLimits)
+	if err != nil {
+		return err
+	}
+
 	ready := sync.WaitGroup{}
 	ready.Add(3)
 	var database db.Database
@@ -76,14 +105,7 @@ func (c *optsAPI) Execute(args []string) error {
 	go func() {
 		defer ready.Done()
 		var err error
-		database, err = db.NewMongo(&db.MongoConfig{
-			Host:     c.Db_Host,
-			Port:     c.Db_Port,
-			Username: c.Db_Username,
-			Password: c.Db_Password,
-			Database: c.Db_Database,
-		})
-		log.Info().Err(err).Str("host", c.Db_Host).Int("port", c.Db_Port).Str("username", c.Db_Username).Str("database", c.Db_Database).Msg("database connection")
+		database, err = c.connectDatabase()
 		if err != nil {
 			panic(err)
 		}
@@ -129,6 +151,9 @@ func (c *optsAPI) Execute(args []string) error {
 	server, err := api.NewServer(&api.Config{
 		MaxMessageAge: c.MaxMessageAge,
 		FlushSearch:   c.FlushSearch,
+		History:       c.History,
+		Auth:          authz,
+		RateLimits:    limits,
 	}, database, qu, sb)
 	log.Info().Err(err).Int("port", c.Port).Msg("api server")
 	if err != nil {
@@ -144,5 +169,38 @@ func (c *optsAPI) Execute(args []string) error {
 		server.Stop()
 	}()
 
+	// serve gRPC alongside HTTP
+	if c.GrpcPort > 0 {
+		go func() {
+			err := server.ServeGRPC(c.GrpcPort)
+			log.Info().Err(err).Int("port", c.GrpcPort).Msg("grpc server stopped")
+			if err != nil {
+				panic(err)
+			}
+		}()
+	}
+
 	return server.Serve(c.Port)
 }
+
+func (c *optsAPI) connectDatabase() (db.Database, error) {
+	switch c.Db_Driver {
+	case "sqlite":
+		database, err := db.NewSQLite(&db.SQLiteConfig{Path: c.Db_Path})
+		log.Info().Err(err).Str("driver", c.Db_Driver).Str("path", c.Db_Path).Msg("database connection")
+		return database, err
+	case "memory":
+		log.Info().Str("driver", c.Db_Driver).Msg("database connection")
+		return db.NewMemory(), nil
+	default:
+		database, err := db.NewMongo(&db.MongoConfig{
+			Host:     c.Db_Host,
+			Port:     c.Db_Port,
+			Username: c.Db_Username,
+			Password: c.Db_Password,
+			Database: c.Db_Database,
+		})
+		log.Info().Err(err).Str("driver", c.Db_Driver).Str("host", c.Db_Host).Int("port", c.Db_Port).Str("username", c.Db_Username).Str("database", c.Db_Database).Msg("database connection")
+		return database, err
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"strings"
+
+	"github.com/voidshard/faction/pkg/client"
 )
 
 const (
@@ -10,8 +12,18 @@ const (
 )
 
 type optCliConn struct {
-	Host string `long:"host" env:"HOST" description:"API host" default:"localhost"`
-	Port int    `long:"port" env:"PORT" description:"API port" default:"5000"`
+	Host  string `long:"host" env:"HOST" description:"API host" default:"localhost"`
+	Port  int    `long:"port" env:"PORT" description:"API port" default:"5000"`
+	Token string `long:"token" env:"TOKEN" description:"Bearer token to authenticate with the API"`
+}
+
+// config returns the client config for our connection options
+func (o *optCliConn) config() *client.Config {
+	cfg := client.NewConfig()
+	cfg.Host = o.Host
+	cfg.Port = o.Port
+	cfg.Token = o.Token
+	return cfg
 }
 
 type optCliGlobal struct {
@@ -26,6 +38,9 @@ type optsCli struct {
 	Watch  cliWatchCmd  `command:"watch" description:"Watch events"`
 	Event  cliEventCmd  `command:"event" description:"Emit events (force reconcile, debugging)"`
 	Search cliSearchCmd `command:"search" description:"Search for objects"`
+	Export cliExportCmd `command:"export" description:"Export a world as an archive"`
+	Import cliImportCmd `command:"import" description:"Import a world from an archive"`
+	Fork   cliForkCmd   `command:"fork" description:"Copy a world into a new world at the same tick"`
 
 	Help cliHelpCmd `command:"help" description:"Help about available objects"`
 }
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/voidshard/faction/pkg/client"
+)
+
+type cliExportCmd struct {
+	optCliConn
+	optGeneral
+	optCliGlobal
+
+	File string `short:"f" long:"file" description:"File to write the archive to (default stdout)"`
+}
+
+func (c *cliExportCmd) Execute(args []string) error {
+	if c.World == "" {
+		return fmt.Errorf("world must be set")
+	}
+
+	conn, err := client.New(c.config())
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if c.File != "" {
+		f, err := os.Create(c.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	return conn.Export(c.World, out)
+}
+
+type cliImportCmd struct {
+	optCliConn
+	optGeneral
+	optCliGlobal
+
+	File string `short:"f" long:"file" description:"Archive to import (default stdin)"`
+}
+
+func (c *cliImportCmd) Execute(args []string) error {
+	if c.World == "" {
+		return fmt.Errorf("world must be set")
+	}
+
+	conn, err := client.New(c.config())
+	if err != nil {
+		return err
+	}
+
+	var in io.Reader = os.Stdin
+	if c.File != "" {
+		f, err := os.Open(c.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	counts, err := conn.Import(c.World, in)
+	kinds := []string{}
+	for k := range counts {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	for _, k := range kinds {
+		fmt.Printf("imported %d %s\n", counts[k], k)
+	}
+	return err
+}
+
+type cliForkCmd struct {
+	optCliConn
+	optGeneral
+	optCliGlobal
+
+	To string `long:"to" required:"true" description:"Id of the new world"`
+}
+
+func (c *cliForkCmd) Execute(args []string) error {
+	if c.World == "" {
+		return fmt.Errorf("world must be set")
+	}
+
+	conn, err := client.New(c.config())
+	if err != nil {
+		return err
+	}
+
+	resp, err := conn.Fork(c.World, c.To)
+	kinds := []string{}
+	for k := range resp.Counts {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	for _, k := range kinds {
+		fmt.Printf("copied %d %s\n", resp.Counts[k], k)
+	}
+	if err == nil {
+		fmt.Printf("forked %s to %s at tick %d\n", c.World, resp.World, resp.Tick)
+	}
+	return err
+}
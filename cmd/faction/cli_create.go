@@ -2,9 +2,13 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/voidshard/faction/pkg/client"
 	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
 )
 
 type cliCreateCmd struct {
@@ -13,16 +17,62 @@ type cliCreateCmd struct {
 	optCliGlobal
 
 	Files []string `short:"f" long:"file" description:"File(s) to read object(s) from"`
+
+	Bulk bool   `long:"bulk" description:"Stream newline delimited JSON objects from file(s) (default stdin), written in chunks"`
+	Kind string `long:"kind" description:"With --bulk, the kind of every object (default each object's _kind)"`
 }
 
 func (c *cliCreateCmd) Execute(args []string) error {
-	conn, err := client.New(client.NewConfig())
+	conn, err := client.New(c.config())
 	if err != nil {
 		return err
 	}
+	if c.Bulk {
+		return c.bulk(conn)
+	}
 	return applyYamlUpdate(conn, c.World, c.Files)
 }
 
+// bulk streams objects from each of our files (or stdin) to the server, reporting lines that failed
+func (c *cliCreateCmd) bulk(conn *client.Client) error {
+	if c.Kind != "" {
+		c.Kind = validKind(c.Kind)
+		if c.Kind == "" {
+			return fmt.Errorf("invalid object kind")
+		}
+	}
+	if len(c.Files) == 0 {
+		return c.bulkFrom(conn, "stdin", os.Stdin)
+	}
+	for _, name := range c.Files {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = c.bulkFrom(conn, name, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *cliCreateCmd) bulkFrom(conn *client.Client, name string, in io.Reader) error {
+	written, failed := 0, 0
+	err := conn.Bulk().World(c.World).Kind(c.Kind).Do(in, func(res *api.BulkResult) error {
+		if res.Error != nil {
+			failed++
+			fmt.Printf("%s line %d: %s\n", name, res.Line, res.Error.Message)
+		} else {
+			written++
+		}
+		return nil
+	})
+	fmt.Printf("%s: written %d, failed %d\n", name, written, failed)
+	return err
+}
+
 func applyYamlUpdate(conn *client.Client, world string, files []string) error {
 	toWrite, err := readObjectsFromFile(files)
 	if err != nil {
@@ -49,6 +99,10 @@ func applyYamlUpdate(conn *client.Client, world string, files []string) error {
 		}
 	}
 
-	return conn.Set(toWrite)
+	result, err := conn.Set(toWrite)
+	if result != nil && len(result.Conflicts) > 0 {
+		fmt.Printf("Not written, etag out of date: %s\n", strings.Join(result.Conflicts, ", "))
+	}
+	return err
 
 }
@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/voidshard/faction/pkg/client"
 	"github.com/voidshard/faction/pkg/kind"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
 )
 
 type cliDeleteCmd struct {
@@ -12,6 +14,9 @@ type cliDeleteCmd struct {
 	optGeneral
 	optCliGlobal
 
+	Wait       bool `long:"wait" description:"Wait for world deletion(s) to complete"`
+	Foreground bool `long:"foreground" description:"Delete dependents of the object(s) before returning"`
+
 	Object struct {
 		Kind string   `positional-arg-name:"object" description:"Object to get"`
 		Id   []string `positional-arg-name:"id" description:"ID of object to delete"`
@@ -27,10 +32,35 @@ func (c *cliDeleteCmd) Execute(args []string) error {
 		return fmt.Errorf("world must be set for non-global objects")
 	}
 
-	conn, err := client.New(client.NewConfig())
+	conn, err := client.New(c.config())
 	if err != nil {
 		return err
 	}
 
-	return conn.Delete(c.Object.Kind, c.World, c.Object.Id)
+	if c.Foreground {
+		err = conn.DeleteForeground(c.Object.Kind, c.World, c.Object.Id)
+	} else {
+		err = conn.Delete(c.Object.Kind, c.World, c.Object.Id)
+	}
+	if err != nil || c.Object.Kind != "world" {
+		return err
+	}
+
+	// worlds are deleted in the background
+	for _, id := range c.Object.Id {
+		for {
+			status, err := conn.WorldDeletion(id)
+			if err != nil {
+				return err
+			}
+			if status.Status == v1.WorldDeletionFailed {
+				return fmt.Errorf("failed to delete world %s: %s", id, status.Message)
+			} else if status.Status == v1.WorldDeletionComplete || !c.Wait {
+				fmt.Printf("world %s deletion %s\n", id, status.Status)
+				break
+			}
+			time.Sleep(time.Second)
+		}
+	}
+	return nil
 }
@@ -30,7 +30,7 @@ func (c *cliEditCmd) Execute(args []string) error {
 		return fmt.Errorf("world must be set for non-global objects")
 	}
 
-	conn, err := client.New(client.NewConfig())
+	conn, err := client.New(c.config())
 	if err != nil {
 		return err
 	}
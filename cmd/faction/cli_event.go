@@ -39,7 +39,7 @@ func (c *cliEventCmd) Execute(args []string) error {
 		return fmt.Errorf("world must be set for non-global objects")
 	}
 
-	conn, err := client.New(client.NewConfig())
+	conn, err := client.New(c.config())
 	if err != nil {
 		return err
 	}
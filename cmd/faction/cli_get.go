@@ -5,6 +5,7 @@ import (
 
 	"github.com/voidshard/faction/pkg/client"
 	"github.com/voidshard/faction/pkg/kind"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
 )
 
 type cliGetCmd struct {
@@ -18,8 +19,10 @@ type cliGetCmd struct {
 
 	Limit  int64 `long:"limit" default:"100" description:"Limit number of results"`
 	Offset int64 `short:"o" long:"offset" default:"0" description:"Offset results"`
+	All    bool  `short:"a" long:"all" description:"Get all results, fetching pages of --limit at a time"`
 
-	Labels map[string]string `short:"l" long:"labels" description:"Filter by labels"`
+	Labels   map[string]string `short:"l" long:"labels" description:"Filter by labels"`
+	Selector string            `short:"s" long:"selector" description:"Filter by label selector, eg. 'class in (noble,clergy),!exiled'"`
 }
 
 func (c *cliGetCmd) Execute(args []string) error {
@@ -32,12 +35,21 @@ func (c *cliGetCmd) Execute(args []string) error {
 		return fmt.Errorf("world must be set for non-global objects")
 	}
 
-	conn, err := client.New(client.NewConfig())
+	conn, err := client.New(c.config())
 	if err != nil {
 		return err
 	}
 
-	objs, err := conn.Get().Ids(args).Limit(c.Limit).Offset(c.Offset).Labels(c.Labels).World(c.World).Do(c.Object.Kind)
+	var objs []v1.Object
+	if c.All {
+		iter := conn.Get().Limit(c.Limit).Labels(c.Labels).Selector(c.Selector).World(c.World).Iter(c.Object.Kind)
+		for iter.Next() {
+			objs = append(objs, iter.Object())
+		}
+		err = iter.Err()
+	} else {
+		objs, err = conn.Get().Ids(args).Limit(c.Limit).Offset(c.Offset).Labels(c.Labels).Selector(c.Selector).World(c.World).Do(c.Object.Kind)
+	}
 	if err != nil {
 		return err
 	}
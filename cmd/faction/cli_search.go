@@ -38,7 +38,7 @@ func (c *cliSearchCmd) Execute(args []string) error {
 		return fmt.Errorf("world must be set for search operations")
 	}
 
-	conn, err := client.New(client.NewConfig())
+	conn, err := client.New(c.config())
 	if err != nil {
 		return err
 	}
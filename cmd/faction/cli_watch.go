@@ -16,16 +16,18 @@ type cliWatchCmd struct {
 
 	Id string `long:"id" short:"i" description:"ID of object(s) to watch" default:""`
 
+	Selector string `long:"selector" short:"s" description:"Label selector objects must match, eg. 'class in (noble,clergy)'" default:""`
+
 	Queue string `long:"queue" description:"Queue to watch for changes, if set queue is durable" default:""`
 }
 
 func (c *cliWatchCmd) Execute(args []string) error {
-	conn, err := client.New(client.NewConfig())
+	conn, err := client.New(c.config())
 	if err != nil {
 		return err
 	}
 
-	sub, err := conn.Watch().Kind(c.Kind).World(c.World).Id(c.Id).Queue(c.Queue).Do()
+	sub, err := conn.Watch().Kind(c.Kind).World(c.World).Id(c.Id).Selector(c.Selector).Queue(c.Queue).Do()
 	if err != nil {
 		return err
 	}
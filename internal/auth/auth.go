@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config configures authentication & the roles that callers may hold, ie.
+//
+//	tokens:
+//	  - token: "s3cret"
+//	    subject: ops
+//	    roles: [admin]
+//	jwt:
+//	  algorithm: HS256
+//	  secret: "signing-key"
+//	roles:
+//	  admin:
+//	    - worlds: ["*"]
+//	      kinds: ["*"]
+//	      verbs: ["*"]
+//	  narnia-reader:
+//	    - worlds: ["narnia"]
+//	      kinds: ["actor", "faction"]
+//	      verbs: ["get", "search", "watch"]
+type Config struct {
+	Tokens []*StaticToken     `yaml:"tokens"`
+	JWT    *JWTConfig         `yaml:"jwt"`
+	Roles  map[string][]*Rule `yaml:"roles"`
+}
+
+// Rule grants Verbs on Kinds within Worlds, where Any ("*") matches anything.
+//
+// Global kinds (ie. worlds) are not within a world, a Rule grants access to them
+// only if Worlds holds Any, or when acting on a world by id, that id.
+type Rule struct {
+	Worlds []string `yaml:"worlds"`
+	Kinds  []string `yaml:"kinds"`
+	Verbs  []string `yaml:"verbs"`
+}
+
+func (r *Rule) allows(verb, world, kind string) bool {
+	return matches(r.Verbs, verb) && matches(r.Worlds, world) && matches(r.Kinds, kind)
+}
+
+// matches returns if v is in the list, or the list holds Any
+func matches(list []string, v string) bool {
+	for _, i := range list {
+		if i == Any || (i == v && v != "") {
+			return true
+		}
+	}
+	return false
+}
+
+// Auth authenticates callers & decides what they're allowed to do.
+type Auth struct {
+	authenticators []Authenticator
+	roles          map[string][]*Rule
+}
+
+// Load reads a Config from a YAML file & returns the Auth it describes.
+func Load(path string) (*Auth, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	err = yaml.Unmarshal(data, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse auth config %s: %w", path, err)
+	}
+	return New(cfg)
+}
+
+func New(cfg *Config) (*Auth, error) {
+	me := &Auth{authenticators: []Authenticator{}, roles: cfg.Roles}
+	if me.roles == nil {
+		me.roles = map[string][]*Rule{}
+	}
+	if len(cfg.Tokens) > 0 {
+		me.authenticators = append(me.authenticators, NewStaticTokens(cfg.Tokens))
+	}
+	if cfg.JWT != nil {
+		j, err := NewJWT(cfg.JWT)
+		if err != nil {
+			return nil, err
+		}
+		me.authenticators = append(me.authenticators, j)
+	}
+	if len(me.authenticators) == 0 {
+		return nil, fmt.Errorf("auth requires tokens or jwt to be configured")
+	}
+	return me, nil
+}
+
+// Authenticate returns the Identity of the first Authenticator to accept the token.
+func (a *Auth) Authenticate(token string) (*Identity, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w no bearer token given", ErrUnauthenticated)
+	}
+	var err error
+	for _, authn := range a.authenticators {
+		var id *Identity
+		id, err = authn.Authenticate(token)
+		if err == nil {
+			return id, nil
+		} else if !errors.Is(err, ErrUnauthenticated) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// Authorize returns nil if one of the roles of the Identity allows the verb on the
+// kind within the world.
+func (a *Auth) Authorize(id *Identity, verb, world, kind string) error {
+	if id == nil {
+		return fmt.Errorf("%w no identity", ErrUnauthenticated)
+	}
+	for _, role := range id.Roles {
+		for _, rule := range a.roles[role] {
+			if rule.allows(verb, world, kind) {
+				return nil
+			}
+		}
+	}
+	if world == "" {
+		return fmt.Errorf("%w %s may not %s %s", ErrForbidden, id.Subject, verb, kind)
+	}
+	return fmt.Errorf("%w %s may not %s %s in world %s", ErrForbidden, id.Subject, verb, kind, world)
+}
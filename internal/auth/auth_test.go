@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signHS256(t *testing.T, secret, alg string, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	assert.Nil(t, err)
+	payload, err := json.Marshal(claims)
+	assert.Nil(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWT(t *testing.T) {
+	j, err := NewJWT(&JWTConfig{Secret: "key", Issuer: "us", Audience: "faction"})
+	assert.Nil(t, err)
+
+	exp := time.Now().Add(time.Hour).Unix()
+	cases := []struct {
+		Name   string
+		Token  string
+		Expect *Identity
+	}{
+		{
+			"valid",
+			signHS256(t, "key", AlgHS256, map[string]interface{}{"sub": "bob", "exp": exp, "iss": "us", "aud": []string{"faction"}, "roles": []string{"reader"}}),
+			&Identity{Subject: "bob", Roles: []string{"reader"}},
+		},
+		{
+			"roles as string",
+			signHS256(t, "key", AlgHS256, map[string]interface{}{"sub": "bob", "exp": exp, "iss": "us", "aud": "faction", "roles": "reader writer"}),
+			&Identity{Subject: "bob", Roles: []string{"reader", "writer"}},
+		},
+		{"wrong key", signHS256(t, "nope", AlgHS256, map[string]interface{}{"sub": "bob", "exp": exp, "iss": "us", "aud": "faction"}), nil},
+		{"alg none", signHS256(t, "key", "none", map[string]interface{}{"sub": "bob", "exp": exp, "iss": "us", "aud": "faction"}), nil},
+		{"expired", signHS256(t, "key", AlgHS256, map[string]interface{}{"sub": "bob", "exp": time.Now().Add(-time.Hour).Unix(), "iss": "us", "aud": "faction"}), nil},
+		{"no expiry", signHS256(t, "key", AlgHS256, map[string]interface{}{"sub": "bob", "iss": "us", "aud": "faction"}), nil},
+		{"wrong issuer", signHS256(t, "key", AlgHS256, map[string]interface{}{"sub": "bob", "exp": exp, "iss": "them", "aud": "faction"}), nil},
+		{"wrong audience", signHS256(t, "key", AlgHS256, map[string]interface{}{"sub": "bob", "exp": exp, "iss": "us", "aud": "other"}), nil},
+		{"malformed", "not.a-token", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			id, err := j.Authenticate(c.Token)
+			if c.Expect == nil {
+				assert.True(t, errors.Is(err, ErrUnauthenticated), err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, c.Expect, id)
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	a, err := New(&Config{
+		Tokens: []*StaticToken{
+			{Token: "admin-token", Subject: "admin", Roles: []string{"admin"}},
+			{Token: "reader-token", Subject: "reader", Roles: []string{"narnia-reader"}},
+		},
+		Roles: map[string][]*Rule{
+			"admin":         {{Worlds: []string{Any}, Kinds: []string{Any}, Verbs: []string{Any}}},
+			"narnia-reader": {{Worlds: []string{"narnia"}, Kinds: []string{"actor"}, Verbs: []string{VerbGet, VerbWatch}}},
+		},
+	})
+	assert.Nil(t, err)
+
+	_, err = a.Authenticate("guess")
+	assert.True(t, errors.Is(err, ErrUnauthenticated))
+
+	admin, err := a.Authenticate("admin-token")
+	assert.Nil(t, err)
+	reader, err := a.Authenticate("reader-token")
+	assert.Nil(t, err)
+
+	cases := []struct {
+		Id     *Identity
+		Verb   string
+		World  string
+		Kind   string
+		Expect error
+	}{
+		{admin, VerbDelete, "narnia", "actor", nil},
+		{admin, VerbGet, "", "world", nil},
+		{reader, VerbGet, "narnia", "actor", nil},
+		{reader, VerbWatch, "narnia", "actor", nil},
+		{reader, VerbSet, "narnia", "actor", ErrForbidden},
+		{reader, VerbGet, "narnia", "faction", ErrForbidden},
+		{reader, VerbGet, "archenland", "actor", ErrForbidden},
+		{reader, VerbGet, "", "world", ErrForbidden},
+		{reader, VerbGet, "narnia", Any, ErrForbidden},
+		{nil, VerbGet, "narnia", "actor", ErrUnauthenticated},
+	}
+
+	for _, c := range cases {
+		err := a.Authorize(c.Id, c.Verb, c.World, c.Kind)
+		if c.Expect == nil {
+			assert.Nil(t, err)
+		} else {
+			assert.True(t, errors.Is(err, c.Expect), err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// Verbs that rules grant, the API maps each of its operations to one of these
+	VerbGet    = "get"
+	VerbSet    = "set"
+	VerbDelete = "delete"
+	VerbSearch = "search"
+	VerbWatch  = "watch"
+	VerbDefer  = "defer"
+
+	// Any matches any world, kind or verb in a Rule
+	Any = "*"
+)
+
+var (
+	ErrUnauthenticated = fmt.Errorf("unauthenticated")
+	ErrForbidden       = fmt.Errorf("forbidden")
+)
+
+// Authenticator validates bearer tokens.
+type Authenticator interface {
+	// Authenticate returns who the token belongs to, or an error wrapping
+	// ErrUnauthenticated if the token is not valid.
+	Authenticate(token string) (*Identity, error)
+}
+
+// Identity is an authenticated caller.
+type Identity struct {
+	// Subject is who the caller is, ie. a user or service name
+	Subject string
+
+	// Roles held by the caller, which decide what they are allowed to do
+	Roles []string
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a context carrying the given Identity.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the Identity carried by the context, if any.
+func FromContext(ctx context.Context) *Identity {
+	id, _ := ctx.Value(identityKey{}).(*Identity)
+	return id
+}
@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+
+	// jwtLeeway allows for clock skew between us & the token issuer
+	jwtLeeway = 30 * time.Second
+
+	defaultRolesClaim = "roles"
+)
+
+// JWTConfig configures validation of signed JWTs, which are validated locally
+// (we never call out to the issuer).
+type JWTConfig struct {
+	// Algorithm the tokens are signed with, either HS256 (default) or RS256
+	Algorithm string `yaml:"algorithm"`
+
+	// Secret is the shared key for HS256
+	Secret string `yaml:"secret"`
+
+	// PublicKey is the path of a PEM encoded public key for RS256
+	PublicKey string `yaml:"publicKey"`
+
+	// Issuer & Audience, if set, must match the iss & aud claims
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+
+	// RolesClaim is the claim holding the caller's roles, "roles" by default
+	RolesClaim string `yaml:"rolesClaim"`
+}
+
+// JWT authenticates signed JSON web tokens.
+type JWT struct {
+	cfg    *JWTConfig
+	secret []byte
+	key    *rsa.PublicKey
+	now    func() time.Time
+}
+
+func NewJWT(cfg *JWTConfig) (*JWT, error) {
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = AlgHS256
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = defaultRolesClaim
+	}
+	me := &JWT{cfg: cfg, now: time.Now}
+
+	switch cfg.Algorithm {
+	case AlgHS256:
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("jwt secret required for %s", AlgHS256)
+		}
+		me.secret = []byte(cfg.Secret)
+	case AlgRS256:
+		data, err := os.ReadFile(cfg.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt public key: %w", err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("jwt public key %s is not PEM encoded", cfg.PublicKey)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse jwt public key: %w", err)
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt public key is not an RSA key")
+		}
+		me.key = rsaKey
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm %s", cfg.Algorithm)
+	}
+
+	return me, nil
+}
+
+func (j *JWT) Authenticate(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w malformed token", ErrUnauthenticated)
+	}
+
+	header := &struct {
+		Alg string `json:"alg"`
+	}{}
+	err := decodeSegment(parts[0], header)
+	if err != nil {
+		return nil, err
+	}
+	// nb. the algorithm is fixed by us, never by the token
+	if header.Alg != j.cfg.Algorithm {
+		return nil, fmt.Errorf("%w unexpected token algorithm %s", ErrUnauthenticated, header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w malformed token signature", ErrUnauthenticated)
+	}
+	err = j.verify(parts[0]+"."+parts[1], sig)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := map[string]interface{}{}
+	err = decodeSegment(parts[1], &claims)
+	if err != nil {
+		return nil, err
+	}
+	err = j.validClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, _ := claims["sub"].(string)
+	return &Identity{Subject: sub, Roles: stringsClaim(claims[j.cfg.RolesClaim])}, nil
+}
+
+func (j *JWT) verify(signed string, sig []byte) error {
+	switch j.cfg.Algorithm {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, j.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return fmt.Errorf("%w invalid token signature", ErrUnauthenticated)
+		}
+	case AlgRS256:
+		sum := sha256.Sum256([]byte(signed))
+		err := rsa.VerifyPKCS1v15(j.key, crypto.SHA256, sum[:], sig)
+		if err != nil {
+			return fmt.Errorf("%w invalid token signature", ErrUnauthenticated)
+		}
+	}
+	return nil
+}
+
+func (j *JWT) validClaims(claims map[string]interface{}) error {
+	now := j.now()
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("%w token has no expiry", ErrUnauthenticated)
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(jwtLeeway)) {
+		return fmt.Errorf("%w token expired", ErrUnauthenticated)
+	}
+	nbf, ok := claims["nbf"].(float64)
+	if ok && now.Add(jwtLeeway).Before(time.Unix(int64(nbf), 0)) {
+		return fmt.Errorf("%w token not yet valid", ErrUnauthenticated)
+	}
+
+	if j.cfg.Issuer != "" {
+		iss, _ := claims["iss"].(string)
+		if iss != j.cfg.Issuer {
+			return fmt.Errorf("%w unexpected token issuer", ErrUnauthenticated)
+		}
+	}
+	if j.cfg.Audience != "" {
+		found := false
+		for _, aud := range stringsClaim(claims["aud"]) {
+			if aud == j.cfg.Audience {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w unexpected token audience", ErrUnauthenticated)
+		}
+	}
+	return nil
+}
+
+// decodeSegment decodes a base64 JSON segment of a token
+func decodeSegment(seg string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return fmt.Errorf("%w malformed token", ErrUnauthenticated)
+	}
+	err = json.Unmarshal(data, out)
+	if err != nil {
+		return fmt.Errorf("%w malformed token", ErrUnauthenticated)
+	}
+	return nil
+}
+
+// stringsClaim reads a claim that is either a list of strings or a space delimited string
+// (as "scope" often is).
+func stringsClaim(v interface{}) []string {
+	switch x := v.(type) {
+	case string:
+		return strings.Fields(x)
+	case []interface{}:
+		out := []string{}
+		for _, i := range x {
+			s, ok := i.(string)
+			if ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return []string{}
+}
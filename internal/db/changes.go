@@ -0,0 +1,40 @@
+package db
+
+import (
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+const (
+	colChanges   = "changes"
+	colSequences = "sequences"
+)
+
+// changesKept is how many of the most recent changes of each world we keep for Changes
+var changesKept uint64 = 10000
+
+// change is an event logged by sequence
+type change struct {
+	Sequence uint64    `json:"_id"`
+	Event    *v1.Event `json:"Event"`
+}
+
+// sequence is the last sequence given out within a world, along with the highest sequence
+// whose change has since been forgotten.
+type sequence struct {
+	World   string `json:"_id"`
+	Last    uint64 `json:"Last"`
+	Trimmed uint64 `json:"Trimmed"`
+}
+
+// expired returns if changes after the given sequence can no longer all be returned
+func (s *sequence) expired(after uint64) bool {
+	return after < s.Trimmed || after > s.Last
+}
+
+// trimTo returns the highest sequence to forget, having logged a change at the given sequence
+func trimTo(seq uint64) uint64 {
+	if seq <= changesKept {
+		return 0
+	}
+	return seq - changesKept
+}
@@ -0,0 +1,612 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/selector"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+func newTestActor(world string, labels map[string]string) *v1.Actor {
+	return &v1.Actor{Meta: v1.Meta{Kind: "actor", World: world, Labels: labels}, Race: "human", Culture: "nord"}
+}
+
+// testSetEtags checks a Database honours the same etag & duplicate rules as Mongo
+func testSetEtags(t *testing.T, m Database) {
+	ctx := context.Background()
+
+	a := newTestActor("narnia", nil)
+	etag1 := uuid.New()
+	res, err := m.Set(ctx, "narnia", etag1, []v1.Object{a})
+	assert.Nil(t, err)
+	assert.Equal(t, etag1, a.GetEtag())
+	assert.Equal(t, map[string]string{a.GetId(): etag1}, res.Written)
+
+	// inserting the same object again is a duplicate
+	dupe := newTestActor("narnia", nil)
+	dupe.SetId(a.GetId())
+	_, err = m.Set(ctx, "narnia", uuid.New(), []v1.Object{dupe})
+	assert.ErrorIs(t, err, ErrDuplicate)
+	assert.Equal(t, "", dupe.GetEtag())
+
+	// updating with the current etag succeeds
+	a.Firstname = "Edmund"
+	etag2 := uuid.New()
+	_, err = m.Set(ctx, "narnia", etag2, []v1.Object{a})
+	assert.Nil(t, err)
+
+	// a retry of the same write (new etag) is accepted
+	a.SetEtag(etag1)
+	_, err = m.Set(ctx, "narnia", etag2, []v1.Object{a})
+	assert.Nil(t, err)
+
+	// a stale etag is rejected
+	a.SetEtag(etag1)
+	b := newTestActor("narnia", nil)
+	etag3 := uuid.New()
+	res, err = m.Set(ctx, "narnia", etag3, []v1.Object{a, b})
+	assert.ErrorIs(t, err, ErrEtagMismatch)
+	assert.Equal(t, []string{a.GetId()}, res.Conflicts)
+	assert.Equal(t, map[string]string{b.GetId(): etag3}, res.Written)
+
+	// only written objects hold the new etag
+	assert.Equal(t, etag1, a.GetEtag())
+	assert.Equal(t, etag3, b.GetEtag())
+
+	found := []*v1.Actor{}
+	err = m.Get(ctx, "narnia", "actor", []string{a.GetId()}, &found)
+	assert.Nil(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, "Edmund", found[0].Firstname)
+	assert.Equal(t, etag2, found[0].GetEtag())
+
+	// a delete with a stale etag is rejected, with the current etag it succeeds
+	err = m.Delete(ctx, "narnia", "actor", a.GetId(), etag1)
+	assert.ErrorIs(t, err, ErrEtagMismatch)
+	err = m.Delete(ctx, "narnia", "actor", a.GetId(), etag2)
+	assert.Nil(t, err)
+	err = m.Delete(ctx, "narnia", "actor", a.GetId(), etag2)
+	assert.ErrorIs(t, err, ErrEtagMismatch)
+
+	found = []*v1.Actor{}
+	err = m.Get(ctx, "narnia", "actor", []string{a.GetId()}, &found)
+	assert.Nil(t, err)
+	assert.Len(t, found, 0)
+}
+
+// testListWorlds checks a Database lists by labels, paginates & divides worlds
+func testListWorlds(t *testing.T, m Database) {
+	ctx := context.Background()
+
+	objects := []v1.Object{}
+	for i := 0; i < 5; i++ {
+		objects = append(objects, newTestActor("narnia", map[string]string{"class": "noble"}))
+	}
+	objects = append(objects, newTestActor("narnia", map[string]string{"class": "serf"}))
+	_, err := m.Set(ctx, "narnia", uuid.New(), objects)
+	assert.Nil(t, err)
+
+	_, err = m.Set(ctx, "oz", uuid.New(), []v1.Object{newTestActor("oz", map[string]string{"class": "noble"})})
+	assert.Nil(t, err)
+
+	found := []map[string]interface{}{}
+	err = m.List(ctx, "narnia", "actor", selector.FromLabels(map[string]string{"class": "noble"}), 0, 0, &found)
+	assert.Nil(t, err)
+	assert.Len(t, found, 5)
+
+	// deleting in one world doesn't affect another
+	err = m.Delete(ctx, "oz", "actor", objects[0].GetId(), "")
+	assert.Nil(t, err)
+
+	all := []map[string]interface{}{}
+	err = m.List(ctx, "narnia", "actor", nil, 100, 0, &all)
+	assert.Nil(t, err)
+	assert.Len(t, all, 6)
+
+	count, err := m.Count(ctx, "narnia", "actor")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), count)
+
+	page := []map[string]interface{}{}
+	err = m.List(ctx, "narnia", "actor", nil, 2, 1, &page)
+	assert.Nil(t, err)
+	assert.Len(t, page, 2)
+	assert.Equal(t, all[1]["_id"], page[0]["_id"])
+
+	// walking pages by the last id seen returns everything once, in order
+	walked := []interface{}{}
+	after := ""
+	for {
+		page = []map[string]interface{}{}
+		err = m.ListAfter(ctx, "narnia", "actor", nil, after, 4, &page)
+		assert.Nil(t, err)
+		for _, obj := range page {
+			walked = append(walked, obj["_id"])
+		}
+		if len(page) < 4 {
+			break
+		}
+		after = page[len(page)-1]["_id"].(string)
+	}
+	expect := []interface{}{}
+	for _, obj := range all {
+		expect = append(expect, obj["_id"])
+	}
+	assert.Equal(t, expect, walked)
+
+	page = []map[string]interface{}{}
+	err = m.ListAfter(ctx, "narnia", "actor", selector.FromLabels(map[string]string{"class": "serf"}), "", 10, &page)
+	assert.Nil(t, err)
+	assert.Len(t, page, 1)
+
+	// set based selectors
+	for in, expect := range map[string]int{
+		"class in (noble,serf)":    6,
+		"class notin (noble)":      1,
+		"class!=serf":              5,
+		"class":                    6,
+		"!class":                   0,
+		"class=noble,class!=noble": 0,
+	} {
+		sel, err := selector.Parse(in)
+		assert.Nil(t, err)
+
+		found = []map[string]interface{}{}
+		err = m.ListAfter(ctx, "narnia", "actor", sel, "", 10, &found)
+		assert.Nil(t, err)
+		assert.Len(t, found, expect, in)
+	}
+}
+
+// testTuples checks a Database computes tuples & modifiers as described in relation.go
+func testTuples(t *testing.T, m Database) {
+	ctx := context.Background()
+	r := RelationFactionFactionTrust
+
+	err := m.SetTuples(ctx, "narnia", r, []*v1.Tuple{
+		{Subject: "a", Object: "b", Value: 100},
+		{Subject: "a", Object: "c", Value: 9990},
+	})
+	assert.Nil(t, err)
+	err = m.SetTuples(ctx, "narnia", r, []*v1.Tuple{{Subject: "a", Object: "b", Value: 50}})
+	assert.Nil(t, err)
+
+	err = m.AddModifiers(ctx, "narnia", r, []*v1.Modifier{
+		{Subject: "a", Object: "b", Value: 10, Expires: 10},
+		{Subject: "a", Object: "b", Value: 20, Expires: 12},
+		{Subject: "a", Object: "c", Value: 100, Expires: 12},
+		{Subject: "a", Object: "d", Value: -5, Expires: 15},
+	})
+	assert.Nil(t, err)
+
+	cases := []struct {
+		Tick   uint64
+		Object string
+		Expect []*v1.Tuple
+	}{
+		{9, "b", []*v1.Tuple{{Subject: "a", Object: "b", Value: 80}}},
+		{10, "b", []*v1.Tuple{{Subject: "a", Object: "b", Value: 70}}},
+		{12, "b", []*v1.Tuple{{Subject: "a", Object: "b", Value: 50}}},
+		{11, "", []*v1.Tuple{
+			{Subject: "a", Object: "b", Value: 70},
+			{Subject: "a", Object: "c", Value: v1.MaxTuple},
+			{Subject: "a", Object: "d", Value: -5},
+		}},
+		{20, "", []*v1.Tuple{
+			{Subject: "a", Object: "b", Value: 50},
+			{Subject: "a", Object: "c", Value: 9990},
+		}},
+	}
+
+	for _, tt := range cases {
+		result, err := m.Tuples(ctx, "narnia", r, "a", tt.Object, tt.Tick)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.Expect, result, "tick %d object %s", tt.Tick, tt.Object)
+	}
+
+	// other worlds are unaffected
+	result, err := m.Tuples(ctx, "oz", r, "a", "", 0)
+	assert.Nil(t, err)
+	assert.Len(t, result, 0)
+}
+
+// testSetAll checks a Database writes objects of several kinds all-or-nothing
+func testSetAll(t *testing.T, m Database) {
+	ctx := context.Background()
+
+	f := &v1.Faction{Meta: v1.Meta{Kind: "faction", World: "narnia"}}
+	a := newTestActor("narnia", nil)
+	etag1 := uuid.New()
+	res, err := m.SetAll(ctx, "narnia", etag1, []v1.Object{f, a})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{f.GetId(): etag1, a.GetId(): etag1}, res.Written)
+
+	// a stale etag on one object means nothing is written
+	f.SetEtag(uuid.New())
+	a.Firstname = "Lucy"
+	b := newTestActor("narnia", nil)
+	res, err = m.SetAll(ctx, "narnia", uuid.New(), []v1.Object{a, b, f})
+	assert.ErrorIs(t, err, ErrEtagMismatch)
+	assert.Equal(t, []string{f.GetId()}, res.Conflicts)
+	assert.Len(t, res.Written, 0)
+	assert.Equal(t, etag1, a.GetEtag())
+	assert.Equal(t, "", b.GetEtag())
+
+	found := []*v1.Actor{}
+	err = m.Get(ctx, "narnia", "actor", []string{a.GetId(), b.GetId()}, &found)
+	assert.Nil(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, "", found[0].Firstname)
+	assert.Equal(t, etag1, found[0].GetEtag())
+
+	// as does a duplicate insert
+	dupe := newTestActor("narnia", nil)
+	dupe.SetId(a.GetId())
+	c := newTestActor("narnia", nil)
+	_, err = m.SetAll(ctx, "narnia", uuid.New(), []v1.Object{c, dupe})
+	assert.ErrorIs(t, err, ErrDuplicate)
+
+	found = []*v1.Actor{}
+	err = m.Get(ctx, "narnia", "actor", []string{c.GetId()}, &found)
+	assert.Nil(t, err)
+	assert.Len(t, found, 0)
+}
+
+// testDeleteWorld checks a Database removes all data in a world, and only that world
+func testDeleteWorld(t *testing.T, m Database) {
+	ctx := context.Background()
+
+	for _, world := range []string{"narnia", "oz"} {
+		_, err := m.Set(ctx, world, uuid.New(), []v1.Object{newTestActor(world, nil)})
+		assert.Nil(t, err)
+		err = m.SetTuples(ctx, world, RelationFactionFactionTrust, []*v1.Tuple{{Subject: "a", Object: "b", Value: 10}})
+		assert.Nil(t, err)
+		err = m.AddDeferredTick(ctx, world, 12)
+		assert.Nil(t, err)
+		err = m.AddDeferredTick(ctx, world, 3)
+		assert.Nil(t, err)
+	}
+
+	ticks, err := m.DeferredTicks(ctx, "narnia")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{3, 12}, ticks)
+
+	err = m.RemoveDeferredTick(ctx, "narnia", 3)
+	assert.Nil(t, err)
+	ticks, err = m.DeferredTicks(ctx, "narnia")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{12}, ticks)
+
+	err = m.DeleteWorld(ctx, "narnia")
+	assert.Nil(t, err)
+
+	for world, expect := range map[string]int{"narnia": 0, "oz": 1} {
+		found := []*v1.Actor{}
+		err = m.List(ctx, world, "actor", nil, 0, 0, &found)
+		assert.Nil(t, err)
+		assert.Len(t, found, expect)
+
+		tuples, err := m.Tuples(ctx, world, RelationFactionFactionTrust, "a", "", 0)
+		assert.Nil(t, err)
+		assert.Len(t, tuples, expect)
+	}
+
+	ticks, err = m.DeferredTicks(ctx, "narnia")
+	assert.Nil(t, err)
+	assert.Len(t, ticks, 0)
+	ticks, err = m.DeferredTicks(ctx, "oz")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{3, 12}, ticks)
+}
+
+// testRevisions checks a Database returns revisions most recent first, capped per object
+func testRevisions(t *testing.T, m Database) {
+	ctx := context.Background()
+
+	etags := []string{}
+	for i := 0; i < 4; i++ {
+		etag := uuid.New()
+		etags = append(etags, etag)
+		err := m.AddRevisions(ctx, "narnia", "actor", []*v1.Revision{
+			{Id: "a", Etag: etag, Tick: uint64(i), Object: map[string]interface{}{"Firstname": etag}},
+			{Id: "b", Etag: etag, Tick: uint64(i)},
+		}, 3)
+		assert.Nil(t, err)
+	}
+
+	// a retried write doesn't add a revision
+	err := m.AddRevisions(ctx, "narnia", "actor", []*v1.Revision{{Id: "a", Etag: etags[3], Tick: 3}}, 3)
+	assert.Nil(t, err)
+
+	revisions, err := m.Revisions(ctx, "narnia", "actor", "a")
+	assert.Nil(t, err)
+	found := []string{}
+	for _, rev := range revisions {
+		found = append(found, rev.Etag)
+	}
+	assert.Equal(t, []string{etags[3], etags[2]}, found[:2])
+	assert.NotContains(t, found, etags[0])
+	assert.Equal(t, etags[3], revisions[0].Object["Firstname"])
+	assert.Equal(t, uint64(3), revisions[0].Tick)
+
+	revisions, err = m.Revisions(ctx, "oz", "actor", "a")
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 0)
+}
+
+// testOutbox checks a Database records one event per change & hands each to one relay
+func testOutbox(t *testing.T, m Database) {
+	ctx := context.Background()
+
+	a := newTestActor("narnia", map[string]string{"class": "noble"})
+	b := newTestActor("narnia", nil)
+	_, err := m.Set(ctx, "narnia", uuid.New(), []v1.Object{a, b})
+	assert.Nil(t, err)
+
+	// a failed write records nothing
+	stale := newTestActor("narnia", nil)
+	stale.SetId(a.GetId())
+	stale.SetEtag(uuid.New())
+	_, err = m.Set(ctx, "narnia", uuid.New(), []v1.Object{stale})
+	assert.ErrorIs(t, err, ErrEtagMismatch)
+
+	// nor does a duplicate insert, though what else was written is recorded
+	dupe := newTestActor("narnia", nil)
+	dupe.SetId(a.GetId())
+	c := newTestActor("narnia", nil)
+	res, err := m.Set(ctx, "narnia", uuid.New(), []v1.Object{dupe, c})
+	assert.ErrorIs(t, err, ErrDuplicate)
+	if assert.NotNil(t, res) {
+		assert.Len(t, res.Written, 1)
+		assert.Contains(t, res.Written, c.GetId())
+		assert.Len(t, res.Conflicts, 0)
+	}
+
+	claimed, err := m.ClaimEvents(ctx, "relay1", time.Minute, 10)
+	assert.Nil(t, err)
+	assert.Len(t, claimed, 3)
+	ids := []string{}
+	outbox := []string{}
+	for _, evt := range claimed {
+		assert.Equal(t, "narnia", evt.Event.World)
+		assert.Equal(t, "actor", evt.Event.Kind)
+		ids = append(ids, evt.Event.Id)
+		outbox = append(outbox, evt.Id)
+	}
+	assert.ElementsMatch(t, []string{a.GetId(), b.GetId(), c.GetId()}, ids)
+
+	// events are leased to the first relay
+	other, err := m.ClaimEvents(ctx, "relay2", time.Minute, 10)
+	assert.Nil(t, err)
+	assert.Len(t, other, 0)
+
+	err = m.RemoveEvents(ctx, outbox)
+	assert.Nil(t, err)
+
+	err = m.Delete(ctx, "narnia", "actor", a.GetId(), "")
+	assert.Nil(t, err)
+	err = m.AddEvents(ctx, []*v1.Event{{World: "narnia", Kind: "world", Id: "narnia"}})
+	assert.Nil(t, err)
+
+	claimed, err = m.ClaimEvents(ctx, "relay2", time.Minute, 10)
+	assert.Nil(t, err)
+	assert.Len(t, claimed, 2)
+	assert.Equal(t, a.GetId(), claimed[0].Event.Id)
+	assert.Equal(t, map[string]string{"class": "noble"}, claimed[0].Event.Labels)
+	assert.True(t, claimed[0].Event.Deleted)
+	assert.Equal(t, "narnia", claimed[1].Event.Id)
+	assert.False(t, claimed[1].Event.Deleted)
+}
+
+// testCopyRelations checks a Database copies tuples & modifiers into another world
+func testCopyRelations(t *testing.T, m Database) {
+	ctx := context.Background()
+	r := RelationPersonPersonTrust
+
+	err := m.SetTuples(ctx, "narnia", r, []*v1.Tuple{{Subject: "a", Object: "b", Value: 100}})
+	assert.Nil(t, err)
+	err = m.AddModifiers(ctx, "narnia", r, []*v1.Modifier{{Subject: "a", Object: "b", Value: 10, Expires: 10}})
+	assert.Nil(t, err)
+	err = m.SetTuples(ctx, "oz", r, []*v1.Tuple{{Subject: "a", Object: "b", Value: -100}})
+	assert.Nil(t, err)
+
+	err = m.CopyRelations(ctx, "narnia", "oz")
+	assert.Nil(t, err)
+
+	for _, world := range []string{"narnia", "oz"} {
+		result, err := m.Tuples(ctx, world, r, "a", "", 5)
+		assert.Nil(t, err)
+		assert.Equal(t, []*v1.Tuple{{Subject: "a", Object: "b", Value: 110}}, result, world)
+	}
+
+	// the worlds are independent after the copy
+	err = m.SetTuples(ctx, "oz", r, []*v1.Tuple{{Subject: "a", Object: "b", Value: 0}})
+	assert.Nil(t, err)
+	result, err := m.Tuples(ctx, "narnia", r, "a", "", 20)
+	assert.Nil(t, err)
+	assert.Equal(t, []*v1.Tuple{{Subject: "a", Object: "b", Value: 100}}, result)
+}
+
+// testDeferredEvents checks a Database returns deferred events after a tick, forgetting them with their tick
+func testDeferredEvents(t *testing.T, m Database) {
+	ctx := context.Background()
+
+	for _, tick := range []uint64{12, 3, 7} {
+		err := m.AddDeferredTick(ctx, "narnia", tick)
+		assert.Nil(t, err)
+		err = m.AddDeferredEvent(ctx, "narnia", tick, &v1.Event{World: "narnia", Kind: "actor", Id: uuid.New()})
+		assert.Nil(t, err)
+	}
+
+	found, err := m.DeferredEvents(ctx, "narnia", 3)
+	assert.Nil(t, err)
+	assert.Len(t, found, 2)
+	for i, tick := range []uint64{7, 12} {
+		assert.Equal(t, tick, found[i].Tick)
+		assert.Equal(t, "actor", found[i].Event.Kind)
+	}
+
+	err = m.RemoveDeferredTick(ctx, "narnia", 7)
+	assert.Nil(t, err)
+	found, err = m.DeferredEvents(ctx, "narnia", 0)
+	assert.Nil(t, err)
+	assert.Len(t, found, 2)
+
+	found, err = m.DeferredEvents(ctx, "oz", 0)
+	assert.Nil(t, err)
+	assert.Len(t, found, 0)
+
+	count, err := m.CountDeferredEvents(ctx, "narnia", 12)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+	count, err = m.CountDeferredEvents(ctx, "narnia", 7)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+// testOwned checks a Database finds objects by their owners
+func testOwned(t *testing.T, m Database) {
+	ctx := context.Background()
+
+	f := &v1.Faction{Meta: v1.Meta{Kind: "faction", World: "narnia"}}
+	_, err := m.Set(ctx, "narnia", uuid.New(), []v1.Object{f})
+	assert.Nil(t, err)
+	owner := &v1.Owner{Kind: "faction", Id: f.GetId()}
+
+	owned := []v1.Object{}
+	for i := 0; i < 3; i++ {
+		a := newTestActor("narnia", nil)
+		a.Owners = []*v1.Owner{{Kind: "faction", Id: uuid.New()}, owner}
+		owned = append(owned, a)
+	}
+	_, err = m.Set(ctx, "narnia", uuid.New(), append(owned, newTestActor("narnia", nil)))
+	assert.Nil(t, err)
+
+	found := []*v1.Actor{}
+	err = m.Owned(ctx, "narnia", "actor", owner, "", 2, &found)
+	assert.Nil(t, err)
+	assert.Len(t, found, 2)
+
+	rest := []*v1.Actor{}
+	err = m.Owned(ctx, "narnia", "actor", owner, found[1].Id, 0, &rest)
+	assert.Nil(t, err)
+	assert.Len(t, rest, 1)
+
+	ids := []string{}
+	for _, a := range append(found, rest...) {
+		ids = append(ids, a.Id)
+	}
+	assert.ElementsMatch(t, []string{owned[0].GetId(), owned[1].GetId(), owned[2].GetId()}, ids)
+
+	// the kind of the owner matters
+	none := []*v1.Actor{}
+	err = m.Owned(ctx, "narnia", "actor", &v1.Owner{Kind: "actor", Id: f.GetId()}, "", 0, &none)
+	assert.Nil(t, err)
+	assert.Len(t, none, 0)
+}
+
+// testChanges checks a Database stamps sequences on writes & replays recent changes from them
+func testChanges(t *testing.T, m Database) {
+	ctx := context.Background()
+	kept := changesKept
+	changesKept = 3
+	defer func() { changesKept = kept }()
+
+	seq, err := m.Sequence(ctx, "narnia")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), seq)
+	found, err := m.Changes(ctx, "narnia", 0, 0)
+	assert.Nil(t, err)
+	assert.Len(t, found, 0)
+
+	a := newTestActor("narnia", nil)
+	b := newTestActor("narnia", nil)
+	_, err = m.Set(ctx, "narnia", uuid.New(), []v1.Object{a, b})
+	assert.Nil(t, err)
+	assert.Less(t, a.GetSequence(), b.GetSequence())
+
+	stored := []*v1.Actor{}
+	err = m.Get(ctx, "narnia", "actor", []string{b.GetId()}, &stored)
+	assert.Nil(t, err)
+	assert.Equal(t, b.GetSequence(), stored[0].GetSequence())
+
+	seq, err = m.Sequence(ctx, "narnia")
+	assert.Nil(t, err)
+	assert.Equal(t, b.GetSequence(), seq)
+
+	// changes after the given sequence are returned in order, for their world only
+	_, err = m.Set(ctx, "oz", uuid.New(), []v1.Object{newTestActor("oz", nil)})
+	assert.Nil(t, err)
+	err = m.Delete(ctx, "narnia", "actor", a.GetId(), "")
+	assert.Nil(t, err)
+
+	found, err = m.Changes(ctx, "narnia", a.GetSequence(), 0)
+	assert.Nil(t, err)
+	assert.Len(t, found, 2)
+	assert.Equal(t, b.GetId(), found[0].Id)
+	assert.Equal(t, b.GetSequence(), found[0].Sequence)
+	assert.Equal(t, a.GetId(), found[1].Id)
+	assert.True(t, found[1].Deleted)
+	assert.Greater(t, found[1].Sequence, b.GetSequence())
+
+	found, err = m.Changes(ctx, "narnia", 0, 1)
+	assert.Nil(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, a.GetId(), found[0].Id)
+
+	// sequences ahead of the world are expired
+	_, err = m.Changes(ctx, "narnia", found[0].Sequence+10, 0)
+	assert.ErrorIs(t, err, ErrExpired)
+
+	// as are those whose changes have been forgotten
+	err = m.AddEvents(ctx, []*v1.Event{{World: "narnia", Kind: "world", Id: "narnia"}})
+	assert.Nil(t, err)
+	_, err = m.Changes(ctx, "narnia", 0, 0)
+	assert.ErrorIs(t, err, ErrExpired)
+	found, err = m.Changes(ctx, "narnia", a.GetSequence(), 0)
+	assert.Nil(t, err)
+	assert.Len(t, found, 3)
+	assert.Equal(t, "narnia", found[2].Id)
+
+	err = m.DeleteWorld(ctx, "narnia")
+	assert.Nil(t, err)
+	seq, err = m.Sequence(ctx, "narnia")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), seq)
+	seq, err = m.Sequence(ctx, "oz")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), seq)
+}
+
+// testWatchers checks a Database counts unexpired event stream leases by world
+func testWatchers(t *testing.T, m Database) {
+	ctx := context.Background()
+
+	assert.Nil(t, m.SetWatcher(ctx, "narnia", "a", time.Minute))
+	assert.Nil(t, m.SetWatcher(ctx, "narnia", "b", time.Minute))
+	assert.Nil(t, m.SetWatcher(ctx, "narnia", "c", time.Nanosecond))
+	assert.Nil(t, m.SetWatcher(ctx, "", "d", time.Minute))
+	time.Sleep(time.Millisecond)
+
+	count, err := m.CountWatchers(ctx, "narnia")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	count, err = m.CountWatchers(ctx, "")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// a renewed lease holds, a released one does not
+	assert.Nil(t, m.SetWatcher(ctx, "narnia", "c", time.Minute))
+	assert.Nil(t, m.RemoveWatcher(ctx, "a"))
+	count, err = m.CountWatchers(ctx, "narnia")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	count, err = m.CountWatchers(ctx, "oz")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+}
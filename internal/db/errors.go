@@ -9,4 +9,5 @@ var (
 	ErrDuplicate    = fmt.Errorf("duplicate object")
 	ErrNotFound     = fmt.Errorf("not found")
 	ErrInvalid      = fmt.Errorf("object invalid")
+	ErrExpired      = fmt.Errorf("sequence expired")
 )
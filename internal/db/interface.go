@@ -2,19 +2,134 @@ package db
 
 import (
 	"context"
+	"time"
 
 	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/selector"
 )
 
 type Database interface {
 	Get(c context.Context, world, kind string, id []string, out interface{}) error
-	List(c context.Context, world, kind string, labels map[string]string, limit, offset int64, out interface{}) error
+	List(c context.Context, world, kind string, labels selector.Selector, limit, offset int64, out interface{}) error
+
+	// ListAfter lists objects as List does, but rather than an offset it returns objects with an
+	// ID greater than `after` (ie. the last ID of the previous page).
+	ListAfter(c context.Context, world, kind string, labels selector.Selector, after string, limit int64, out interface{}) error
+
+	// Owned lists objects of a kind within a world that list the given owner among their
+	// Owners, with an ID greater than `after` (as ListAfter).
+	Owned(c context.Context, world, kind string, owner *v1.Owner, after string, limit int64, out interface{}) error
+
+	// Count returns the number of objects of a kind within a world.
+	Count(c context.Context, world, kind string) (int64, error)
+
+	// Set, SetAll & Delete record an event in the outbox for each object they change
+	// along with the change itself (see ClaimEvents).
+	//
+	// Each change is given the next sequence of its world, which is set on written objects
+	// & their events (see Sequence).
+	//
+	// Set writes everything that it can. If some objects could not be written it returns
+	// ErrDuplicate (if any object to be created already exists) or ErrEtagMismatch along with
+	// a Result of those that were written & the IDs of updates that conflicted.
 	Set(c context.Context, world, etag string, in []v1.Object) (*Result, error)
-	Delete(c context.Context, world, kind string, id string) error
+
+	// Delete removes an object, if an etag is given the object is only removed if it is at
+	// that Etag (otherwise ErrEtagMismatch). Deleting an object that doesn't exist is not an
+	// error unless an etag is given.
+	Delete(c context.Context, world, kind, id, etag string) error
+
+	// SetAll writes objects of any number of kinds within a world as one transaction.
+	// Either every object is written or none are, in which case the Result lists the
+	// conflicting IDs (if any).
+	SetAll(c context.Context, world, etag string, in []v1.Object) (*Result, error)
+
+	// SetTuples writes the given tuples of a relation, replacing any existing value(s).
+	SetTuples(c context.Context, world string, r Relation, in []*v1.Tuple) error
+
+	// AddModifiers adds modifiers to tuples of a relation, these apply until their Expires tick.
+	AddModifiers(c context.Context, world string, r Relation, in []*v1.Modifier) error
+
+	// Tuples returns the computed values of a relation for the subject at the given tick.
+	// If object is set only the tuple for (subject, object) is returned.
+	Tuples(c context.Context, world string, r Relation, subject, object string, tick uint64) ([]*v1.Tuple, error)
+
+	// AddRevisions records revisions of objects of a kind, keeping at most `keep` (most recent)
+	// revisions of each object.
+	AddRevisions(c context.Context, world, kind string, in []*v1.Revision, keep int) error
+
+	// Revisions returns the recorded revisions of an object, most recent first.
+	Revisions(c context.Context, world, kind, id string) ([]*v1.Revision, error)
+
+	// DeleteWorld removes all data held within the world (objects, tuples, deferred ticks).
+	// nb. the world object itself is global and is not removed.
+	DeleteWorld(c context.Context, world string) error
+
+	// AddDeferredTick records that events have been deferred to the given tick of a world.
+	AddDeferredTick(c context.Context, world string, tick uint64) error
+
+	// RemoveDeferredTick forgets a tick recorded by AddDeferredTick along with any events
+	// recorded for it by AddDeferredEvent.
+	RemoveDeferredTick(c context.Context, world string, tick uint64) error
+
+	// DeferredTicks returns the recorded ticks of a world, in ascending order.
+	DeferredTicks(c context.Context, world string) ([]uint64, error)
+
+	// AddDeferredEvent records an event deferred to the given tick of a world, so that pending
+	// events can be found again (eg. when forking a world).
+	AddDeferredEvent(c context.Context, world string, tick uint64, evt *v1.Event) error
+
+	// DeferredEvents returns the recorded events of a world deferred to ticks after the given
+	// tick, in ascending order of tick.
+	DeferredEvents(c context.Context, world string, after uint64) ([]*DeferredEvent, error)
+
+	// CountDeferredEvents returns the number of events recorded for the given tick of a world.
+	CountDeferredEvents(c context.Context, world string, tick uint64) (int64, error)
+
+	// CopyRelations copies the tuples & modifiers of every relation from one world into another.
+	CopyRelations(c context.Context, from, to string) error
+
+	// Sequence returns the sequence of the last change made within a world.
+	Sequence(c context.Context, world string) (uint64, error)
+
+	// Changes returns up to `limit` events for changes within a world with a sequence greater
+	// than `after`, in ascending order of sequence. Only recent changes are kept (see
+	// changesKept), if changes after the given sequence have been forgotten (or the
+	// sequence is ahead of the world) we return ErrExpired.
+	Changes(c context.Context, world string, after uint64, limit int64) ([]*v1.Event, error)
+
+	// AddEvents records events in the outbox for changes not made via Set, SetAll or Delete.
+	AddEvents(c context.Context, in []*v1.Event) error
+
+	// ClaimEvents returns up to `limit` events from the outbox (oldest first) for the owner to
+	// publish, no other owner may claim them until the lease expires.
+	ClaimEvents(c context.Context, owner string, lease time.Duration, limit int64) ([]*OutboxEvent, error)
+
+	// RemoveEvents removes published events from the outbox.
+	RemoveEvents(c context.Context, id []string) error
+
+	// SetWatcher records (or renews) the lease of an event stream watching a world, so that
+	// streams can be counted across API servers. Streams watching no world are recorded under
+	// the world "". The lease expires unless renewed, in case the stream's server dies.
+	SetWatcher(c context.Context, world, id string, lease time.Duration) error
+
+	// RemoveWatcher releases the lease of an event stream (see SetWatcher).
+	RemoveWatcher(c context.Context, id string) error
+
+	// CountWatchers returns the number of event streams watching a world whose leases have
+	// not expired.
+	CountWatchers(c context.Context, world string) (int64, error)
 
 	Close()
 }
 
+// DeferredEvent is an event waiting in the queue for the given tick of its world.
+type DeferredEvent struct {
+	Id    string    `json:"_id"`
+	Tick  uint64    `json:"Tick"`
+	Event *v1.Event `json:"Event"`
+}
+
 // Result returns information about a batch write operation.
 //
 // On batch writes we use optimistic locking with our Etags. So rows are only written if the Etag
@@ -23,11 +138,15 @@ type Database interface {
 type Result struct {
 	// Written maps ID -> new Etag of written rows
 	Written map[string]string
+
+	// Conflicts lists IDs of rows that were not written because their Etag did not match
+	Conflicts []string
 }
 
 func NewResult() *Result {
 	return &Result{
-		Written: map[string]string{},
+		Written:   map[string]string{},
+		Conflicts: []string{},
 	}
 }
 
@@ -35,4 +154,5 @@ func (r *Result) Merge(other *Result) {
 	for k, v := range other.Written {
 		r.Written[k] = v
 	}
+	r.Conflicts = append(r.Conflicts, other.Conflicts...)
 }
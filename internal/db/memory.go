@@ -0,0 +1,789 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+	"github.com/voidshard/faction/pkg/util/selector"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+// Memory is an in-memory Database, intended for tests & local development.
+//
+// It aims to behave as Mongo does; objects are namespaced by world_collection, writes use the
+// same Etag rules as prepareSet and objects are stored as JSON so that reads decode in the
+// same way.
+type Memory struct {
+	log log.Logger
+
+	lock sync.RWMutex
+
+	// collection -> id -> document
+	data map[string]map[string]*memoryDoc
+
+	// collection -> subject -> object -> tuple
+	tuples map[string]map[string]map[string]*v1.Tuple
+
+	// collection -> modifiers
+	modifiers map[string][]*v1.Modifier
+
+	// collection -> ticks with deferred events
+	deferred map[string]map[uint64]bool
+
+	// collection -> events deferred to ticks
+	deferredEvents map[string][]*DeferredEvent
+
+	// collection -> id -> revisions (oldest first)
+	history map[string]map[string][]*v1.Revision
+
+	// id -> events waiting to be published
+	outbox map[string]*OutboxEvent
+
+	// world -> sequence
+	sequences map[string]*sequence
+
+	// world -> recent changes (ascending sequence)
+	changes map[string][]*v1.Event
+
+	// id -> leases of event streams
+	watchers map[string]*watcher
+}
+
+// memoryDoc is a stored object along with the fields we need to filter on.
+type memoryDoc struct {
+	Id         string            `json:"_id"`
+	Etag       string            `json:"_etag"`
+	Controller string            `json:"_controller"`
+	Labels     map[string]string `json:"Labels"`
+	Owners     []*v1.Owner       `json:"Owners"`
+
+	raw []byte
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		log:       log.Sublogger("memory"),
+		lock:      sync.RWMutex{},
+		data:      map[string]map[string]*memoryDoc{},
+		tuples:    map[string]map[string]map[string]*v1.Tuple{},
+		modifiers: map[string][]*v1.Modifier{},
+		deferred:  map[string]map[uint64]bool{},
+		history:   map[string]map[string][]*v1.Revision{},
+		outbox:    map[string]*OutboxEvent{},
+		sequences: map[string]*sequence{},
+		changes:   map[string][]*v1.Event{},
+		watchers:  map[string]*watcher{},
+
+		deferredEvents: map[string][]*DeferredEvent{},
+	}
+}
+
+func (m *Memory) Get(c context.Context, world, kind string, id []string, out interface{}) error {
+	pan := log.NewSpan(c, "db.Find", map[string]interface{}{"world": world, "kind": kind, "ids": len(id)})
+	defer pan.End()
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	col := m.data[world_collection(world, kind)]
+	found := []*memoryDoc{}
+	for _, i := range id {
+		doc, ok := col[i]
+		if ok {
+			found = append(found, doc)
+		}
+	}
+	sortDocs(found)
+
+	return decodeDocs(found, out)
+}
+
+func (m *Memory) List(c context.Context, world, kind string, labels selector.Selector, limit, offset int64, out interface{}) error {
+	pan := log.NewSpan(c, "db.Find", map[string]interface{}{"world": world, "kind": kind, "labels": len(labels), "limit": limit, "offset": offset})
+	defer pan.End()
+
+	return pan.Err(m.list(world, kind, labels, "", limit, offset, out))
+}
+
+func (m *Memory) ListAfter(c context.Context, world, kind string, labels selector.Selector, after string, limit int64, out interface{}) error {
+	pan := log.NewSpan(c, "db.Find", map[string]interface{}{"world": world, "kind": kind, "labels": len(labels), "limit": limit, "after": after})
+	defer pan.End()
+	return pan.Err(m.list(world, kind, labels, after, limit, 0, out))
+}
+
+func (m *Memory) Owned(c context.Context, world, kind string, owner *v1.Owner, after string, limit int64, out interface{}) error {
+	pan := log.NewSpan(c, "db.Owned", map[string]interface{}{"world": world, "kind": kind, "owner": owner.Id, "limit": limit, "after": after})
+	defer pan.End()
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	found := []*memoryDoc{}
+	for _, doc := range m.data[world_collection(world, kind)] {
+		if after != "" && doc.Id <= after {
+			continue
+		}
+		for _, o := range doc.Owners {
+			if o.Kind == owner.Kind && o.Id == owner.Id {
+				found = append(found, doc)
+				break
+			}
+		}
+	}
+	sortDocs(found)
+	if limit > 0 && limit < int64(len(found)) {
+		found = found[:limit]
+	}
+	return pan.Err(decodeDocs(found, out))
+}
+
+func (m *Memory) Count(c context.Context, world, kind string) (int64, error) {
+	pan := log.NewSpan(c, "db.Count", map[string]interface{}{"world": world, "kind": kind})
+	defer pan.End()
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return int64(len(m.data[world_collection(world, kind)])), nil
+}
+
+// list returns objects matching the labels with an Id greater than `after` (if given)
+func (m *Memory) list(world, kind string, labels selector.Selector, after string, limit, offset int64, out interface{}) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	found := []*memoryDoc{}
+	for _, doc := range m.data[world_collection(world, kind)] {
+		if after != "" && doc.Id <= after {
+			continue
+		}
+		if labels.Matches(doc.Labels) {
+			found = append(found, doc)
+		}
+	}
+	sortDocs(found)
+
+	// nb. as with Mongo a limit of 0 implies no limit
+	if offset > int64(len(found)) {
+		offset = int64(len(found))
+	}
+	found = found[offset:]
+	if limit > 0 && limit < int64(len(found)) {
+		found = found[:limit]
+	}
+
+	return decodeDocs(found, out)
+}
+
+func (m *Memory) Delete(c context.Context, world, kind, id, etag string) error {
+	pan := log.NewSpan(c, "db.Delete", map[string]interface{}{"world": world, "kind": kind, "id": id, "etag": etag})
+	defer pan.End()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	col := m.data[world_collection(world, kind)]
+	doc, ok := col[id]
+	if etag != "" && (!ok || doc.Etag != etag) {
+		return pan.Err(ErrEtagMismatch)
+	}
+	if ok {
+		delete(col, id)
+		m.addEvent(deletedEvent(world, kind, &eventMeta{Id: doc.Id, Sequence: m.nextSequence(world), Controller: doc.Controller, Labels: doc.Labels}, true))
+	}
+	return nil
+}
+
+func (m *Memory) Set(c context.Context, world, etag string, in []v1.Object) (*Result, error) {
+	pan := log.NewSpan(c, "db.Set", map[string]interface{}{"world": world, "etag": etag, "count": len(in)})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil, fmt.Errorf("%w no objects to set", ErrInvalid)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	name := world_collection(world, in[0].GetKind())
+	col, ok := m.data[name]
+	if !ok {
+		col = map[string]*memoryDoc{}
+		m.data[name] = col
+	}
+
+	// As with Mongo's unordered bulk writes, we write everything that we can and
+	// report a failure if any of the objects could not be written.
+	res := NewResult()
+	duplicate := false
+	for _, v := range in {
+		isCreate := v.GetEtag() == ""
+		if isCreate && v.GetId() == "" {
+			v.SetId(uuid.New())
+		}
+
+		existing, exists := col[v.GetId()]
+		var write bool
+		if isCreate {
+			write = !exists
+			duplicate = duplicate || exists
+		} else {
+			// update if the ID and either the new or the old etag match (see prepareSet)
+			write = exists && (existing.Etag == v.GetEtag() || existing.Etag == etag)
+		}
+		if !write {
+			if !isCreate {
+				res.Conflicts = append(res.Conflicts, v.GetId())
+			}
+			continue
+		}
+
+		v.SetEtag(etag) // nb. only objects we write hold the new etag
+		v.SetSequence(m.nextSequence(world))
+		doc, err := encodeDoc(v)
+		if err != nil {
+			return nil, err
+		}
+		col[doc.Id] = doc
+		res.Written[doc.Id] = etag
+		m.addEvent(objectEvent(world, v, true))
+	}
+
+	m.log.Debug().Str("collection", name).Int("count", len(in)).Int("written", len(res.Written)).Msg("setObjects")
+	if duplicate {
+		return res, ErrDuplicate
+	}
+	if len(res.Written) == len(in) {
+		return res, nil
+	}
+	return res, ErrEtagMismatch
+}
+
+func (m *Memory) SetAll(c context.Context, world, etag string, in []v1.Object) (*Result, error) {
+	pan := log.NewSpan(c, "db.SetAll", map[string]interface{}{"world": world, "etag": etag, "count": len(in)})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil, fmt.Errorf("%w no objects to set", ErrInvalid)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// check everything can be written before we write anything
+	conflicts := []string{}
+	seen := map[string]bool{}
+	for _, v := range in {
+		name := world_collection(world, v.GetKind())
+		isCreate := v.GetEtag() == ""
+		if isCreate && v.GetId() == "" {
+			v.SetId(uuid.New())
+		}
+
+		key := fmt.Sprintf("%s/%s", name, v.GetId())
+		existing, exists := m.data[name][v.GetId()]
+		if isCreate && (exists || seen[key]) {
+			return nil, ErrDuplicate
+		} else if !isCreate && !(exists && (existing.Etag == v.GetEtag() || existing.Etag == etag)) {
+			conflicts = append(conflicts, v.GetId())
+		}
+		seen[key] = true
+	}
+	if len(conflicts) > 0 {
+		res := NewResult()
+		res.Conflicts = conflicts
+		return res, ErrEtagMismatch
+	}
+
+	docs := map[string][]*memoryDoc{}
+	events := []*OutboxEvent{}
+	for _, v := range in {
+		v.SetEtag(etag)
+		v.SetSequence(m.nextSequence(world))
+		doc, err := encodeDoc(v)
+		if err != nil {
+			return nil, pan.Err(err)
+		}
+		name := world_collection(world, v.GetKind())
+		docs[name] = append(docs[name], doc)
+		events = append(events, objectEvent(world, v, true))
+	}
+
+	res := NewResult()
+	for name, written := range docs {
+		col, ok := m.data[name]
+		if !ok {
+			col = map[string]*memoryDoc{}
+			m.data[name] = col
+		}
+		for _, doc := range written {
+			col[doc.Id] = doc
+			res.Written[doc.Id] = etag
+		}
+	}
+	for _, evt := range events {
+		m.addEvent(evt)
+	}
+
+	m.log.Debug().Str("world", world).Int("count", len(in)).Msg("setAll")
+	return res, nil
+}
+
+func (m *Memory) SetTuples(c context.Context, world string, r Relation, in []*v1.Tuple) error {
+	pan := log.NewSpan(c, "db.SetTuples", map[string]interface{}{"world": world, "relation": string(r), "count": len(in)})
+	defer pan.End()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	name := r.tuples(world)
+	col, ok := m.tuples[name]
+	if !ok {
+		col = map[string]map[string]*v1.Tuple{}
+		m.tuples[name] = col
+	}
+	for _, t := range in {
+		bySubject, ok := col[t.Subject]
+		if !ok {
+			bySubject = map[string]*v1.Tuple{}
+			col[t.Subject] = bySubject
+		}
+		bySubject[t.Object] = &v1.Tuple{Subject: t.Subject, Object: t.Object, Value: clampTuple(t.Value)}
+	}
+	return nil
+}
+
+func (m *Memory) AddModifiers(c context.Context, world string, r Relation, in []*v1.Modifier) error {
+	pan := log.NewSpan(c, "db.AddModifiers", map[string]interface{}{"world": world, "relation": string(r), "count": len(in)})
+	defer pan.End()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	name := r.modifiers(world)
+	for _, mod := range in {
+		cp := *mod
+		m.modifiers[name] = append(m.modifiers[name], &cp)
+	}
+	return nil
+}
+
+func (m *Memory) Tuples(c context.Context, world string, r Relation, subject, object string, tick uint64) ([]*v1.Tuple, error) {
+	pan := log.NewSpan(c, "db.Tuples", map[string]interface{}{"world": world, "relation": string(r), "subject": subject, "object": object, "tick": tick})
+	defer pan.End()
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	tuples := []*v1.Tuple{}
+	for obj, t := range m.tuples[r.tuples(world)][subject] {
+		if object == "" || object == obj {
+			tuples = append(tuples, t)
+		}
+	}
+
+	modifiers := []*v1.Modifier{}
+	for _, mod := range m.modifiers[r.modifiers(world)] {
+		if mod.Subject == subject && (object == "" || object == mod.Object) {
+			modifiers = append(modifiers, mod)
+		}
+	}
+
+	return computeTuples(tuples, modifiers, tick), nil
+}
+
+func (m *Memory) AddRevisions(c context.Context, world, kind string, in []*v1.Revision, keep int) error {
+	pan := log.NewSpan(c, "db.AddRevisions", map[string]interface{}{"world": world, "kind": kind, "count": len(in), "keep": keep})
+	defer pan.End()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	name := history_collection(world, kind)
+	col, ok := m.history[name]
+	if !ok {
+		col = map[string][]*v1.Revision{}
+		m.history[name] = col
+	}
+	for _, rev := range in {
+		if hasRevision(col[rev.Id], rev.Etag) {
+			continue // ie. a retried write
+		}
+		cp := *rev
+		revisions := append(col[rev.Id], &cp)
+		if len(revisions) > keep {
+			revisions = revisions[len(revisions)-keep:]
+		}
+		col[rev.Id] = revisions
+	}
+	return nil
+}
+
+func (m *Memory) Revisions(c context.Context, world, kind, id string) ([]*v1.Revision, error) {
+	pan := log.NewSpan(c, "db.Revisions", map[string]interface{}{"world": world, "kind": kind, "id": id})
+	defer pan.End()
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return latestRevisions(m.history[history_collection(world, kind)][id]), nil
+}
+
+func (m *Memory) DeleteWorld(c context.Context, world string) error {
+	pan := log.NewSpan(c, "db.DeleteWorld", map[string]interface{}{"world": world})
+	defer pan.End()
+	if world == "" {
+		return fmt.Errorf("%w world required", ErrInvalid)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	suffix := world_suffix(world)
+	for name := range m.data {
+		if strings.HasSuffix(name, suffix) {
+			delete(m.data, name)
+		}
+	}
+	for name := range m.tuples {
+		if strings.HasSuffix(name, suffix) {
+			delete(m.tuples, name)
+		}
+	}
+	for name := range m.modifiers {
+		if strings.HasSuffix(name, suffix) {
+			delete(m.modifiers, name)
+		}
+	}
+	for name := range m.deferred {
+		if strings.HasSuffix(name, suffix) {
+			delete(m.deferred, name)
+		}
+	}
+	for name := range m.deferredEvents {
+		if strings.HasSuffix(name, suffix) {
+			delete(m.deferredEvents, name)
+		}
+	}
+	for name := range m.history {
+		if strings.HasSuffix(name, suffix) {
+			delete(m.history, name)
+		}
+	}
+	delete(m.sequences, world)
+	delete(m.changes, world)
+	return nil
+}
+
+func (m *Memory) AddDeferredTick(c context.Context, world string, tick uint64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	name := world_collection(world, colDeferred)
+	ticks, ok := m.deferred[name]
+	if !ok {
+		ticks = map[uint64]bool{}
+		m.deferred[name] = ticks
+	}
+	ticks[tick] = true
+	return nil
+}
+
+func (m *Memory) RemoveDeferredTick(c context.Context, world string, tick uint64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.deferred[world_collection(world, colDeferred)], tick)
+
+	name := world_collection(world, colDeferredEvents)
+	kept := []*DeferredEvent{}
+	for _, evt := range m.deferredEvents[name] {
+		if evt.Tick != tick {
+			kept = append(kept, evt)
+		}
+	}
+	m.deferredEvents[name] = kept
+	return nil
+}
+
+func (m *Memory) DeferredTicks(c context.Context, world string) ([]uint64, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	ticks := []uint64{}
+	for tick := range m.deferred[world_collection(world, colDeferred)] {
+		ticks = append(ticks, tick)
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i] < ticks[j] })
+	return ticks, nil
+}
+
+func (m *Memory) AddDeferredEvent(c context.Context, world string, tick uint64, evt *v1.Event) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	name := world_collection(world, colDeferredEvents)
+	cp := *evt
+	m.deferredEvents[name] = append(m.deferredEvents[name], &DeferredEvent{Id: uuid.New(), Tick: tick, Event: &cp})
+	return nil
+}
+
+func (m *Memory) DeferredEvents(c context.Context, world string, after uint64) ([]*DeferredEvent, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	found := []*DeferredEvent{}
+	for _, evt := range m.deferredEvents[world_collection(world, colDeferredEvents)] {
+		if evt.Tick > after {
+			cp := *evt.Event
+			found = append(found, &DeferredEvent{Id: evt.Id, Tick: evt.Tick, Event: &cp})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Tick < found[j].Tick })
+	return found, nil
+}
+
+func (m *Memory) CountDeferredEvents(c context.Context, world string, tick uint64) (int64, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var count int64
+	for _, evt := range m.deferredEvents[world_collection(world, colDeferredEvents)] {
+		if evt.Tick == tick {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *Memory) CopyRelations(c context.Context, from, to string) error {
+	pan := log.NewSpan(c, "db.CopyRelations", map[string]interface{}{"from": from, "to": to})
+	defer pan.End()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, r := range allRelations {
+		src, ok := m.tuples[r.tuples(from)]
+		if ok {
+			dst, ok := m.tuples[r.tuples(to)]
+			if !ok {
+				dst = map[string]map[string]*v1.Tuple{}
+				m.tuples[r.tuples(to)] = dst
+			}
+			for subject, byObject := range src {
+				bySubject, ok := dst[subject]
+				if !ok {
+					bySubject = map[string]*v1.Tuple{}
+					dst[subject] = bySubject
+				}
+				for object, t := range byObject {
+					cp := *t
+					bySubject[object] = &cp
+				}
+			}
+		}
+		for _, mod := range m.modifiers[r.modifiers(from)] {
+			cp := *mod
+			m.modifiers[r.modifiers(to)] = append(m.modifiers[r.modifiers(to)], &cp)
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Close() {}
+
+// hasRevision returns if a revision with the given etag is in the list
+func hasRevision(in []*v1.Revision, etag string) bool {
+	for _, rev := range in {
+		if rev.Etag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Memory) AddEvents(c context.Context, in []*v1.Event) error {
+	pan := log.NewSpan(c, "db.AddEvents", map[string]interface{}{"count": len(in)})
+	defer pan.End()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, evt := range in {
+		m.addEvent(addedEvent(evt, m.nextSequence(evt.World)))
+	}
+	return nil
+}
+
+func (m *Memory) ClaimEvents(c context.Context, owner string, lease time.Duration, limit int64) ([]*OutboxEvent, error) {
+	pan := log.NewSpan(c, "db.ClaimEvents", map[string]interface{}{"owner": owner, "limit": limit})
+	defer pan.End()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now().UnixNano()
+	found := []*OutboxEvent{}
+	for _, evt := range m.outbox {
+		if evt.claimable(owner, now) {
+			found = append(found, evt)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Created < found[j].Created })
+	if limit > 0 && limit < int64(len(found)) {
+		found = found[:limit]
+	}
+
+	claimed := make([]*OutboxEvent, len(found))
+	for i, evt := range found {
+		evt.Owner = owner
+		evt.Lease = now + int64(lease)
+		cp := *evt
+		claimed[i] = &cp
+	}
+	return claimed, nil
+}
+
+func (m *Memory) RemoveEvents(c context.Context, id []string) error {
+	pan := log.NewSpan(c, "db.RemoveEvents", map[string]interface{}{"count": len(id)})
+	defer pan.End()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, i := range id {
+		delete(m.outbox, i)
+	}
+	return nil
+}
+
+func (m *Memory) SetWatcher(c context.Context, world, id string, lease time.Duration) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.watchers[id] = &watcher{Id: id, World: world, Expires: time.Now().Add(lease).UnixNano()}
+	return nil
+}
+
+func (m *Memory) RemoveWatcher(c context.Context, id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.watchers, id)
+	return nil
+}
+
+func (m *Memory) CountWatchers(c context.Context, world string) (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now().UnixNano()
+	var count int64
+	for id, w := range m.watchers {
+		if w.Expires < now {
+			delete(m.watchers, id)
+		} else if w.World == world {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *Memory) Sequence(c context.Context, world string) (uint64, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	seq, ok := m.sequences[world]
+	if !ok {
+		return 0, nil
+	}
+	return seq.Last, nil
+}
+
+func (m *Memory) Changes(c context.Context, world string, after uint64, limit int64) ([]*v1.Event, error) {
+	pan := log.NewSpan(c, "db.Changes", map[string]interface{}{"world": world, "after": after, "limit": limit})
+	defer pan.End()
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	seq, ok := m.sequences[world]
+	if !ok {
+		seq = &sequence{World: world}
+	}
+	if seq.expired(after) {
+		return nil, pan.Err(ErrExpired)
+	}
+
+	changes := m.changes[world]
+	i := sort.Search(len(changes), func(i int) bool { return changes[i].Sequence > after })
+	found := []*v1.Event{}
+	for _, evt := range changes[i:] {
+		if limit > 0 && int64(len(found)) >= limit {
+			break
+		}
+		cp := *evt
+		found = append(found, &cp)
+	}
+	return found, nil
+}
+
+// nextSequence returns the next sequence of a world, the caller must hold the lock
+func (m *Memory) nextSequence(world string) uint64 {
+	seq, ok := m.sequences[world]
+	if !ok {
+		seq = &sequence{World: world}
+		m.sequences[world] = seq
+	}
+	seq.Last++
+	return seq.Last
+}
+
+// addEvent records an event in the outbox & logs the change, the caller must hold the lock
+func (m *Memory) addEvent(evt *OutboxEvent) {
+	m.outbox[evt.Id] = evt
+
+	world := evt.Event.World
+	cp := *evt.Event
+	changes := append(m.changes[world], &cp)
+
+	trim := trimTo(cp.Sequence)
+	if trim > m.sequences[world].Trimmed {
+		i := sort.Search(len(changes), func(i int) bool { return changes[i].Sequence > trim })
+		changes = changes[i:]
+		m.sequences[world].Trimmed = trim
+	}
+	m.changes[world] = changes
+}
+
+func sortDocs(in []*memoryDoc) {
+	sort.Slice(in, func(i, j int) bool { return in[i].Id < in[j].Id })
+}
+
+func encodeDoc(v v1.Object) (*memoryDoc, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	doc := &memoryDoc{}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		return nil, err
+	}
+	doc.raw = data
+	return doc, nil
+}
+
+// decodeDocs writes the given docs into out, which is expected to be a pointer to a slice.
+func decodeDocs(in []*memoryDoc, out interface{}) error {
+	raw := make([]json.RawMessage, len(in))
+	for i, doc := range in {
+		raw[i] = doc.raw
+	}
+	return decodeJSON(raw, out)
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestMemorySetEtags(t *testing.T) {
+	testSetEtags(t, NewMemory())
+}
+
+func TestMemoryListWorlds(t *testing.T) {
+	testListWorlds(t, NewMemory())
+}
+
+func TestMemoryTuples(t *testing.T) {
+	testTuples(t, NewMemory())
+}
+
+func TestMemorySetAll(t *testing.T) {
+	testSetAll(t, NewMemory())
+}
+
+func TestMemoryDeleteWorld(t *testing.T) {
+	testDeleteWorld(t, NewMemory())
+}
+
+func TestMemoryRevisions(t *testing.T) {
+	testRevisions(t, NewMemory())
+}
+
+func TestMemoryOutbox(t *testing.T) {
+	testOutbox(t, NewMemory())
+}
+
+func TestMemoryCopyRelations(t *testing.T) {
+	testCopyRelations(t, NewMemory())
+}
+
+func TestMemoryDeferredEvents(t *testing.T) {
+	testDeferredEvents(t, NewMemory())
+}
+
+func TestMemoryOwned(t *testing.T) {
+	testOwned(t, NewMemory())
+}
+
+func TestMemoryChanges(t *testing.T) {
+	testChanges(t, NewMemory())
+}
+
+func TestMemoryWatchers(t *testing.T) {
+	testWatchers(t, NewMemory())
+}
@@ -2,8 +2,10 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/martinlindhe/base36"
@@ -11,20 +13,40 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"github.com/voidshard/faction/pkg/kind"
 	v1 "github.com/voidshard/faction/pkg/structs/v1"
 	"github.com/voidshard/faction/pkg/util/log"
+	"github.com/voidshard/faction/pkg/util/selector"
 	"github.com/voidshard/faction/pkg/util/uuid"
 )
 
 const (
-	colWorlds = "world"
+	colWorlds   = "world"
+	colDeferred = "deferred"
+	colHistory  = "history"
+
+	// colDeferredEvents holds the events deferred to each tick of a world
+	colDeferredEvents = "deferred_events"
+
+	// indexPrefix marks indexes we manage, so that we don't drop any created by hand
+	indexPrefix = "faction_"
+
+	// mongo error code when listing indexes of a collection that does not exist
+	codeNamespaceNotFound = 26
 )
 
+// baseIndexes are provisioned on the collection of every kind
+var baseIndexes = [][]string{{"_controller"}, {"_etag"}, {"Labels.$**"}, {"Owners.Id"}}
+
 type Mongo struct {
 	opts *options.ClientOptions
 	log  log.Logger
 	cfg  *MongoConfig
 	conn *mongo.Client
+
+	// collections whose indexes we've reconciled
+	indexed     map[string]bool
+	indexedLock sync.Mutex
 }
 
 type MongoConfig struct {
@@ -41,7 +63,9 @@ func NewMongo(cfg *MongoConfig) (*Mongo, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
 	defer cancel()
 
-	bsonOpts := &options.BSONOptions{UseJSONStructTags: true}
+	// nb. documents decoded into interface{} (ie. revisions) should be maps so that they
+	// marshal to JSON as objects
+	bsonOpts := &options.BSONOptions{UseJSONStructTags: true, DefaultDocumentM: true}
 
 	opts := options.Client().ApplyURI(url).SetBSONOptions(bsonOpts)
 	// github.com/open-telemetry/opentelemetry-go-contrib/blob/main/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo/example_test.go
@@ -59,8 +83,9 @@ func NewMongo(cfg *MongoConfig) (*Mongo, error) {
 			"port":     cfg.Port,
 			"database": cfg.Database,
 		}),
-		cfg:  cfg,
-		conn: client,
+		cfg:     cfg,
+		conn:    client,
+		indexed: map[string]bool{},
 	}
 	go me.ping()
 	return me, nil
@@ -111,16 +136,100 @@ func (m *Mongo) Get(c context.Context, world, kind string, id []string, out inte
 	return m.objects(c, world_collection(world, kind), bson.M{"_id": bson.M{"$in": id}}, out)
 }
 
-func (m *Mongo) List(c context.Context, world, kind string, labels map[string]string, limit, offset int64, out interface{}) error {
+func (m *Mongo) List(c context.Context, world, kind string, labels selector.Selector, limit, offset int64, out interface{}) error {
 	pan := log.NewSpan(c, "db.Find", map[string]interface{}{"world": world, "kind": kind, "labels": len(labels), "limit": limit, "offset": offset})
 	defer pan.End()
-	return m.listObjects(c, world_collection(world, kind), labels, limit, offset, out)
+	return m.listObjects(c, world_collection(world, kind), labels, "", limit, offset, out)
+}
+
+func (m *Mongo) ListAfter(c context.Context, world, kind string, labels selector.Selector, after string, limit int64, out interface{}) error {
+	pan := log.NewSpan(c, "db.Find", map[string]interface{}{"world": world, "kind": kind, "labels": len(labels), "limit": limit, "after": after})
+	defer pan.End()
+	return m.listObjects(c, world_collection(world, kind), labels, after, limit, 0, out)
+}
+
+func (m *Mongo) Owned(c context.Context, world, kind string, owner *v1.Owner, after string, limit int64, out interface{}) error {
+	pan := log.NewSpan(c, "db.Owned", map[string]interface{}{"world": world, "kind": kind, "owner": owner.Id, "limit": limit, "after": after})
+	defer pan.End()
+
+	filter := bson.M{"Owners": bson.M{"$elemMatch": bson.M{"Kind": owner.Kind, "Id": owner.Id}}}
+	if after != "" {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	cursor, err := m.collection(world_collection(world, kind)).Find(c, filter, &options.FindOptions{
+		Limit: &limit,
+		Sort:  bson.M{"_id": 1},
+	})
+	if err != nil {
+		return pan.Err(err)
+	}
+	return pan.Err(cursor.All(c, out))
+}
+
+func (m *Mongo) Count(c context.Context, world, kind string) (int64, error) {
+	pan := log.NewSpan(c, "db.Count", map[string]interface{}{"world": world, "kind": kind})
+	defer pan.End()
+	count, err := m.collection(world_collection(world, kind)).CountDocuments(c, bson.M{})
+	return count, pan.Err(err)
 }
 
-func (m *Mongo) Delete(c context.Context, world, kind string, id string) error {
-	pan := log.NewSpan(c, "db.Delete", map[string]interface{}{"world": world, "kind": kind, "id": id})
+func (m *Mongo) Delete(c context.Context, world, kind, id, etag string) error {
+	pan := log.NewSpan(c, "db.Delete", map[string]interface{}{"world": world, "kind": kind, "id": id, "etag": etag})
 	defer pan.End()
-	return m.deleteObject(c, world_collection(world, kind), id)
+	collection := world_collection(world, kind)
+
+	meta := &eventMeta{}
+	err := m.collection(collection).FindOne(c, bson.M{"_id": id}).Decode(meta)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if etag != "" {
+			return pan.Err(ErrEtagMismatch)
+		}
+		return nil
+	} else if err != nil {
+		return pan.Err(err)
+	}
+	if etag != "" && meta.Etag != etag {
+		return pan.Err(ErrEtagMismatch)
+	}
+	meta.Sequence, err = m.nextSequences(c, world, 1)
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	pending := []*OutboxEvent{deletedEvent(world, kind, meta, false)}
+	err = m.insertEvents(c, pending)
+	if err != nil {
+		return pan.Err(err)
+	}
+	deleted, err := m.deleteObject(c, collection, id, etag)
+	if err != nil {
+		// we don't know if the delete happened, so we check before confirming the event
+		cc := context.WithoutCancel(c)
+		count, cerr := m.collection(collection).CountDocuments(cc, bson.M{"_id": id})
+		if cerr == nil {
+			res := NewResult()
+			if count == 0 {
+				res.Written[id] = ""
+			}
+			cerr = m.confirmEvents(cc, pending, res)
+		}
+		if cerr != nil {
+			m.log.Warn().Err(cerr).Str("collection", collection).Msg("failed to confirm events")
+		}
+		return pan.Err(err)
+	}
+	if !deleted {
+		// the object changed (or was deleted by someone else) after we read it, so we drop the event
+		err = m.confirmEvents(c, pending, NewResult())
+		if err != nil {
+			return pan.Err(err)
+		}
+		if etag != "" {
+			return pan.Err(ErrEtagMismatch)
+		}
+		return nil
+	}
+	return pan.Err(m.confirmEvents(c, pending, nil))
 }
 
 func (m *Mongo) Set(c context.Context, world, etag string, in []v1.Object) (*Result, error) {
@@ -129,11 +238,578 @@ func (m *Mongo) Set(c context.Context, world, etag string, in []v1.Object) (*Res
 	if len(in) == 0 {
 		return nil, fmt.Errorf("%w no objects to set", ErrInvalid)
 	}
+	prior := objectEtags(in)
 	models, err := prepareSet(world, etag, in)
 	if err != nil {
 		return nil, err
 	}
-	return m.setObjects(c, world_collection(world, in[0].GetKind()), models)
+	ids := []string{}
+	for _, v := range in {
+		ids = append(ids, v.GetId())
+	}
+	collection := world_collection(world, in[0].GetKind())
+	m.ensureIndexes(c, collection, in[0].GetKind())
+
+	err = m.stampSequences(c, world, in)
+	if err != nil {
+		restoreEtags(in, prior, nil)
+		return nil, pan.Err(err)
+	}
+	pending := pendingEvents(world, in)
+	err = m.insertEvents(c, pending)
+	if err != nil {
+		restoreEtags(in, prior, nil)
+		return nil, pan.Err(err)
+	}
+
+	res, err := m.setObjects(c, collection, etag, ids, models)
+
+	// nb. with an unordered bulk write some objects may be written even if there is an error,
+	// if we don't have a result we look for what holds our etag. We confirm events even if
+	// the caller has given up, so that none are sent for writes that did not happen.
+	cc := context.WithoutCancel(c)
+	written := res
+	var cerr error
+	if written == nil {
+		written, cerr = m.writtenAt(cc, collection, etag, ids)
+	}
+	if cerr == nil {
+		restoreEtags(in, prior, written)
+		cerr = m.confirmEvents(cc, pending, written)
+	}
+	if cerr != nil {
+		m.log.Warn().Err(cerr).Str("collection", collection).Msg("failed to confirm events")
+	}
+	return res, pan.Err(err)
+}
+
+func (m *Mongo) SetAll(c context.Context, world, etag string, in []v1.Object) (*Result, error) {
+	pan := log.NewSpan(c, "db.SetAll", map[string]interface{}{"world": world, "etag": etag, "count": len(in)})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil, fmt.Errorf("%w no objects to set", ErrInvalid)
+	}
+
+	// we write one bulk operation per collection (kind)
+	prior := objectEtags(in)
+	batches := []*setBatch{}
+	byCollection := map[string]*setBatch{}
+	for _, v := range in {
+		collection := world_collection(world, v.GetKind())
+		b, ok := byCollection[collection]
+		if !ok {
+			b = &setBatch{collection: collection, updates: []string{}}
+			byCollection[collection] = b
+			batches = append(batches, b)
+		}
+		if v.GetEtag() != "" {
+			b.updates = append(b.updates, v.GetId())
+		}
+		b.objects = append(b.objects, v)
+	}
+	for _, b := range batches {
+		// nb. prepareSet assigns Ids & Etags, so we only do this once
+		models, err := prepareSet(world, etag, b.objects)
+		if err != nil {
+			restoreEtags(in, prior, nil)
+			return nil, pan.Err(err)
+		}
+		b.models = models
+		for _, v := range b.objects {
+			b.ids = append(b.ids, v.GetId())
+		}
+		m.ensureIndexes(c, b.collection, b.objects[0].GetKind())
+	}
+
+	err := m.stampSequences(c, world, in)
+	if err != nil {
+		restoreEtags(in, prior, nil)
+		return nil, pan.Err(err)
+	}
+	pending := pendingEvents(world, in)
+	err = m.insertEvents(c, pending)
+	if err != nil {
+		restoreEtags(in, prior, nil)
+		return nil, pan.Err(err)
+	}
+
+	var res *Result
+	if m.supportsTransactions(c) {
+		res, err = m.setAllTransaction(c, etag, batches)
+	} else {
+		res, err = m.setAllCompensating(c, etag, batches)
+	}
+
+	// either everything was written or nothing was, if we don't know which we look for what
+	// holds our etag (see Set)
+	cc := context.WithoutCancel(c)
+	var cerr error
+	if err == nil {
+		cerr = m.confirmEvents(cc, pending, nil)
+	} else if errors.Is(err, ErrEtagMismatch) || errors.Is(err, ErrDuplicate) {
+		restoreEtags(in, prior, nil)
+		cerr = m.confirmEvents(cc, pending, NewResult())
+	} else {
+		written := NewResult()
+		for _, b := range batches {
+			var found *Result
+			found, cerr = m.writtenAt(cc, b.collection, etag, b.ids)
+			if cerr != nil {
+				break
+			}
+			written.Merge(found)
+		}
+		if cerr == nil {
+			restoreEtags(in, prior, written)
+			cerr = m.confirmEvents(cc, pending, written)
+		}
+	}
+	if cerr != nil {
+		m.log.Warn().Err(cerr).Str("world", world).Msg("failed to confirm events")
+	}
+	return res, pan.Err(err)
+}
+
+func (m *Mongo) SetTuples(c context.Context, world string, r Relation, in []*v1.Tuple) error {
+	pan := log.NewSpan(c, "db.SetTuples", map[string]interface{}{"world": world, "relation": string(r), "count": len(in)})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil
+	}
+	return pan.Err(m.setTuples(c, r.tuples(world), in))
+}
+
+func (m *Mongo) AddModifiers(c context.Context, world string, r Relation, in []*v1.Modifier) error {
+	pan := log.NewSpan(c, "db.AddModifiers", map[string]interface{}{"world": world, "relation": string(r), "count": len(in)})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil
+	}
+	return pan.Err(m.addModifiers(c, r.modifiers(world), in))
+}
+
+func (m *Mongo) Tuples(c context.Context, world string, r Relation, subject, object string, tick uint64) ([]*v1.Tuple, error) {
+	pan := log.NewSpan(c, "db.Tuples", map[string]interface{}{"world": world, "relation": string(r), "subject": subject, "object": object, "tick": tick})
+	defer pan.End()
+
+	filter := bson.M{"Subject": subject}
+	if object != "" {
+		filter["Object"] = object
+	}
+
+	tuples := []*v1.Tuple{}
+	err := m.objects(c, r.tuples(world), filter, &tuples)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+
+	filter["Expires"] = bson.M{"$gt": tick}
+	modifiers := []*v1.Modifier{}
+	err = m.objects(c, r.modifiers(world), filter, &modifiers)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+
+	return computeTuples(tuples, modifiers, tick), nil
+}
+
+func (m *Mongo) AddRevisions(c context.Context, world, kind string, in []*v1.Revision, keep int) error {
+	pan := log.NewSpan(c, "db.AddRevisions", map[string]interface{}{"world": world, "kind": kind, "count": len(in), "keep": keep})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil
+	}
+
+	// revisions of an object are held in a single document, capped at the most recent `keep`
+	byId := map[string][]*v1.Revision{}
+	order := []string{}
+	for _, rev := range in {
+		if _, ok := byId[rev.Id]; !ok {
+			order = append(order, rev.Id)
+		}
+		byId[rev.Id] = append(byId[rev.Id], rev)
+	}
+	models := []mongo.WriteModel{}
+	for _, id := range order {
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": id}).SetUpdate(bson.M{
+			"$push": bson.M{"Revisions": bson.M{"$each": byId[id], "$slice": -keep}},
+		}).SetUpsert(true))
+	}
+
+	collection := history_collection(world, kind)
+	m.log.Debug().Str("collection", collection).Int("count", len(in)).Msg("addRevisions")
+	_, err := m.collection(collection).BulkWrite(c, models, options.BulkWrite().SetOrdered(false))
+	return pan.Err(err)
+}
+
+func (m *Mongo) Revisions(c context.Context, world, kind, id string) ([]*v1.Revision, error) {
+	pan := log.NewSpan(c, "db.Revisions", map[string]interface{}{"world": world, "kind": kind, "id": id})
+	defer pan.End()
+
+	found := []*struct {
+		Revisions []*v1.Revision `json:"Revisions"`
+	}{}
+	err := m.objects(c, history_collection(world, kind), bson.M{"_id": id}, &found)
+	if err != nil || len(found) == 0 {
+		return []*v1.Revision{}, pan.Err(err)
+	}
+	return latestRevisions(found[0].Revisions), nil
+}
+
+func (m *Mongo) DeleteWorld(c context.Context, world string) error {
+	pan := log.NewSpan(c, "db.DeleteWorld", map[string]interface{}{"world": world})
+	defer pan.End()
+	if world == "" {
+		return fmt.Errorf("%w world required", ErrInvalid)
+	}
+
+	// nb. the suffix is base36, so contains nothing special to a regex
+	names, err := m.conn.Database(m.cfg.Database).ListCollectionNames(
+		c,
+		bson.M{"name": bson.M{"$regex": fmt.Sprintf("%s$", world_suffix(world))}},
+	)
+	if err != nil {
+		return pan.Err(err)
+	}
+	for _, name := range names {
+		m.log.Debug().Str("collection", name).Str("world", world).Msg("dropping collection")
+		err = m.collection(name).Drop(c)
+		if err != nil {
+			return pan.Err(err)
+		}
+		m.indexedLock.Lock()
+		delete(m.indexed, name)
+		m.indexedLock.Unlock()
+	}
+	_, err = m.collection(colSequences).DeleteOne(c, bson.M{"_id": world})
+	return pan.Err(err)
+}
+
+func (m *Mongo) AddDeferredTick(c context.Context, world string, tick uint64) error {
+	_, err := m.collection(world_collection(world, colDeferred)).ReplaceOne(
+		c,
+		bson.M{"_id": tick},
+		bson.M{"_id": tick, "Tick": tick},
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (m *Mongo) RemoveDeferredTick(c context.Context, world string, tick uint64) error {
+	_, err := m.collection(world_collection(world, colDeferred)).DeleteOne(c, bson.M{"_id": tick})
+	if err != nil {
+		return err
+	}
+	_, err = m.collection(world_collection(world, colDeferredEvents)).DeleteMany(c, bson.M{"Tick": tick})
+	return err
+}
+
+func (m *Mongo) DeferredTicks(c context.Context, world string) ([]uint64, error) {
+	cursor, err := m.collection(world_collection(world, colDeferred)).Find(c, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	found := []*struct {
+		Tick uint64 `json:"Tick"`
+	}{}
+	err = cursor.All(c, &found)
+	if err != nil {
+		return nil, err
+	}
+	ticks := []uint64{}
+	for _, f := range found {
+		ticks = append(ticks, f.Tick)
+	}
+	return ticks, nil
+}
+
+func (m *Mongo) AddDeferredEvent(c context.Context, world string, tick uint64, evt *v1.Event) error {
+	_, err := m.collection(world_collection(world, colDeferredEvents)).InsertOne(c, &DeferredEvent{Id: uuid.New(), Tick: tick, Event: evt})
+	return err
+}
+
+func (m *Mongo) DeferredEvents(c context.Context, world string, after uint64) ([]*DeferredEvent, error) {
+	cursor, err := m.collection(world_collection(world, colDeferredEvents)).Find(
+		c,
+		bson.M{"Tick": bson.M{"$gt": after}},
+		options.Find().SetSort(bson.D{{Key: "Tick", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	found := []*DeferredEvent{}
+	err = cursor.All(c, &found)
+	return found, err
+}
+
+func (m *Mongo) CountDeferredEvents(c context.Context, world string, tick uint64) (int64, error) {
+	return m.collection(world_collection(world, colDeferredEvents)).CountDocuments(c, bson.M{"Tick": tick})
+}
+
+func (m *Mongo) CopyRelations(c context.Context, from, to string) error {
+	pan := log.NewSpan(c, "db.CopyRelations", map[string]interface{}{"from": from, "to": to})
+	defer pan.End()
+
+	for _, r := range allRelations {
+		err := m.copyCollection(c, r.tuples(from), r.tuples(to))
+		if err != nil {
+			return pan.Err(err)
+		}
+		err = m.copyCollection(c, r.modifiers(from), r.modifiers(to))
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+	return nil
+}
+
+// copyCollection copies all documents of one collection into another in batches, documents
+// keep their _id so tuples copied over existing tuples replace them.
+func (m *Mongo) copyCollection(c context.Context, from, to string) error {
+	m.log.Debug().Str("from", from).Str("to", to).Msg("copyCollection")
+
+	cursor, err := m.collection(from).Find(c, bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(c)
+
+	flush := func(models []mongo.WriteModel) error {
+		if len(models) == 0 {
+			return nil
+		}
+		_, err := m.collection(to).BulkWrite(c, models, options.BulkWrite().SetOrdered(false))
+		return err
+	}
+
+	models := []mongo.WriteModel{}
+	for cursor.Next(c) {
+		doc := bson.M{}
+		err = cursor.Decode(&doc)
+		if err != nil {
+			return err
+		}
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": doc["_id"]}).SetReplacement(doc).SetUpsert(true))
+		if len(models) >= 1000 {
+			err = flush(models)
+			if err != nil {
+				return err
+			}
+			models = []mongo.WriteModel{}
+		}
+	}
+	if err = cursor.Err(); err != nil {
+		return err
+	}
+	return flush(models)
+}
+
+func (m *Mongo) AddEvents(c context.Context, in []*v1.Event) error {
+	pan := log.NewSpan(c, "db.AddEvents", map[string]interface{}{"count": len(in)})
+	defer pan.End()
+
+	events := []*OutboxEvent{}
+	for _, evt := range in {
+		seq, err := m.nextSequences(c, evt.World, 1)
+		if err != nil {
+			return pan.Err(err)
+		}
+		events = append(events, addedEvent(evt, seq))
+	}
+	err := m.insertEvents(c, events)
+	if err != nil {
+		return pan.Err(err)
+	}
+	return pan.Err(m.logChanges(c, events))
+}
+
+func (m *Mongo) Sequence(c context.Context, world string) (uint64, error) {
+	seq, err := m.sequence(c, world)
+	if err != nil {
+		return 0, err
+	}
+	return seq.Last, nil
+}
+
+func (m *Mongo) Changes(c context.Context, world string, after uint64, limit int64) ([]*v1.Event, error) {
+	pan := log.NewSpan(c, "db.Changes", map[string]interface{}{"world": world, "after": after, "limit": limit})
+	defer pan.End()
+
+	seq, err := m.sequence(c, world)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	if seq.expired(after) {
+		return nil, pan.Err(ErrExpired)
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+	cursor, err := m.collection(world_collection(world, colChanges)).Find(c, bson.M{"_id": bson.M{"$gt": after}}, opts)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	changes := []*change{}
+	err = cursor.All(c, &changes)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+
+	found := make([]*v1.Event, len(changes))
+	for i, ch := range changes {
+		found[i] = ch.Event
+	}
+	return found, nil
+}
+
+// sequence returns the sequence of a world
+func (m *Mongo) sequence(c context.Context, world string) (*sequence, error) {
+	seq := &sequence{World: world}
+	err := m.collection(colSequences).FindOne(c, bson.M{"_id": world}).Decode(seq)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return seq, nil
+	}
+	return seq, err
+}
+
+// nextSequences reserves the next n sequences of a world, returning the first of them
+func (m *Mongo) nextSequences(c context.Context, world string, n int) (uint64, error) {
+	seq := &sequence{}
+	err := m.collection(colSequences).FindOneAndUpdate(
+		c,
+		bson.M{"_id": world},
+		bson.M{"$inc": bson.M{"Last": n}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(seq)
+	if err != nil {
+		return 0, err
+	}
+	return seq.Last - uint64(n) + 1, nil
+}
+
+// stampSequences gives each of the objects the next sequence of the world
+func (m *Mongo) stampSequences(c context.Context, world string, in []v1.Object) error {
+	first, err := m.nextSequences(c, world, len(in))
+	if err != nil {
+		return err
+	}
+	for i, v := range in {
+		v.SetSequence(first + uint64(i))
+	}
+	return nil
+}
+
+// logChanges records the events of changes by sequence (see Changes) & forgets old changes.
+//
+// nb. since changes are logged once their write is confirmed they may be logged out of order,
+// which is why watchers subscribe to events before reading changes.
+func (m *Mongo) logChanges(c context.Context, in []*OutboxEvent) error {
+	byWorld := map[string][]interface{}{}
+	last := map[string]uint64{}
+	for _, evt := range in {
+		world := evt.Event.World
+		byWorld[world] = append(byWorld[world], &change{Sequence: evt.Event.Sequence, Event: evt.Event})
+		last[world] = max(last[world], evt.Event.Sequence)
+	}
+
+	for world, docs := range byWorld {
+		collection := m.collection(world_collection(world, colChanges))
+		_, err := collection.InsertMany(c, docs)
+		if err != nil {
+			return err
+		}
+
+		trim := trimTo(last[world])
+		if trim == 0 {
+			continue
+		}
+		_, err = m.collection(colSequences).UpdateOne(c, bson.M{"_id": world}, bson.M{"$max": bson.M{"Trimmed": trim}})
+		if err != nil {
+			return err
+		}
+		_, err = collection.DeleteMany(c, bson.M{"_id": bson.M{"$lte": trim}})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Mongo) ClaimEvents(c context.Context, owner string, lease time.Duration, limit int64) ([]*OutboxEvent, error) {
+	pan := log.NewSpan(c, "db.ClaimEvents", map[string]interface{}{"owner": owner, "limit": limit})
+	defer pan.End()
+
+	// nb. this matches OutboxEvent.claimable
+	now := time.Now().UnixNano()
+	filter := bson.M{"$and": bson.A{
+		bson.M{"$or": bson.A{bson.M{"Ready": true}, bson.M{"Created": bson.M{"$lt": now - int64(outboxGrace)}}}},
+		bson.M{"$or": bson.A{bson.M{"Owner": owner}, bson.M{"Lease": bson.M{"$lt": now}}}},
+	}}
+
+	candidates := []*OutboxEvent{}
+	err := m.outboxEvents(c, filter, limit, &candidates)
+	if err != nil || len(candidates) == 0 {
+		return candidates, pan.Err(err)
+	}
+	ids := []string{}
+	for _, evt := range candidates {
+		ids = append(ids, evt.Id)
+	}
+
+	// another relay may have claimed some of these since we looked, the filter is re-checked
+	// per document by the update so each event is only claimed by one relay.
+	_, err = m.collection(colOutbox).UpdateMany(
+		c,
+		bson.M{"$and": bson.A{bson.M{"_id": bson.M{"$in": ids}}, filter}},
+		bson.M{"$set": bson.M{"Owner": owner, "Lease": now + int64(lease)}},
+	)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+
+	claimed := []*OutboxEvent{}
+	err = m.outboxEvents(c, bson.M{"_id": bson.M{"$in": ids}, "Owner": owner}, 0, &claimed)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	claimed, err = m.verifyEvents(c, claimed)
+	return claimed, pan.Err(err)
+}
+
+func (m *Mongo) RemoveEvents(c context.Context, id []string) error {
+	pan := log.NewSpan(c, "db.RemoveEvents", map[string]interface{}{"count": len(id)})
+	defer pan.End()
+	if len(id) == 0 {
+		return nil
+	}
+	_, err := m.collection(colOutbox).DeleteMany(c, bson.M{"_id": bson.M{"$in": id}})
+	return pan.Err(err)
+}
+
+func (m *Mongo) SetWatcher(c context.Context, world, id string, lease time.Duration) error {
+	_, err := m.collection(colWatchers).ReplaceOne(
+		c,
+		bson.M{"_id": id},
+		&watcher{Id: id, World: world, Expires: time.Now().Add(lease).UnixNano()},
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (m *Mongo) RemoveWatcher(c context.Context, id string) error {
+	_, err := m.collection(colWatchers).DeleteOne(c, bson.M{"_id": id})
+	return err
+}
+
+func (m *Mongo) CountWatchers(c context.Context, world string) (int64, error) {
+	now := time.Now().UnixNano()
+	_, err := m.collection(colWatchers).DeleteMany(c, bson.M{"Expires": bson.M{"$lt": now}})
+	if err != nil {
+		return 0, err
+	}
+	return m.collection(colWatchers).CountDocuments(c, bson.M{"World": world})
 }
 
 func (m *Mongo) Close() {
@@ -153,13 +829,20 @@ func (m *Mongo) objects(c context.Context, collection string, filter bson.M, out
 	return cursor.All(c, out)
 }
 
-func (m *Mongo) listObjects(c context.Context, collection string, labels map[string]string, limit, offset int64, out interface{}) error {
-	var filter map[string]string
-	if labels != nil && len(labels) > 0 {
-		filter = map[string]string{}
-		for k, v := range labels {
-			filter[fmt.Sprintf("Labels.%s", k)] = v
+// listObjects finds objects matching the labels, ordered by _id. If `after` is given only
+// objects with a greater _id are returned, which (unlike an offset) is served from the index.
+func (m *Mongo) listObjects(c context.Context, collection string, labels selector.Selector, after string, limit, offset int64, out interface{}) error {
+	filter := bson.M{}
+	if len(labels) > 0 {
+		// nb. requirements may share a key, so each is a clause of its own
+		and := bson.A{}
+		for _, req := range labels {
+			and = append(and, labelFilter(req))
 		}
+		filter["$and"] = and
+	}
+	if after != "" {
+		filter["_id"] = bson.M{"$gt": after}
 	}
 
 	m.log.Debug().Str("database", m.cfg.Database).Str("collection", collection).Int("limit", int(limit)).Int("offset", int(offset)).Msg("listObjects")
@@ -175,32 +858,414 @@ func (m *Mongo) listObjects(c context.Context, collection string, labels map[str
 	return cursor.All(c, out)
 }
 
-func (m *Mongo) deleteObject(c context.Context, collection, id string) error {
-	m.log.Debug().Str("database", m.cfg.Database).Str("collection", collection).Str("_id", id).Msg("deleteObject")
-	_, err := m.collection(collection).DeleteOne(c, bson.M{"_id": id})
+// labelFilter returns the filter for a single label requirement
+func labelFilter(req *selector.Requirement) bson.M {
+	field := fmt.Sprintf("Labels.%s", req.Key)
+	switch req.Op {
+	case selector.NotEquals:
+		return bson.M{field: bson.M{"$ne": req.Values[0]}}
+	case selector.In:
+		return bson.M{field: bson.M{"$in": req.Values}}
+	case selector.NotIn:
+		return bson.M{field: bson.M{"$nin": req.Values}}
+	case selector.Exists:
+		return bson.M{field: bson.M{"$exists": true}}
+	case selector.DoesNotExist:
+		return bson.M{field: bson.M{"$exists": false}}
+	}
+	return bson.M{field: req.Values[0]}
+}
+
+// outboxEvents finds up to `limit` outbox events matching the filter, oldest first
+func (m *Mongo) outboxEvents(c context.Context, filter bson.M, limit int64, out *[]*OutboxEvent) error {
+	cursor, err := m.collection(colOutbox).Find(c, filter, &options.FindOptions{
+		Limit: &limit,
+		Sort:  bson.M{"Created": 1},
+	})
+	if err != nil {
+		return err
+	}
+	return cursor.All(c, out)
+}
+
+// insertEvents writes events to the outbox.
+//
+// Since we cannot assume transactions (ie. FerretDB) events of object writes are recorded
+// as pending before the write & confirmed (or removed) after, however the write ends (see
+// confirmEvents). Should we die in between the events are checked against their objects
+// once outboxGrace has passed (see verifyEvents), so a change is never missed & no event is
+// sent for a write that did not happen.
+func (m *Mongo) insertEvents(c context.Context, in []*OutboxEvent) error {
+	if len(in) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(in))
+	for i, evt := range in {
+		docs[i] = evt
+	}
+	_, err := m.collection(colOutbox).InsertMany(c, docs)
 	return err
 }
 
+// confirmEvents marks pending events ready to publish (logging their changes) if their object
+// was written & removes the rest. A nil result implies everything was written.
+func (m *Mongo) confirmEvents(c context.Context, pending []*OutboxEvent, res *Result) error {
+	written := []*OutboxEvent{}
+	failed := []*OutboxEvent{}
+	for _, evt := range pending {
+		if res == nil {
+			written = append(written, evt)
+		} else if _, ok := res.Written[evt.Event.Id]; ok {
+			written = append(written, evt)
+		} else {
+			failed = append(failed, evt)
+		}
+	}
+	return m.settleEvents(c, written, failed)
+}
+
+// settleEvents marks events ready to publish (logging their changes) & removes the failed ones
+func (m *Mongo) settleEvents(c context.Context, written, failed []*OutboxEvent) error {
+	if len(written) > 0 {
+		err := m.logChanges(c, written)
+		if err != nil {
+			return err
+		}
+		_, err = m.collection(colOutbox).UpdateMany(c, bson.M{"_id": bson.M{"$in": outboxIds(written)}}, bson.M{"$set": bson.M{"Ready": true}})
+		if err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		_, err := m.collection(colOutbox).DeleteMany(c, bson.M{"_id": bson.M{"$in": outboxIds(failed)}})
+		return err
+	}
+	return nil
+}
+
+// verifyEvents settles claimed events that are still pending (ie. their writer died before
+// confirming them) by checking if their writes are visible (see OutboxEvent.written). Events
+// that are ready, or whose write happened, are returned in order.
+func (m *Mongo) verifyEvents(c context.Context, claimed []*OutboxEvent) ([]*OutboxEvent, error) {
+	dropped := map[string]bool{}
+	byCollection := map[string][]*OutboxEvent{}
+	for _, evt := range claimed {
+		if evt.Ready {
+			continue
+		}
+		collection := world_collection(evt.Event.World, evt.Event.Kind)
+		byCollection[collection] = append(byCollection[collection], evt)
+	}
+
+	for collection, pending := range byCollection {
+		ids := []string{}
+		for _, evt := range pending {
+			ids = append(ids, evt.Event.Id)
+		}
+		found := []*eventMeta{}
+		err := m.objects(c, collection, bson.M{"_id": bson.M{"$in": ids}}, &found)
+		if err != nil {
+			return nil, err
+		}
+		stored := map[string]uint64{}
+		for _, meta := range found {
+			stored[meta.Id] = meta.Sequence
+		}
+
+		written := []*OutboxEvent{}
+		failed := []*OutboxEvent{}
+		for _, evt := range pending {
+			seq, exists := stored[evt.Event.Id]
+			if evt.written(seq, exists) {
+				written = append(written, evt)
+			} else {
+				failed = append(failed, evt)
+				dropped[evt.Id] = true
+			}
+		}
+		m.log.Warn().Str("collection", collection).Int("written", len(written)).Int("failed", len(failed)).Msg("verified unconfirmed events")
+		err = m.settleEvents(c, written, failed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ready := []*OutboxEvent{}
+	for _, evt := range claimed {
+		if !dropped[evt.Id] {
+			ready = append(ready, evt)
+		}
+	}
+	return ready, nil
+}
+
+// outboxIds returns the ids of the given outbox events
+func outboxIds(in []*OutboxEvent) []string {
+	ids := make([]string, len(in))
+	for i, evt := range in {
+		ids[i] = evt.Id
+	}
+	return ids
+}
+
+// pendingEvents returns unconfirmed outbox events for writes to the given objects
+func pendingEvents(world string, in []v1.Object) []*OutboxEvent {
+	events := make([]*OutboxEvent, len(in))
+	for i, v := range in {
+		events[i] = objectEvent(world, v, false)
+	}
+	return events
+}
+
+// deleteObject deletes an object (at the given etag, if set) returning if it was deleted
+func (m *Mongo) deleteObject(c context.Context, collection, id, etag string) (bool, error) {
+	m.log.Debug().Str("database", m.cfg.Database).Str("collection", collection).Str("_id", id).Msg("deleteObject")
+	filter := bson.M{"_id": id}
+	if etag != "" {
+		filter["_etag"] = etag
+	}
+	res, err := m.collection(collection).DeleteOne(c, filter)
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
 // setObjects takes a list of objects and writes them to the database. Handles both insert and update.
 // In the worst case(s) we may need to re-read the Etags of docs to determine which were successful.
-func (m *Mongo) setObjects(c context.Context, collection string, models []mongo.WriteModel) (*Result, error) {
+func (m *Mongo) setObjects(c context.Context, collection, etag string, ids []string, models []mongo.WriteModel) (*Result, error) {
 	m.log.Debug().Str("collection", collection).Int("count", len(models)).Msg("setObjects")
 
 	results, err := m.collection(collection).BulkWrite(c, models, options.BulkWrite().SetOrdered(false))
-	if err != nil {
-		if mongo.IsDuplicateKeyError(err) {
-			return nil, ErrDuplicate
+	if err != nil && mongo.IsDuplicateKeyError(err) && mongo.SessionFromContext(c) != nil {
+		// within a transaction the server has aborted it, so nothing was written & we can't
+		// read with the session (which would fail as a transient error, retrying the transaction)
+		return nil, ErrDuplicate
+	} else if err != nil && mongo.IsDuplicateKeyError(err) {
+		// the rest of the write carried on, so we find what was written. Only updates conflict,
+		// inserts that were not written are the duplicates.
+		res, err := m.writtenAt(c, collection, etag, ids)
+		if err != nil {
+			return nil, err
+		}
+		inserts := map[string]bool{}
+		for i, mod := range models {
+			if _, ok := mod.(*mongo.InsertOneModel); ok {
+				inserts[ids[i]] = true
+			}
 		}
+		conflicts := []string{}
+		for _, id := range res.Conflicts {
+			if !inserts[id] {
+				conflicts = append(conflicts, id)
+			}
+		}
+		res.Conflicts = conflicts
+		return res, ErrDuplicate
+	} else if err != nil {
 		return nil, err // Timeout / network error
 	}
 
 	res := NewResult()
 	if int(results.InsertedCount+results.ModifiedCount+results.UpsertedCount) == len(models) {
+		for _, id := range ids {
+			res.Written[id] = etag
+		}
 		return res, nil
 	}
+
+	// some writes failed the etag check
+	res, err = m.writtenAt(c, collection, etag, ids)
+	if err != nil {
+		return nil, err
+	}
 	return res, ErrEtagMismatch
 }
 
+// writtenAt returns which of the given objects hold the given etag, ie. were written (either
+// just now, or by a previous attempt of this same write) by a write that did not entirely
+// succeed. The rest are listed as conflicts.
+func (m *Mongo) writtenAt(c context.Context, collection, etag string, ids []string) (*Result, error) {
+	found := []*struct {
+		Id string `json:"_id"`
+	}{}
+	err := m.objects(c, collection, bson.M{"_id": bson.M{"$in": ids}, "_etag": etag}, &found)
+	if err != nil {
+		return nil, err
+	}
+	res := NewResult()
+	for _, doc := range found {
+		res.Written[doc.Id] = etag
+	}
+	for _, id := range ids {
+		if _, ok := res.Written[id]; !ok {
+			res.Conflicts = append(res.Conflicts, id)
+		}
+	}
+	return res, nil
+}
+
+// setBatch is the write(s) for a single collection as part of a SetAll
+type setBatch struct {
+	collection string
+	objects    []v1.Object
+	ids        []string
+	updates    []string // ids of objects being updated (rather than inserted)
+	models     []mongo.WriteModel
+}
+
+// supportsTransactions returns if the server supports multi-document transactions, which
+// requires a replica set or sharded cluster (in particular FerretDB does not).
+func (m *Mongo) supportsTransactions(c context.Context) bool {
+	hello := bson.M{}
+	err := m.conn.Database("admin").RunCommand(c, bson.M{"hello": 1}).Decode(&hello)
+	if err != nil {
+		m.log.Warn().Err(err).Msg("failed to determine server topology, assuming no transactions")
+		return false
+	}
+	_, isReplicaSet := hello["setName"]
+	return isReplicaSet || hello["msg"] == "isdbgrid"
+}
+
+// setAllTransaction writes all batches in a single multi-document transaction.
+func (m *Mongo) setAllTransaction(c context.Context, etag string, batches []*setBatch) (*Result, error) {
+	m.log.Debug().Int("collections", len(batches)).Msg("setAllTransaction")
+
+	session, err := m.conn.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(c)
+
+	res := NewResult()
+	_, err = session.WithTransaction(c, func(sc mongo.SessionContext) (interface{}, error) {
+		res = NewResult() // the transaction may be retried
+		for _, b := range batches {
+			written, err := m.setObjects(sc, b.collection, etag, b.ids, b.models)
+			if errors.Is(err, ErrEtagMismatch) {
+				res.Merge(written) // carry on so we can report all conflicts
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			res.Merge(written)
+		}
+		if len(res.Conflicts) > 0 {
+			return nil, ErrEtagMismatch // aborts the transaction
+		}
+		return nil, nil
+	})
+	if errors.Is(err, ErrEtagMismatch) {
+		failed := NewResult()
+		failed.Conflicts = res.Conflicts
+		return failed, err
+	} else if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// setAllCompensating is used where the server does not support transactions. We write each batch
+// in turn and, if any write fails, we put back everything that we changed.
+//
+// This is all-or-nothing but it is not isolated; readers may briefly see some of the writes.
+func (m *Mongo) setAllCompensating(c context.Context, etag string, batches []*setBatch) (*Result, error) {
+	m.log.Debug().Int("collections", len(batches)).Msg("setAllCompensating")
+
+	// keep the current version of anything we update so we can restore it
+	originals := map[string][]bson.M{}
+	for _, b := range batches {
+		if len(b.updates) == 0 {
+			continue
+		}
+		docs := []bson.M{}
+		err := m.objects(c, b.collection, bson.M{"_id": bson.M{"$in": b.updates}}, &docs)
+		if err != nil {
+			return nil, err
+		}
+		originals[b.collection] = docs
+	}
+
+	res := NewResult()
+	var failure error
+	for _, b := range batches {
+		written, err := m.setObjects(c, b.collection, etag, b.ids, b.models)
+		if written != nil {
+			res.Merge(written)
+		}
+		if err != nil {
+			failure = err
+			if !errors.Is(err, ErrEtagMismatch) {
+				break
+			}
+		}
+	}
+	if failure == nil {
+		return res, nil
+	}
+
+	// undo our writes; anything holding our etag was written by us
+	for _, b := range batches {
+		for _, doc := range originals[b.collection] {
+			_, err := m.collection(b.collection).ReplaceOne(c, bson.M{"_id": doc["_id"], "_etag": etag}, doc)
+			if err != nil {
+				m.log.Error().Err(err).Str("collection", b.collection).Interface("_id", doc["_id"]).Msg("failed to restore object")
+			}
+		}
+		_, err := m.collection(b.collection).DeleteMany(c, bson.M{"_id": bson.M{"$in": b.ids, "$nin": b.updates}, "_etag": etag})
+		if err != nil {
+			m.log.Error().Err(err).Str("collection", b.collection).Msg("failed to remove inserted objects")
+		}
+	}
+
+	if errors.Is(failure, ErrEtagMismatch) {
+		failed := NewResult()
+		failed.Conflicts = res.Conflicts
+		return failed, failure
+	}
+	return nil, failure
+}
+
+// mongoTuple is how we store a Tuple, the _id is derived from the (subject, object) pair
+// so that setting a tuple is an upsert.
+type mongoTuple struct {
+	Id      string `json:"_id"`
+	Subject string `json:"Subject"`
+	Object  string `json:"Object"`
+	Value   int64  `json:"Value"`
+}
+
+func (m *Mongo) setTuples(c context.Context, collection string, in []*v1.Tuple) error {
+	m.log.Debug().Str("collection", collection).Int("count", len(in)).Msg("setTuples")
+
+	models := []mongo.WriteModel{}
+	for _, t := range in {
+		doc := &mongoTuple{
+			// nb. subject & object are validated as alphanumsymbol, so cannot contain ':'
+			Id:      fmt.Sprintf("%s:%s", t.Subject, t.Object),
+			Subject: t.Subject,
+			Object:  t.Object,
+			Value:   clampTuple(t.Value),
+		}
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": doc.Id}).SetReplacement(doc).SetUpsert(true))
+	}
+
+	_, err := m.collection(collection).BulkWrite(c, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (m *Mongo) addModifiers(c context.Context, collection string, in []*v1.Modifier) error {
+	m.log.Debug().Str("collection", collection).Int("count", len(in)).Msg("addModifiers")
+
+	docs := []interface{}{}
+	for _, mod := range in {
+		docs = append(docs, mod)
+	}
+
+	_, err := m.collection(collection).InsertMany(c, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
 // prepareSet takes a list of objects and prepares them for insert or update
 func prepareSet(world, newEtag string, in []v1.Object) ([]mongo.WriteModel, error) {
 	models := []mongo.WriteModel{}
@@ -240,6 +1305,89 @@ func prepareSet(world, newEtag string, in []v1.Object) ([]mongo.WriteModel, erro
 	return models, nil
 }
 
+// ensureIndexes reconciles the indexes of a kind's collection the first time we write to it.
+// Failures are logged rather than returned; indexes are a performance concern and some
+// mongo-alikes (eg. FerretDB) do not support every index type.
+func (m *Mongo) ensureIndexes(c context.Context, collection, k string) {
+	m.indexedLock.Lock()
+	defer m.indexedLock.Unlock()
+
+	if m.indexed[collection] {
+		return
+	}
+	m.indexed[collection] = true
+
+	err := m.reconcileIndexes(c, collection, kindIndexes(k))
+	if err != nil {
+		m.log.Warn().Err(err).Str("collection", collection).Str("kind", k).Msg("failed to reconcile indexes")
+	}
+}
+
+// kindIndexes returns the indexes wanted on the collection of a kind
+func kindIndexes(k string) [][]string {
+	want := [][]string{}
+	want = append(want, baseIndexes...)
+	return append(want, kind.Indexes(k)...)
+}
+
+// reconcileIndexes creates the wanted indexes that are missing & drops indexes we manage
+// that are no longer wanted.
+func (m *Mongo) reconcileIndexes(c context.Context, collection string, want [][]string) error {
+	col := m.collection(collection)
+
+	existing := map[string]bool{}
+	specs, err := col.Indexes().ListSpecifications(c)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == codeNamespaceNotFound {
+		// the collection will be created along with the indexes
+	} else if err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		existing[spec.Name] = true
+	}
+
+	wanted := map[string]bool{}
+	errs := []error{}
+	for _, fields := range want {
+		name := indexName(fields)
+		wanted[name] = true
+		if existing[name] {
+			continue
+		}
+
+		keys := bson.D{}
+		for _, f := range fields {
+			keys = append(keys, bson.E{Key: f, Value: 1})
+		}
+		m.log.Debug().Str("collection", collection).Str("index", name).Msg("creating index")
+		_, err = col.Indexes().CreateOne(c, mongo.IndexModel{Keys: keys, Options: options.Index().SetName(name)})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("create index %s: %w", name, err))
+		}
+	}
+
+	for name := range existing {
+		if !strings.HasPrefix(name, indexPrefix) || wanted[name] {
+			continue
+		}
+		m.log.Debug().Str("collection", collection).Str("index", name).Msg("dropping index")
+		_, err = col.Indexes().DropOne(c, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("drop index %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// indexName returns the name of the index we manage on the given fields
+// ie. faction_Headquarters_Area, faction_Labels_all
+func indexName(fields []string) string {
+	r := strings.NewReplacer("$**", "all", ".", "_")
+	return indexPrefix + r.Replace(strings.Join(fields, "_"))
+}
+
 // world_collection returns a name for a world, collection tuple.
 // ie. actors_world1, actors_world2 etc.
 // This forcibly divides data from different worlds and simplifies data management.
@@ -250,3 +1398,13 @@ func world_collection(world, name string) string {
 	}
 	return strings.ToLower(fmt.Sprintf("%s_%s", name, base36.EncodeBytes([]byte(world))))
 }
+
+// history_collection returns the name of the collection holding revisions of a kind within a world.
+func history_collection(world, kind string) string {
+	return world_collection(world, fmt.Sprintf("%s_%s", colHistory, kind))
+}
+
+// world_suffix returns the suffix that world_collection gives every collection in a world.
+func world_suffix(world string) string {
+	return strings.ToLower(fmt.Sprintf("_%s", base36.EncodeBytes([]byte(world))))
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+// newTestMongo connects to the server given by FACTION_TEST_MONGO_HOST (if any) & returns a
+// client of a fresh database, which is dropped when the test ends.
+func newTestMongo(t *testing.T) *Mongo {
+	host := os.Getenv("FACTION_TEST_MONGO_HOST")
+	if host == "" {
+		t.Skip("FACTION_TEST_MONGO_HOST not set")
+	}
+	m, err := NewMongo(&MongoConfig{
+		Host:     host,
+		Port:     27017,
+		Username: os.Getenv("FACTION_TEST_MONGO_USERNAME"),
+		Password: os.Getenv("FACTION_TEST_MONGO_PASSWORD"),
+		Database: "test" + uuid.New()[:8],
+	})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		m.conn.Database(m.cfg.Database).Drop(context.Background())
+		m.Close()
+	})
+	return m
+}
+
+func TestMongoSetEtags(t *testing.T) {
+	testSetEtags(t, newTestMongo(t))
+}
+
+// nb. against a replica set this writes in a transaction, otherwise it compensates
+func TestMongoSetAll(t *testing.T) {
+	testSetAll(t, newTestMongo(t))
+}
+
+// a duplicate aborts a transaction, which should fail straight away rather than be retried
+func TestMongoSetAllDuplicate(t *testing.T) {
+	m := newTestMongo(t)
+	if !m.supportsTransactions(context.Background()) {
+		t.Skip("server does not support transactions")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	a := newTestActor("narnia", nil)
+	_, err := m.SetAll(ctx, "narnia", uuid.New(), []v1.Object{a})
+	assert.Nil(t, err)
+
+	dupe := newTestActor("narnia", nil)
+	dupe.SetId(a.GetId())
+	_, err = m.SetAll(ctx, "narnia", uuid.New(), []v1.Object{newTestActor("narnia", nil), dupe})
+	assert.ErrorIs(t, err, ErrDuplicate)
+	assert.Nil(t, ctx.Err())
+}
+
+func TestMongoOutbox(t *testing.T) {
+	testOutbox(t, newTestMongo(t))
+}
+
+func TestKindIndexes(t *testing.T) {
+	names := []string{}
+	for _, fields := range kindIndexes("faction") {
+		names = append(names, indexName(fields))
+	}
+	assert.Equal(t, []string{
+		"faction__controller",
+		"faction__etag",
+		"faction_Labels_all",
+		"faction_Owners_Id",
+		"faction_Headquarters_Area",
+	}, names)
+
+	// kinds without declared indexes have only the base indexes
+	assert.Equal(t, baseIndexes, kindIndexes("race"))
+	assert.Equal(t, "faction_Race_Culture", indexName([]string{"Race", "Culture"}))
+}
+
+func TestOutboxEventWritten(t *testing.T) {
+	meta := &eventMeta{Id: "a", Sequence: 5}
+	set := objectEvent("w", &v1.Actor{Meta: v1.Meta{Kind: "actor", Id: "a", Sequence: 5}}, false)
+	del := deletedEvent("w", "actor", meta, false)
+
+	// a write is visible if the object holds its sequence
+	assert.True(t, set.written(5, true))
+	assert.False(t, set.written(4, true)) // the write never happened
+	assert.False(t, set.written(6, true)) // written again since, which has its own event
+	assert.False(t, set.written(0, false))
+
+	// a delete is visible if the object is gone
+	assert.True(t, del.written(0, false))
+	assert.False(t, del.written(5, true))
+}
+
+func TestMongoWatchers(t *testing.T) {
+	testWatchers(t, newTestMongo(t))
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"time"
+
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+const (
+	colOutbox = "outbox"
+
+	// outboxGrace is how long a pending event waits for the write that raised it to confirm
+	// it, after which we assume the writer died mid-way & check the object to see if the write
+	// happened (see OutboxEvent.written).
+	outboxGrace = 2 * time.Minute
+)
+
+// OutboxEvent is an event recorded along with the write that caused it, waiting to be published.
+//
+// Events are claimed by a relay (see ClaimEvents) for the duration of a lease, published to the
+// queue & then removed (see RemoveEvents). If the relay dies, another claims them once the lease
+// expires.
+type OutboxEvent struct {
+	Id    string    `json:"_id"`
+	Event *v1.Event `json:"Event"`
+
+	// Created is when the event was recorded (unix nanoseconds)
+	Created int64 `json:"Created"`
+
+	// Ready is set once the write is known to have succeeded. Databases that write the event in
+	// the same transaction as the object set this immediately.
+	Ready bool `json:"Ready"`
+
+	// Owner is the relay that has claimed the event until Lease (unix nanoseconds)
+	Owner string `json:"Owner"`
+	Lease int64  `json:"Lease"`
+}
+
+// eventMeta are the fields of a stored object that we copy into events.
+type eventMeta struct {
+	Id         string            `json:"_id"`
+	Etag       string            `json:"_etag"`
+	Sequence   uint64            `json:"_seq"`
+	Controller string            `json:"_controller"`
+	Labels     map[string]string `json:"Labels"`
+}
+
+// newOutboxEvent returns an outbox entry for a change to an object within a world
+func newOutboxEvent(world, kind string, meta *eventMeta, ready bool) *OutboxEvent {
+	return &OutboxEvent{
+		Id: uuid.New(),
+		Event: &v1.Event{
+			World:      world,
+			Kind:       kind,
+			Controller: meta.Controller,
+			Id:         meta.Id,
+			Labels:     meta.Labels,
+			Sequence:   meta.Sequence,
+		},
+		Created: time.Now().UnixNano(),
+		Ready:   ready,
+	}
+}
+
+// objectEvent returns an outbox entry for a write to the given object
+func objectEvent(world string, v v1.Object, ready bool) *OutboxEvent {
+	return newOutboxEvent(world, v.GetKind(), &eventMeta{Id: v.GetId(), Sequence: v.GetSequence(), Controller: v.GetController(), Labels: v.GetLabels()}, ready)
+}
+
+// deletedEvent returns an outbox entry for the deletion of an object
+func deletedEvent(world, kind string, meta *eventMeta, ready bool) *OutboxEvent {
+	e := newOutboxEvent(world, kind, meta, ready)
+	e.Event.Deleted = true
+	return e
+}
+
+// addedEvent returns an outbox entry for an event given to AddEvents, at the given sequence
+func addedEvent(evt *v1.Event, seq uint64) *OutboxEvent {
+	e := newOutboxEvent(evt.World, evt.Kind, &eventMeta{Id: evt.Id, Sequence: seq, Controller: evt.Controller, Labels: evt.Labels}, true)
+	e.Event.Deleted = evt.Deleted
+	return e
+}
+
+// written returns if the write that raised a pending event is visible, given the sequence of its
+// object now (if it exists). A deleted object must be gone, a written one must still be at the
+// sequence of the event; if it has been written again since, that write has an event of its own.
+func (e *OutboxEvent) written(seq uint64, exists bool) bool {
+	if e.Event.Deleted {
+		return !exists
+	}
+	return exists && seq == e.Event.Sequence
+}
+
+// claimable returns if a relay may claim the event at the given time (unix nanoseconds)
+func (e *OutboxEvent) claimable(owner string, now int64) bool {
+	if !e.Ready && e.Created >= now-int64(outboxGrace) {
+		return false
+	}
+	return e.Owner == owner || e.Lease < now
+}
@@ -2,6 +2,9 @@ package db
 
 import (
 	"fmt"
+	"sort"
+
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
 )
 
 // Relation here is a name we accept for Tuple(s) and Modifier(s).
@@ -125,6 +128,66 @@ var (
 	}
 )
 
-func (r Relation) table(worldId string) string {
-	return fmt.Sprintf("tuples_%s_%s", worldId, r)
+// ParseRelation returns the Relation of the given name, if it is known.
+func ParseRelation(name string) (Relation, error) {
+	for _, r := range allRelations {
+		if string(r) == name {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf("%w relation %s not known", ErrInvalid, name)
+}
+
+// Relations returns all known Relation(s)
+func Relations() []Relation {
+	return append([]Relation{}, allRelations...)
+}
+
+func (r Relation) tuples(world string) string {
+	return world_collection(world, fmt.Sprintf("tuples_%s", r))
+}
+
+func (r Relation) modifiers(world string) string {
+	return world_collection(world, fmt.Sprintf("modifiers_%s", r))
+}
+
+// computeTuples applies all modifiers that have not expired by the given tick to
+// the given tuples (see "Compute Tuples" above) and returns the final values sorted
+// by Object.
+//
+// Modifiers without a matching Tuple are applied to an implied Tuple of value 0.
+func computeTuples(tuples []*v1.Tuple, modifiers []*v1.Modifier, tick uint64) []*v1.Tuple {
+	byObject := map[string]*v1.Tuple{}
+	for _, t := range tuples {
+		byObject[t.Object] = &v1.Tuple{Subject: t.Subject, Object: t.Object, Value: t.Value}
+	}
+	for _, m := range modifiers {
+		if m.Expires <= tick {
+			continue
+		}
+		t, ok := byObject[m.Object]
+		if !ok {
+			t = &v1.Tuple{Subject: m.Subject, Object: m.Object}
+			byObject[m.Object] = t
+		}
+		t.Value += m.Value
+	}
+
+	result := []*v1.Tuple{}
+	for _, t := range byObject {
+		t.Value = clampTuple(t.Value)
+		result = append(result, t)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Object < result[j].Object })
+	return result
+}
+
+// clampTuple restricts a value to MinTuple - MaxTuple
+func clampTuple(v int64) int64 {
+	if v < v1.MinTuple {
+		return v1.MinTuple
+	} else if v > v1.MaxTuple {
+		return v1.MaxTuple
+	}
+	return v
 }
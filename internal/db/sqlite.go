@@ -0,0 +1,1215 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+	"github.com/voidshard/faction/pkg/util/selector"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+// SQLite is a Database backed by a single SQLite file, intended for small single machine worlds.
+//
+// Each world_collection gets a table holding the JSON document along with the _id and _etag
+// columns we filter on, plus a companion "_labels" table so that label lookups are indexed.
+type SQLite struct {
+	log  log.Logger
+	cfg  *SQLiteConfig
+	conn *sql.DB
+
+	// tables we know exist
+	tables     map[string]bool
+	tablesLock sync.Mutex
+}
+
+type SQLiteConfig struct {
+	// Path to the database file, ":memory:" is supported
+	Path string
+}
+
+func NewSQLite(cfg *SQLiteConfig) (*SQLite, error) {
+	if cfg == nil {
+		cfg = &SQLiteConfig{}
+	}
+	if cfg.Path == "" {
+		cfg.Path = "faction.db"
+	}
+
+	conn, err := sql.Open("sqlite", cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite permits a single writer; we serialise everything through one connection
+	// which also keeps ":memory:" databases alive between calls.
+	conn.SetMaxOpenConns(1)
+
+	_, err = conn.Exec("PRAGMA journal_mode=WAL")
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &SQLite{
+		log:        log.Sublogger("sqlite", map[string]interface{}{"path": cfg.Path}),
+		cfg:        cfg,
+		conn:       conn,
+		tables:     map[string]bool{},
+		tablesLock: sync.Mutex{},
+	}, nil
+}
+
+func (s *SQLite) Get(c context.Context, world, kind string, id []string, out interface{}) error {
+	pan := log.NewSpan(c, "db.Find", map[string]interface{}{"world": world, "kind": kind, "ids": len(id)})
+	defer pan.End()
+
+	table := world_collection(world, kind)
+	if len(id) == 0 {
+		return decodeJSON(nil, out)
+	}
+	if err := s.ensureTable(c, table); err != nil {
+		return pan.Err(err)
+	}
+
+	args := []interface{}{}
+	for _, i := range id {
+		args = append(args, i)
+	}
+	query := fmt.Sprintf(`SELECT doc FROM "%s" WHERE _id IN (%s) ORDER BY _id`, table, placeholders(len(id)))
+
+	return pan.Err(s.documents(c, table, query, args, out))
+}
+
+func (s *SQLite) List(c context.Context, world, kind string, labels selector.Selector, limit, offset int64, out interface{}) error {
+	pan := log.NewSpan(c, "db.Find", map[string]interface{}{"world": world, "kind": kind, "labels": len(labels), "limit": limit, "offset": offset})
+	defer pan.End()
+
+	return pan.Err(s.list(c, world, kind, labels, "", limit, offset, out))
+}
+
+func (s *SQLite) ListAfter(c context.Context, world, kind string, labels selector.Selector, after string, limit int64, out interface{}) error {
+	pan := log.NewSpan(c, "db.Find", map[string]interface{}{"world": world, "kind": kind, "labels": len(labels), "limit": limit, "after": after})
+	defer pan.End()
+	return pan.Err(s.list(c, world, kind, labels, after, limit, 0, out))
+}
+
+func (s *SQLite) Owned(c context.Context, world, kind string, owner *v1.Owner, after string, limit int64, out interface{}) error {
+	pan := log.NewSpan(c, "db.Owned", map[string]interface{}{"world": world, "kind": kind, "owner": owner.Id, "limit": limit, "after": after})
+	defer pan.End()
+
+	table := world_collection(world, kind)
+	if err := s.ensureTable(c, table); err != nil {
+		return pan.Err(err)
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+	query := fmt.Sprintf(
+		`SELECT doc FROM "%s" WHERE _id > ? AND EXISTS (SELECT 1 FROM json_each(doc, '$.Owners') WHERE json_extract(value, '$.Kind') = ? AND json_extract(value, '$.Id') = ?) ORDER BY _id LIMIT ?`,
+		table,
+	)
+	return pan.Err(s.documents(c, table, query, []interface{}{after, owner.Kind, owner.Id, limit}, out))
+}
+
+func (s *SQLite) Count(c context.Context, world, kind string) (int64, error) {
+	pan := log.NewSpan(c, "db.Count", map[string]interface{}{"world": world, "kind": kind})
+	defer pan.End()
+
+	table := world_collection(world, kind)
+	if err := s.ensureTable(c, table); err != nil {
+		return 0, pan.Err(err)
+	}
+	var count int64
+	err := s.conn.QueryRowContext(c, fmt.Sprintf(`SELECT COUNT(*) FROM "%s"`, table)).Scan(&count)
+	return count, pan.Err(err)
+}
+
+// list returns objects matching the labels with an Id greater than `after` (if given)
+func (s *SQLite) list(c context.Context, world, kind string, labels selector.Selector, after string, limit, offset int64, out interface{}) error {
+	table := world_collection(world, kind)
+	if err := s.ensureTable(c, table); err != nil {
+		return err
+	}
+
+	where := []string{}
+	args := []interface{}{}
+	if after != "" {
+		where = append(where, "_id > ?")
+		args = append(args, after)
+	}
+	for _, req := range labels {
+		clause, reqArgs := labelClause(table, req)
+		where = append(where, clause)
+		args = append(args, reqArgs...)
+	}
+
+	query := fmt.Sprintf(`SELECT doc FROM "%s"`, table)
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	// nb. as with Mongo a limit of 0 implies no limit
+	if limit <= 0 {
+		limit = -1
+	}
+	query += " ORDER BY _id LIMIT ? OFFSET ?"
+	args = append(args, limit, offset)
+
+	return s.documents(c, table, query, args, out)
+}
+
+// labelClause returns a WHERE clause (and args) for a single label requirement, answered
+// from the labels table. Negative requirements also match objects without the label.
+func labelClause(table string, req *selector.Requirement) (string, []interface{}) {
+	args := []interface{}{req.Key}
+	match := "key = ?"
+	switch req.Op {
+	case selector.Equals, selector.NotEquals, selector.In, selector.NotIn:
+		match += fmt.Sprintf(" AND value IN (%s)", placeholders(len(req.Values)))
+		for _, v := range req.Values {
+			args = append(args, v)
+		}
+	}
+
+	in := "IN"
+	switch req.Op {
+	case selector.NotEquals, selector.NotIn, selector.DoesNotExist:
+		in = "NOT IN"
+	}
+	return fmt.Sprintf(`_id %s (SELECT _id FROM "%s_labels" WHERE %s)`, in, table, match), args
+}
+
+func (s *SQLite) Delete(c context.Context, world, kind, id, etag string) error {
+	pan := log.NewSpan(c, "db.Delete", map[string]interface{}{"world": world, "kind": kind, "id": id, "etag": etag})
+	defer pan.End()
+
+	table := world_collection(world, kind)
+	if err := s.ensureTable(c, table); err != nil {
+		return pan.Err(err)
+	}
+	if err := s.ensureOutbox(c); err != nil {
+		return pan.Err(err)
+	}
+	if err := s.ensureChanges(c, world); err != nil {
+		return pan.Err(err)
+	}
+
+	s.log.Debug().Str("table", table).Str("_id", id).Msg("deleteObject")
+	tx, err := s.conn.BeginTx(c, nil)
+	if err != nil {
+		return pan.Err(err)
+	}
+	defer tx.Rollback()
+
+	var doc string
+	err = tx.QueryRowContext(c, fmt.Sprintf(`SELECT doc FROM "%s" WHERE _id = ?`, table), id).Scan(&doc)
+	if err == sql.ErrNoRows {
+		if etag != "" {
+			return pan.Err(ErrEtagMismatch)
+		}
+		return nil
+	} else if err != nil {
+		return pan.Err(err)
+	}
+	meta := &eventMeta{}
+	err = json.Unmarshal([]byte(doc), meta)
+	if err != nil {
+		return pan.Err(err)
+	}
+	if etag != "" && meta.Etag != etag {
+		return pan.Err(ErrEtagMismatch)
+	}
+
+	_, err = tx.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s_labels" WHERE _id = ?`, table), id)
+	if err != nil {
+		return pan.Err(err)
+	}
+	_, err = tx.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s" WHERE _id = ?`, table), id)
+	if err != nil {
+		return pan.Err(err)
+	}
+	meta.Sequence, err = s.nextSequence(c, tx, world)
+	if err != nil {
+		return pan.Err(err)
+	}
+	err = s.addEvent(c, tx, deletedEvent(world, kind, meta, true))
+	if err != nil {
+		return pan.Err(err)
+	}
+	return pan.Err(tx.Commit())
+}
+
+func (s *SQLite) Set(c context.Context, world, etag string, in []v1.Object) (*Result, error) {
+	pan := log.NewSpan(c, "db.Set", map[string]interface{}{"world": world, "etag": etag, "count": len(in)})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil, fmt.Errorf("%w no objects to set", ErrInvalid)
+	}
+
+	table := world_collection(world, in[0].GetKind())
+	if err := s.ensureTable(c, table); err != nil {
+		return nil, pan.Err(err)
+	}
+	if err := s.ensureOutbox(c); err != nil {
+		return nil, pan.Err(err)
+	}
+	if err := s.ensureChanges(c, world); err != nil {
+		return nil, pan.Err(err)
+	}
+	s.log.Debug().Str("table", table).Int("count", len(in)).Msg("setObjects")
+
+	tx, err := s.conn.BeginTx(c, nil)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	defer tx.Rollback()
+
+	// if we roll back nothing was written, so no object should hold the new etag
+	committed := false
+	prior := objectEtags(in)
+	defer func() {
+		if !committed {
+			restoreEtags(in, prior, nil)
+		}
+	}()
+
+	// As with Mongo's unordered bulk writes, we write everything that we can and
+	// report a failure if any of the objects could not be written.
+	res := NewResult()
+	duplicate := false
+	for _, v := range in {
+		isCreate := v.GetEtag() == ""
+		written, err := s.setObject(c, tx, world, table, etag, v)
+		if err != nil {
+			return nil, pan.Err(err)
+		}
+		if written {
+			res.Written[v.GetId()] = etag
+			err = s.addEvent(c, tx, objectEvent(world, v, true))
+			if err != nil {
+				return nil, pan.Err(err)
+			}
+		} else if isCreate {
+			duplicate = true
+		} else {
+			res.Conflicts = append(res.Conflicts, v.GetId())
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	committed = true
+
+	if duplicate {
+		return res, ErrDuplicate
+	}
+	if len(res.Written) == len(in) {
+		return res, nil
+	}
+	return res, ErrEtagMismatch
+}
+
+func (s *SQLite) SetAll(c context.Context, world, etag string, in []v1.Object) (*Result, error) {
+	pan := log.NewSpan(c, "db.SetAll", map[string]interface{}{"world": world, "etag": etag, "count": len(in)})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil, fmt.Errorf("%w no objects to set", ErrInvalid)
+	}
+
+	// nb. table creation is DDL, which we do up front rather than in the transaction
+	for _, v := range in {
+		if err := s.ensureTable(c, world_collection(world, v.GetKind())); err != nil {
+			return nil, pan.Err(err)
+		}
+	}
+	if err := s.ensureOutbox(c); err != nil {
+		return nil, pan.Err(err)
+	}
+	if err := s.ensureChanges(c, world); err != nil {
+		return nil, pan.Err(err)
+	}
+	s.log.Debug().Str("world", world).Int("count", len(in)).Msg("setAll")
+
+	tx, err := s.conn.BeginTx(c, nil)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	defer tx.Rollback()
+
+	// if we roll back nothing was written, so no object should hold the new etag
+	committed := false
+	prior := objectEtags(in)
+	defer func() {
+		if !committed {
+			restoreEtags(in, prior, nil)
+		}
+	}()
+
+	res := NewResult()
+	duplicate := false
+	for _, v := range in {
+		isCreate := v.GetEtag() == ""
+		written, err := s.setObject(c, tx, world, world_collection(world, v.GetKind()), etag, v)
+		if err != nil {
+			return nil, pan.Err(err)
+		}
+		if written {
+			res.Written[v.GetId()] = etag
+			err = s.addEvent(c, tx, objectEvent(world, v, true))
+			if err != nil {
+				return nil, pan.Err(err)
+			}
+		} else if isCreate {
+			duplicate = true
+		} else {
+			res.Conflicts = append(res.Conflicts, v.GetId())
+		}
+	}
+
+	// unlike Set, any failure means nothing is written
+	if duplicate {
+		return nil, ErrDuplicate
+	}
+	if len(res.Conflicts) > 0 {
+		failed := NewResult()
+		failed.Conflicts = res.Conflicts
+		return failed, ErrEtagMismatch
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	committed = true
+	return res, nil
+}
+
+func (s *SQLite) SetTuples(c context.Context, world string, r Relation, in []*v1.Tuple) error {
+	pan := log.NewSpan(c, "db.SetTuples", map[string]interface{}{"world": world, "relation": string(r), "count": len(in)})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil
+	}
+	if err := s.ensureRelation(c, world, r); err != nil {
+		return pan.Err(err)
+	}
+
+	tx, err := s.conn.BeginTx(c, nil)
+	if err != nil {
+		return pan.Err(err)
+	}
+	defer tx.Rollback()
+
+	stmt := fmt.Sprintf(
+		`INSERT INTO "%s" (Subject, Object, Value) VALUES (?, ?, ?) ON CONFLICT(Subject, Object) DO UPDATE SET Value = excluded.Value`,
+		r.tuples(world),
+	)
+	for _, t := range in {
+		_, err = tx.ExecContext(c, stmt, t.Subject, t.Object, clampTuple(t.Value))
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+	return pan.Err(tx.Commit())
+}
+
+func (s *SQLite) AddModifiers(c context.Context, world string, r Relation, in []*v1.Modifier) error {
+	pan := log.NewSpan(c, "db.AddModifiers", map[string]interface{}{"world": world, "relation": string(r), "count": len(in)})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil
+	}
+	if err := s.ensureRelation(c, world, r); err != nil {
+		return pan.Err(err)
+	}
+
+	tx, err := s.conn.BeginTx(c, nil)
+	if err != nil {
+		return pan.Err(err)
+	}
+	defer tx.Rollback()
+
+	stmt := fmt.Sprintf(`INSERT INTO "%s" (Subject, Object, Value, Expires) VALUES (?, ?, ?, ?)`, r.modifiers(world))
+	for _, m := range in {
+		_, err = tx.ExecContext(c, stmt, m.Subject, m.Object, m.Value, m.Expires)
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+	return pan.Err(tx.Commit())
+}
+
+func (s *SQLite) Tuples(c context.Context, world string, r Relation, subject, object string, tick uint64) ([]*v1.Tuple, error) {
+	pan := log.NewSpan(c, "db.Tuples", map[string]interface{}{"world": world, "relation": string(r), "subject": subject, "object": object, "tick": tick})
+	defer pan.End()
+	if err := s.ensureRelation(c, world, r); err != nil {
+		return nil, pan.Err(err)
+	}
+
+	where := "Subject = ?"
+	args := []interface{}{subject}
+	if object != "" {
+		where += " AND Object = ?"
+		args = append(args, object)
+	}
+
+	tuples := []*v1.Tuple{}
+	rows, err := s.conn.QueryContext(c, fmt.Sprintf(`SELECT Subject, Object, Value FROM "%s" WHERE %s`, r.tuples(world), where), args...)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	for rows.Next() {
+		t := &v1.Tuple{}
+		err = rows.Scan(&t.Subject, &t.Object, &t.Value)
+		if err != nil {
+			rows.Close()
+			return nil, pan.Err(err)
+		}
+		tuples = append(tuples, t)
+	}
+	rows.Close()
+
+	modifiers := []*v1.Modifier{}
+	rows, err = s.conn.QueryContext(
+		c,
+		fmt.Sprintf(`SELECT Subject, Object, Value, Expires FROM "%s" WHERE %s AND Expires > ?`, r.modifiers(world), where),
+		append(args, tick)...,
+	)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		m := &v1.Modifier{}
+		err = rows.Scan(&m.Subject, &m.Object, &m.Value, &m.Expires)
+		if err != nil {
+			return nil, pan.Err(err)
+		}
+		modifiers = append(modifiers, m)
+	}
+
+	return computeTuples(tuples, modifiers, tick), pan.Err(rows.Err())
+}
+
+func (s *SQLite) AddRevisions(c context.Context, world, kind string, in []*v1.Revision, keep int) error {
+	pan := log.NewSpan(c, "db.AddRevisions", map[string]interface{}{"world": world, "kind": kind, "count": len(in), "keep": keep})
+	defer pan.End()
+	if len(in) == 0 {
+		return nil
+	}
+
+	table := history_collection(world, kind)
+	if err := s.ensureHistory(c, table); err != nil {
+		return pan.Err(err)
+	}
+
+	tx, err := s.conn.BeginTx(c, nil)
+	if err != nil {
+		return pan.Err(err)
+	}
+	defer tx.Rollback()
+
+	ids := map[string]bool{}
+	for _, rev := range in {
+		doc, err := json.Marshal(rev)
+		if err != nil {
+			return pan.Err(err)
+		}
+		_, err = tx.ExecContext(
+			c,
+			fmt.Sprintf(`INSERT INTO "%s" (_id, Etag, doc) VALUES (?, ?, ?) ON CONFLICT(_id, Etag) DO NOTHING`, table),
+			rev.Id, rev.Etag, string(doc),
+		)
+		if err != nil {
+			return pan.Err(err)
+		}
+		ids[rev.Id] = true
+	}
+
+	// remove all but the most recent `keep` revisions of each object
+	for id := range ids {
+		_, err = tx.ExecContext(
+			c,
+			fmt.Sprintf(`DELETE FROM "%s" WHERE _id = ? AND seq NOT IN (SELECT seq FROM "%s" WHERE _id = ? ORDER BY seq DESC LIMIT ?)`, table, table),
+			id, id, keep,
+		)
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+
+	return pan.Err(tx.Commit())
+}
+
+func (s *SQLite) Revisions(c context.Context, world, kind, id string) ([]*v1.Revision, error) {
+	pan := log.NewSpan(c, "db.Revisions", map[string]interface{}{"world": world, "kind": kind, "id": id})
+	defer pan.End()
+
+	table := history_collection(world, kind)
+	if err := s.ensureHistory(c, table); err != nil {
+		return nil, pan.Err(err)
+	}
+
+	revisions := []*v1.Revision{}
+	err := s.documents(c, table, fmt.Sprintf(`SELECT doc FROM "%s" WHERE _id = ? ORDER BY seq DESC`, table), []interface{}{id}, &revisions)
+	return revisions, pan.Err(err)
+}
+
+func (s *SQLite) DeleteWorld(c context.Context, world string) error {
+	pan := log.NewSpan(c, "db.DeleteWorld", map[string]interface{}{"world": world})
+	defer pan.End()
+	if world == "" {
+		return fmt.Errorf("%w world required", ErrInvalid)
+	}
+	if err := s.ensureSequences(c); err != nil {
+		return pan.Err(err)
+	}
+
+	suffix := world_suffix(world)
+	rows, err := s.conn.QueryContext(c, `SELECT name FROM sqlite_master WHERE type = 'table'`)
+	if err != nil {
+		return pan.Err(err)
+	}
+	tables := []string{}
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			rows.Close()
+			return pan.Err(err)
+		}
+		if strings.HasSuffix(name, suffix) {
+			tables = append(tables, name)
+		}
+	}
+	rows.Close()
+
+	s.tablesLock.Lock()
+	defer s.tablesLock.Unlock()
+
+	for _, table := range tables {
+		s.log.Debug().Str("table", table).Str("world", world).Msg("dropping table")
+		// nb. object tables have a companion labels table
+		for _, stmt := range []string{
+			fmt.Sprintf(`DROP TABLE IF EXISTS "%s"`, table),
+			fmt.Sprintf(`DROP TABLE IF EXISTS "%s_labels"`, table),
+		} {
+			_, err = s.conn.ExecContext(c, stmt)
+			if err != nil {
+				return pan.Err(err)
+			}
+		}
+		delete(s.tables, table)
+	}
+
+	_, err = s.conn.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s" WHERE world = ?`, colSequences), world)
+	return pan.Err(err)
+}
+
+func (s *SQLite) AddDeferredTick(c context.Context, world string, tick uint64) error {
+	table := world_collection(world, colDeferred)
+	if err := s.ensureDeferred(c, table); err != nil {
+		return err
+	}
+	_, err := s.conn.ExecContext(c, fmt.Sprintf(`INSERT INTO "%s" (Tick) VALUES (?) ON CONFLICT(Tick) DO NOTHING`, table), tick)
+	return err
+}
+
+func (s *SQLite) RemoveDeferredTick(c context.Context, world string, tick uint64) error {
+	table := world_collection(world, colDeferred)
+	if err := s.ensureDeferred(c, table); err != nil {
+		return err
+	}
+	_, err := s.conn.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s" WHERE Tick = ?`, table), tick)
+	if err != nil {
+		return err
+	}
+
+	events := world_collection(world, colDeferredEvents)
+	if err := s.ensureDeferredEvents(c, events); err != nil {
+		return err
+	}
+	_, err = s.conn.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s" WHERE Tick = ?`, events), tick)
+	return err
+}
+
+func (s *SQLite) DeferredTicks(c context.Context, world string) ([]uint64, error) {
+	table := world_collection(world, colDeferred)
+	if err := s.ensureDeferred(c, table); err != nil {
+		return nil, err
+	}
+	rows, err := s.conn.QueryContext(c, fmt.Sprintf(`SELECT Tick FROM "%s" ORDER BY Tick`, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ticks := []uint64{}
+	for rows.Next() {
+		var tick uint64
+		err = rows.Scan(&tick)
+		if err != nil {
+			return nil, err
+		}
+		ticks = append(ticks, tick)
+	}
+	return ticks, rows.Err()
+}
+
+func (s *SQLite) AddDeferredEvent(c context.Context, world string, tick uint64, evt *v1.Event) error {
+	table := world_collection(world, colDeferredEvents)
+	if err := s.ensureDeferredEvents(c, table); err != nil {
+		return err
+	}
+	doc := &DeferredEvent{Id: uuid.New(), Tick: tick, Event: evt}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	_, err = s.conn.ExecContext(c, fmt.Sprintf(`INSERT INTO "%s" (_id, Tick, doc) VALUES (?, ?, ?)`, table), doc.Id, tick, string(data))
+	return err
+}
+
+func (s *SQLite) DeferredEvents(c context.Context, world string, after uint64) ([]*DeferredEvent, error) {
+	table := world_collection(world, colDeferredEvents)
+	if err := s.ensureDeferredEvents(c, table); err != nil {
+		return nil, err
+	}
+	rows, err := s.conn.QueryContext(c, fmt.Sprintf(`SELECT doc FROM "%s" WHERE Tick > ? ORDER BY Tick, rowid`, table), after)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := []*DeferredEvent{}
+	for rows.Next() {
+		var doc string
+		err = rows.Scan(&doc)
+		if err != nil {
+			return nil, err
+		}
+		evt := &DeferredEvent{}
+		err = json.Unmarshal([]byte(doc), evt)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, evt)
+	}
+	return found, rows.Err()
+}
+
+func (s *SQLite) CountDeferredEvents(c context.Context, world string, tick uint64) (int64, error) {
+	table := world_collection(world, colDeferredEvents)
+	if err := s.ensureDeferredEvents(c, table); err != nil {
+		return 0, err
+	}
+	var count int64
+	err := s.conn.QueryRowContext(c, fmt.Sprintf(`SELECT COUNT(*) FROM "%s" WHERE Tick = ?`, table), tick).Scan(&count)
+	return count, err
+}
+
+func (s *SQLite) CopyRelations(c context.Context, from, to string) error {
+	pan := log.NewSpan(c, "db.CopyRelations", map[string]interface{}{"from": from, "to": to})
+	defer pan.End()
+
+	for _, r := range allRelations {
+		for _, world := range []string{from, to} {
+			if err := s.ensureRelation(c, world, r); err != nil {
+				return pan.Err(err)
+			}
+		}
+	}
+
+	tx, err := s.conn.BeginTx(c, nil)
+	if err != nil {
+		return pan.Err(err)
+	}
+	defer tx.Rollback()
+
+	for _, r := range allRelations {
+		for _, stmt := range []string{
+			fmt.Sprintf(`INSERT OR REPLACE INTO "%s" (Subject, Object, Value) SELECT Subject, Object, Value FROM "%s"`, r.tuples(to), r.tuples(from)),
+			fmt.Sprintf(`INSERT INTO "%s" (Subject, Object, Value, Expires) SELECT Subject, Object, Value, Expires FROM "%s"`, r.modifiers(to), r.modifiers(from)),
+		} {
+			_, err = tx.ExecContext(c, stmt)
+			if err != nil {
+				return pan.Err(err)
+			}
+		}
+	}
+	return pan.Err(tx.Commit())
+}
+
+func (s *SQLite) AddEvents(c context.Context, in []*v1.Event) error {
+	pan := log.NewSpan(c, "db.AddEvents", map[string]interface{}{"count": len(in)})
+	defer pan.End()
+	if err := s.ensureOutbox(c); err != nil {
+		return pan.Err(err)
+	}
+	for _, evt := range in {
+		if err := s.ensureChanges(c, evt.World); err != nil {
+			return pan.Err(err)
+		}
+	}
+
+	tx, err := s.conn.BeginTx(c, nil)
+	if err != nil {
+		return pan.Err(err)
+	}
+	defer tx.Rollback()
+
+	for _, evt := range in {
+		seq, err := s.nextSequence(c, tx, evt.World)
+		if err != nil {
+			return pan.Err(err)
+		}
+		err = s.addEvent(c, tx, addedEvent(evt, seq))
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+	return pan.Err(tx.Commit())
+}
+
+func (s *SQLite) ClaimEvents(c context.Context, owner string, lease time.Duration, limit int64) ([]*OutboxEvent, error) {
+	pan := log.NewSpan(c, "db.ClaimEvents", map[string]interface{}{"owner": owner, "limit": limit})
+	defer pan.End()
+	if err := s.ensureOutbox(c); err != nil {
+		return nil, pan.Err(err)
+	}
+
+	tx, err := s.conn.BeginTx(c, nil)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	defer tx.Rollback()
+
+	// nb. this matches OutboxEvent.claimable
+	now := time.Now().UnixNano()
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := tx.QueryContext(
+		c,
+		fmt.Sprintf(`SELECT doc FROM "%s" WHERE (Ready = 1 OR Created < ?) AND (Owner = ? OR Lease < ?) ORDER BY Created LIMIT ?`, colOutbox),
+		now-int64(outboxGrace), owner, now, limit,
+	)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	claimed := []*OutboxEvent{}
+	for rows.Next() {
+		var doc string
+		err = rows.Scan(&doc)
+		if err != nil {
+			rows.Close()
+			return nil, pan.Err(err)
+		}
+		evt := &OutboxEvent{}
+		err = json.Unmarshal([]byte(doc), evt)
+		if err != nil {
+			rows.Close()
+			return nil, pan.Err(err)
+		}
+		evt.Owner = owner
+		evt.Lease = now + int64(lease)
+		claimed = append(claimed, evt)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, pan.Err(err)
+	}
+
+	for _, evt := range claimed {
+		_, err = tx.ExecContext(c, fmt.Sprintf(`UPDATE "%s" SET Owner = ?, Lease = ? WHERE _id = ?`, colOutbox), evt.Owner, evt.Lease, evt.Id)
+		if err != nil {
+			return nil, pan.Err(err)
+		}
+	}
+	return claimed, pan.Err(tx.Commit())
+}
+
+func (s *SQLite) RemoveEvents(c context.Context, id []string) error {
+	pan := log.NewSpan(c, "db.RemoveEvents", map[string]interface{}{"count": len(id)})
+	defer pan.End()
+	if len(id) == 0 {
+		return nil
+	}
+	if err := s.ensureOutbox(c); err != nil {
+		return pan.Err(err)
+	}
+
+	args := make([]interface{}, len(id))
+	for i, v := range id {
+		args[i] = v
+	}
+	_, err := s.conn.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s" WHERE _id IN (%s)`, colOutbox, placeholders(len(id))), args...)
+	return pan.Err(err)
+}
+
+func (s *SQLite) Close() {
+	s.conn.Close()
+}
+
+func (s *SQLite) SetWatcher(c context.Context, world, id string, lease time.Duration) error {
+	if err := s.ensureWatchers(c); err != nil {
+		return err
+	}
+	_, err := s.conn.ExecContext(
+		c,
+		fmt.Sprintf(`INSERT INTO "%s" (_id, World, Expires) VALUES (?, ?, ?) ON CONFLICT(_id) DO UPDATE SET World = excluded.World, Expires = excluded.Expires`, colWatchers),
+		id, world, time.Now().Add(lease).UnixNano(),
+	)
+	return err
+}
+
+func (s *SQLite) RemoveWatcher(c context.Context, id string) error {
+	if err := s.ensureWatchers(c); err != nil {
+		return err
+	}
+	_, err := s.conn.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s" WHERE _id = ?`, colWatchers), id)
+	return err
+}
+
+func (s *SQLite) CountWatchers(c context.Context, world string) (int64, error) {
+	if err := s.ensureWatchers(c); err != nil {
+		return 0, err
+	}
+	now := time.Now().UnixNano()
+	_, err := s.conn.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s" WHERE Expires < ?`, colWatchers), now)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	err = s.conn.QueryRowContext(c, fmt.Sprintf(`SELECT COUNT(*) FROM "%s" WHERE World = ?`, colWatchers), world).Scan(&count)
+	return count, err
+}
+
+func (s *SQLite) Sequence(c context.Context, world string) (uint64, error) {
+	if err := s.ensureChanges(c, world); err != nil {
+		return 0, err
+	}
+	seq, err := s.sequence(c, s.conn, world)
+	if err != nil {
+		return 0, err
+	}
+	return seq.Last, nil
+}
+
+func (s *SQLite) Changes(c context.Context, world string, after uint64, limit int64) ([]*v1.Event, error) {
+	pan := log.NewSpan(c, "db.Changes", map[string]interface{}{"world": world, "after": after, "limit": limit})
+	defer pan.End()
+	if err := s.ensureChanges(c, world); err != nil {
+		return nil, pan.Err(err)
+	}
+
+	seq, err := s.sequence(c, s.conn, world)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	if seq.expired(after) {
+		return nil, pan.Err(ErrExpired)
+	}
+
+	if limit <= 0 {
+		limit = -1
+	}
+	table := world_collection(world, colChanges)
+	rows, err := s.conn.QueryContext(c, fmt.Sprintf(`SELECT doc FROM "%s" WHERE seq > ? ORDER BY seq LIMIT ?`, table), after, limit)
+	if err != nil {
+		return nil, pan.Err(err)
+	}
+	defer rows.Close()
+
+	found := []*v1.Event{}
+	for rows.Next() {
+		var doc string
+		err = rows.Scan(&doc)
+		if err != nil {
+			return nil, pan.Err(err)
+		}
+		evt := &v1.Event{}
+		err = json.Unmarshal([]byte(doc), evt)
+		if err != nil {
+			return nil, pan.Err(err)
+		}
+		found = append(found, evt)
+	}
+	return found, pan.Err(rows.Err())
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx
+type querier interface {
+	QueryRowContext(c context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// sequence returns the sequence of a world
+func (s *SQLite) sequence(c context.Context, q querier, world string) (*sequence, error) {
+	seq := &sequence{World: world}
+	err := q.QueryRowContext(c, fmt.Sprintf(`SELECT Last, Trimmed FROM "%s" WHERE world = ?`, colSequences), world).Scan(&seq.Last, &seq.Trimmed)
+	if err == sql.ErrNoRows {
+		return seq, nil
+	}
+	return seq, err
+}
+
+// nextSequence returns the next sequence of a world as part of the given transaction
+func (s *SQLite) nextSequence(c context.Context, tx *sql.Tx, world string) (uint64, error) {
+	var seq uint64
+	err := tx.QueryRowContext(
+		c,
+		fmt.Sprintf(`INSERT INTO "%s" (world, Last, Trimmed) VALUES (?, 1, 0) ON CONFLICT(world) DO UPDATE SET Last = Last + 1 RETURNING Last`, colSequences),
+		world,
+	).Scan(&seq)
+	return seq, err
+}
+
+// setObject inserts or updates (if the Etag matches) a single object, returning if it was written.
+// The object is given the next sequence of the world.
+func (s *SQLite) setObject(c context.Context, tx *sql.Tx, world, table, etag string, v v1.Object) (bool, error) {
+	isCreate := v.GetEtag() == ""
+	if isCreate && v.GetId() == "" {
+		v.SetId(uuid.New())
+	}
+	oldEtag := v.GetEtag()
+	oldSeq := v.GetSequence()
+	v.SetEtag(etag)
+
+	seq, err := s.nextSequence(c, tx, world)
+	if err != nil {
+		return false, err
+	}
+	v.SetSequence(seq)
+
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+
+	var result sql.Result
+	if isCreate {
+		result, err = tx.ExecContext(
+			c,
+			fmt.Sprintf(`INSERT INTO "%s" (_id, _etag, doc) VALUES (?, ?, ?) ON CONFLICT(_id) DO NOTHING`, table),
+			v.GetId(), etag, string(doc),
+		)
+	} else {
+		// update if the ID and either the new or the old etag match (see prepareSet)
+		result, err = tx.ExecContext(
+			c,
+			fmt.Sprintf(`UPDATE "%s" SET _etag = ?, doc = ? WHERE _id = ? AND _etag IN (?, ?)`, table),
+			etag, string(doc), v.GetId(), oldEtag, etag,
+		)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil || count == 0 {
+		// nb. only objects we write hold the new etag
+		v.SetEtag(oldEtag)
+		v.SetSequence(oldSeq)
+		return false, err
+	}
+
+	return true, s.setLabels(c, tx, table, v.GetId(), v.GetLabels())
+}
+
+// addEvent records an event in the outbox & logs the change as part of the given transaction
+func (s *SQLite) addEvent(c context.Context, tx *sql.Tx, evt *OutboxEvent) error {
+	doc, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(
+		c,
+		fmt.Sprintf(`INSERT INTO "%s" (_id, Created, Ready, Owner, Lease, doc) VALUES (?, ?, ?, ?, ?, ?)`, colOutbox),
+		evt.Id, evt.Created, evt.Ready, evt.Owner, evt.Lease, string(doc),
+	)
+	if err != nil {
+		return err
+	}
+
+	changed, err := json.Marshal(evt.Event)
+	if err != nil {
+		return err
+	}
+	table := world_collection(evt.Event.World, colChanges)
+	_, err = tx.ExecContext(c, fmt.Sprintf(`INSERT INTO "%s" (seq, doc) VALUES (?, ?)`, table), evt.Event.Sequence, string(changed))
+	if err != nil {
+		return err
+	}
+
+	trim := trimTo(evt.Event.Sequence)
+	if trim == 0 {
+		return nil
+	}
+	_, err = tx.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s" WHERE seq <= ?`, table), trim)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(c, fmt.Sprintf(`UPDATE "%s" SET Trimmed = MAX(Trimmed, ?) WHERE world = ?`, colSequences), trim, evt.Event.World)
+	return err
+}
+
+func (s *SQLite) setLabels(c context.Context, tx *sql.Tx, table, id string, labels map[string]string) error {
+	_, err := tx.ExecContext(c, fmt.Sprintf(`DELETE FROM "%s_labels" WHERE _id = ?`, table), id)
+	if err != nil {
+		return err
+	}
+	for k, v := range labels {
+		_, err = tx.ExecContext(c, fmt.Sprintf(`INSERT INTO "%s_labels" (_id, key, value) VALUES (?, ?, ?)`, table), id, k, v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLite) documents(c context.Context, table, query string, args []interface{}, out interface{}) error {
+	s.log.Debug().Str("table", table).Str("query", query).Msg("documents")
+
+	rows, err := s.conn.QueryContext(c, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	docs := []json.RawMessage{}
+	for rows.Next() {
+		var doc string
+		err = rows.Scan(&doc)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, json.RawMessage(doc))
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	return decodeJSON(docs, out)
+}
+
+// ensureTable creates the tables & indexes for the given world_collection if required
+func (s *SQLite) ensureTable(c context.Context, table string) error {
+	return s.ensure(
+		c,
+		table,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (_id TEXT PRIMARY KEY, _etag TEXT NOT NULL, doc TEXT NOT NULL)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s_etag" ON "%s" (_etag)`, table, table),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s_labels" (_id TEXT NOT NULL, key TEXT NOT NULL, value TEXT NOT NULL, PRIMARY KEY (_id, key))`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s_labels_kv" ON "%s_labels" (key, value)`, table, table),
+	)
+}
+
+// ensureRelation creates the tuple & modifier tables for the given relation if required
+func (s *SQLite) ensureRelation(c context.Context, world string, r Relation) error {
+	tuples := r.tuples(world)
+	modifiers := r.modifiers(world)
+	return s.ensure(
+		c,
+		tuples,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (Subject TEXT NOT NULL, Object TEXT NOT NULL, Value INTEGER NOT NULL, PRIMARY KEY (Subject, Object))`, tuples),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (Subject TEXT NOT NULL, Object TEXT NOT NULL, Value INTEGER NOT NULL, Expires INTEGER NOT NULL)`, modifiers),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s_subject" ON "%s" (Subject, Object, Expires)`, modifiers, modifiers),
+	)
+}
+
+// ensureDeferred creates the table of deferred ticks if required
+func (s *SQLite) ensureDeferred(c context.Context, table string) error {
+	return s.ensure(c, table, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (Tick INTEGER PRIMARY KEY)`, table))
+}
+
+// ensureDeferredEvents creates the table of events deferred to ticks if required
+func (s *SQLite) ensureDeferredEvents(c context.Context, table string) error {
+	return s.ensure(
+		c,
+		table,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (_id TEXT PRIMARY KEY, Tick INTEGER NOT NULL, doc TEXT NOT NULL)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s_tick" ON "%s" (Tick)`, table, table),
+	)
+}
+
+// ensureOutbox creates the table of events waiting to be published if required
+func (s *SQLite) ensureOutbox(c context.Context) error {
+	return s.ensure(
+		c,
+		colOutbox,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (_id TEXT PRIMARY KEY, Created INTEGER NOT NULL, Ready INTEGER NOT NULL, Owner TEXT NOT NULL, Lease INTEGER NOT NULL, doc TEXT NOT NULL)`, colOutbox),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s_created" ON "%s" (Created)`, colOutbox, colOutbox),
+	)
+}
+
+// ensureWatchers creates the table of event stream leases if required
+func (s *SQLite) ensureWatchers(c context.Context) error {
+	return s.ensure(
+		c,
+		colWatchers,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (_id TEXT PRIMARY KEY, World TEXT NOT NULL, Expires INTEGER NOT NULL)`, colWatchers),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s_world" ON "%s" (World, Expires)`, colWatchers, colWatchers),
+	)
+}
+
+// ensureSequences creates the table of world sequences if required
+func (s *SQLite) ensureSequences(c context.Context) error {
+	return s.ensure(
+		c,
+		colSequences,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (world TEXT PRIMARY KEY, Last INTEGER NOT NULL, Trimmed INTEGER NOT NULL)`, colSequences),
+	)
+}
+
+// ensureChanges creates the table of sequences & the table of recent changes of a world if required
+func (s *SQLite) ensureChanges(c context.Context, world string) error {
+	if err := s.ensureSequences(c); err != nil {
+		return err
+	}
+	table := world_collection(world, colChanges)
+	return s.ensure(c, table, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (seq INTEGER PRIMARY KEY, doc TEXT NOT NULL)`, table))
+}
+
+// ensureHistory creates the table of object revisions if required
+func (s *SQLite) ensureHistory(c context.Context, table string) error {
+	return s.ensure(
+		c,
+		table,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (seq INTEGER PRIMARY KEY AUTOINCREMENT, _id TEXT NOT NULL, Etag TEXT NOT NULL, doc TEXT NOT NULL, UNIQUE (_id, Etag))`, table),
+	)
+}
+
+// ensure runs the given statements once for the named table
+func (s *SQLite) ensure(c context.Context, table string, statements ...string) error {
+	s.tablesLock.Lock()
+	defer s.tablesLock.Unlock()
+
+	if s.tables[table] {
+		return nil
+	}
+
+	for _, stmt := range statements {
+		_, err := s.conn.ExecContext(c, stmt)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.tables[table] = true
+	return nil
+}
+
+// placeholders returns n comma separated '?'
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
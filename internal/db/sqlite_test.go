@@ -0,0 +1,63 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSQLite(t *testing.T) *SQLite {
+	s, err := NewSQLite(&SQLiteConfig{Path: filepath.Join(t.TempDir(), "test.db")})
+	assert.Nil(t, err)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestSQLiteSetEtags(t *testing.T) {
+	testSetEtags(t, newTestSQLite(t))
+}
+
+func TestSQLiteListWorlds(t *testing.T) {
+	testListWorlds(t, newTestSQLite(t))
+}
+
+func TestSQLiteTuples(t *testing.T) {
+	testTuples(t, newTestSQLite(t))
+}
+
+func TestSQLiteSetAll(t *testing.T) {
+	testSetAll(t, newTestSQLite(t))
+}
+
+func TestSQLiteDeleteWorld(t *testing.T) {
+	testDeleteWorld(t, newTestSQLite(t))
+}
+
+func TestSQLiteRevisions(t *testing.T) {
+	testRevisions(t, newTestSQLite(t))
+}
+
+func TestSQLiteOutbox(t *testing.T) {
+	testOutbox(t, newTestSQLite(t))
+}
+
+func TestSQLiteCopyRelations(t *testing.T) {
+	testCopyRelations(t, newTestSQLite(t))
+}
+
+func TestSQLiteDeferredEvents(t *testing.T) {
+	testDeferredEvents(t, newTestSQLite(t))
+}
+
+func TestSQLiteOwned(t *testing.T) {
+	testOwned(t, newTestSQLite(t))
+}
+
+func TestSQLiteChanges(t *testing.T) {
+	testChanges(t, newTestSQLite(t))
+}
+
+func TestSQLiteWatchers(t *testing.T) {
+	testWatchers(t, newTestSQLite(t))
+}
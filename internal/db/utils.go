@@ -0,0 +1,56 @@
+package db
+
+import (
+	"encoding/json"
+
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+// decodeJSON writes the given JSON documents into out, which is expected to be a pointer to a slice.
+func decodeJSON(docs []json.RawMessage, out interface{}) error {
+	if docs == nil {
+		docs = []json.RawMessage{}
+	}
+	data, err := json.Marshal(docs)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// latestRevisions takes revisions in the order they were written and returns them most recent
+// first. If a write was retried we may have an Etag more than once, we keep the latest.
+func latestRevisions(in []*v1.Revision) []*v1.Revision {
+	seen := map[string]bool{}
+	out := []*v1.Revision{}
+	for i := len(in) - 1; i >= 0; i-- {
+		if seen[in[i].Etag] {
+			continue
+		}
+		seen[in[i].Etag] = true
+		out = append(out, in[i])
+	}
+	return out
+}
+
+// objectEtags returns the current etags of the given objects
+func objectEtags(in []v1.Object) []string {
+	etags := make([]string, len(in))
+	for i, v := range in {
+		etags[i] = v.GetEtag()
+	}
+	return etags
+}
+
+// restoreEtags puts back the etags (see objectEtags) of objects that were not written, so that
+// only objects we wrote hold the new etag. A nil Result means nothing was written.
+func restoreEtags(in []v1.Object, etags []string, res *Result) {
+	for i, v := range in {
+		if res != nil {
+			if _, ok := res.Written[v.GetId()]; ok {
+				continue
+			}
+		}
+		v.SetEtag(etags[i])
+	}
+}
@@ -0,0 +1,12 @@
+package db
+
+const colWatchers = "watchers"
+
+// watcher is the lease of an event stream watching a world (see SetWatcher)
+type watcher struct {
+	Id    string `json:"_id"`
+	World string `json:"World"`
+
+	// Expires is when the lease ends unless renewed (unix nanoseconds)
+	Expires int64 `json:"Expires"`
+}
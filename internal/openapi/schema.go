@@ -0,0 +1,296 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	typeRawMessage = reflect.TypeOf(json.RawMessage{})
+)
+
+// Rule applies a custom validate tag (with its param, if any) to the schema of a field.
+type Rule func(s *Schema, param string)
+
+// Generator derives schemas from Go types, using their json tags for names & validate tags
+// (see github.com/go-playground/validator) for constraints.
+//
+// Named struct types are added to Schemas once & referred to by $ref, anonymous (embedded)
+// structs are flattened as encoding/json does.
+type Generator struct {
+	Schemas map[string]*Schema
+
+	// Rules hold the meaning of custom validate tags, unknown tags are ignored
+	Rules map[string]Rule
+
+	types map[string]reflect.Type
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		Schemas: map[string]*Schema{},
+		Rules:   map[string]Rule{},
+		types:   map[string]reflect.Type{},
+	}
+}
+
+// Schema returns the schema of a type, named structs are returned as a $ref (see Named)
+func (g *Generator) Schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == typeRawMessage:
+		return &Schema{} // any JSON
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return Ref(g.Named(t))
+	case t.Kind() == reflect.Struct:
+		return g.Inline(t)
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Minimum: float(0)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.Schema(t.Elem())}
+	case reflect.Map:
+		s := &Schema{Type: "object", AdditionalProperties: g.Schema(t.Elem())}
+		switch t.Key().Kind() {
+		case reflect.String:
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64:
+			s.PropertyNames = &Schema{Type: "string", Pattern: "^[0-9]+$"}
+		default:
+			s.PropertyNames = &Schema{Type: "string", Pattern: "^-?[0-9]+$"}
+		}
+		return s
+	}
+	return &Schema{}
+}
+
+// Named adds the schema of a named struct to Schemas (if it isn't already) and returns its name.
+func (g *Generator) Named(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	name := t.Name()
+	existing, ok := g.types[name]
+	if ok && existing != t {
+		// two packages have a type of the same name
+		name = strings.ReplaceAll(t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:], ".", "") + name
+		existing, ok = g.types[name]
+	}
+	if ok {
+		return name
+	}
+
+	// nb. we note the schema before filling it in, so that recursive types refer to it
+	s := &Schema{}
+	g.types[name] = t
+	g.Schemas[name] = s
+	*s = *g.Inline(t)
+	return name
+}
+
+// Inline returns the schema of a struct (rather than a $ref to it)
+func (g *Generator) Inline(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.addFields(s, t)
+	return s
+}
+
+// addFields adds the (exported) fields of a struct to a schema
+func (g *Generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fs := g.Schema(f.Type)
+		if g.applyTags(fs, f.Type, strings.Split(f.Tag.Get("validate"), ",")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fs
+	}
+}
+
+// applyTags applies validate tags to the schema of a type, returning if the tags require a value
+func (g *Generator) applyTags(s *Schema, t reflect.Type, tags []string) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	required := false
+	for i := 0; i < len(tags); i++ {
+		tag, param, _ := strings.Cut(strings.TrimSpace(tags[i]), "=")
+		switch tag {
+		case "":
+		case "required":
+			required = true
+		case "dive":
+			rest := tags[i+1:]
+			switch {
+			case s.Items != nil:
+				g.applyTags(s.Items, t.Elem(), rest)
+			case s.AdditionalProperties != nil:
+				if len(rest) > 0 && strings.TrimSpace(rest[0]) == "keys" {
+					end := len(rest)
+					for j, k := range rest {
+						if strings.TrimSpace(k) == "endkeys" {
+							end = j
+							break
+						}
+					}
+					if s.PropertyNames == nil {
+						s.PropertyNames = &Schema{Type: "string"}
+					}
+					g.applyTags(s.PropertyNames, t.Key(), rest[1:end])
+					rest = rest[min(end+1, len(rest)):]
+				}
+				g.applyTags(s.AdditionalProperties, t.Elem(), rest)
+			}
+			return required
+		default:
+			if s.Ref != "" {
+				continue // constraints of structs are those of their fields
+			}
+			rule, ok := g.Rules[tag]
+			if ok {
+				rule(s, param)
+			} else {
+				applyBuiltin(s, t, tag, param)
+			}
+		}
+	}
+	return required
+}
+
+// applyBuiltin applies one of the validators built into go-playground/validator
+func applyBuiltin(s *Schema, t reflect.Type, tag, param string) {
+	switch tag {
+	case "min", "gte":
+		setBound(s, t, param, true, false)
+	case "max", "lte":
+		setBound(s, t, param, false, false)
+	case "gt":
+		setBound(s, t, param, true, true)
+	case "lt":
+		setBound(s, t, param, false, true)
+	case "len":
+		setBound(s, t, param, true, false)
+		setBound(s, t, param, false, false)
+	case "oneof":
+		for _, v := range strings.Fields(param) {
+			v = strings.Trim(v, "'")
+			if s.Type == "integer" || s.Type == "number" {
+				n, err := strconv.ParseFloat(v, 64)
+				if err == nil {
+					s.Enum = append(s.Enum, n)
+				}
+				continue
+			}
+			s.Enum = append(s.Enum, v)
+		}
+	case "alphanum":
+		s.Pattern = "^[a-zA-Z0-9]+$"
+	case "alpha":
+		s.Pattern = "^[a-zA-Z]+$"
+	case "numeric":
+		s.Pattern = "^[-+]?[0-9]+(\\.[0-9]+)?$"
+	case "uuid4", "uuid":
+		s.Format = "uuid"
+	case "email":
+		s.Format = "email"
+	case "url", "uri":
+		s.Format = "uri"
+	}
+}
+
+// setBound sets a minimum (or maximum) on a schema, which for strings, lists & maps is
+// their length as it is for the validator.
+func setBound(s *Schema, t reflect.Type, param string, lower, exclusive bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		count := int64(n)
+		if exclusive && lower {
+			count++
+		} else if exclusive {
+			count--
+		}
+		switch {
+		case t.Kind() == reflect.String && lower:
+			s.MinLength = &count
+		case t.Kind() == reflect.String:
+			s.MaxLength = &count
+		case t.Kind() == reflect.Map && lower:
+			s.MinProperties = &count
+		case t.Kind() == reflect.Map:
+			s.MaxProperties = &count
+		case lower:
+			s.MinItems = &count
+		default:
+			s.MaxItems = &count
+		}
+	default:
+		switch {
+		case exclusive && lower:
+			s.ExclusiveMinimum = &n
+		case exclusive:
+			s.ExclusiveMaximum = &n
+		case lower:
+			s.Minimum = &n
+		default:
+			s.Maximum = &n
+		}
+	}
+}
+
+func float(v float64) *float64 {
+	return &v
+}
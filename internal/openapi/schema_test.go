@@ -0,0 +1,70 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMeta struct {
+	Id     string            `json:"_id" validate:"valid_id"`
+	Labels map[string]string `json:"Labels" validate:"max=5,dive,keys,alphanum,endkeys"`
+}
+
+type testObject struct {
+	testMeta `json:",inline"`
+
+	Name     string             `json:"Name" validate:"required,min=1,max=10"`
+	Level    uint64             `json:"Level" validate:"gt=2"`
+	Type     string             `json:"Type" validate:"oneof=a b ''"`
+	Children []*testObject      `json:"Children" validate:"max=3,dive"`
+	Ethos    map[string]float64 `json:"Ethos" validate:"dive,gte=0,lte=100"`
+	Ticks    map[uint64]int64   `json:"Ticks"`
+	Raw      json.RawMessage    `json:"Raw"`
+	Ignored  string             `json:"-"`
+}
+
+func TestSchema(t *testing.T) {
+	g := NewGenerator()
+	g.Rules["valid_id"] = func(s *Schema, param string) { s.Format = "uuid" }
+
+	s := g.Schema(reflect.TypeOf(&testObject{}))
+	assert.Equal(t, Ref("testObject"), s)
+
+	obj := g.Schemas["testObject"]
+	assert.NotNil(t, obj)
+	assert.Equal(t, "object", obj.Type)
+	assert.Equal(t, []string{"Name"}, obj.Required)
+	assert.ElementsMatch(t, []string{"_id", "Labels", "Name", "Level", "Type", "Children", "Ethos", "Ticks", "Raw"}, keys(obj.Properties))
+
+	// embedded structs are flattened, custom rules apply
+	assert.Equal(t, &Schema{Type: "string", Format: "uuid"}, obj.Properties["_id"])
+	assert.Equal(t, int64(5), *obj.Properties["Labels"].MaxProperties)
+	assert.Equal(t, "^[a-zA-Z0-9]+$", obj.Properties["Labels"].PropertyNames.Pattern)
+
+	// bounds are lengths for strings, values for numbers
+	assert.Equal(t, int64(1), *obj.Properties["Name"].MinLength)
+	assert.Equal(t, int64(10), *obj.Properties["Name"].MaxLength)
+	assert.Equal(t, 2.0, *obj.Properties["Level"].ExclusiveMinimum)
+	assert.Equal(t, []interface{}{"a", "b", ""}, obj.Properties["Type"].Enum)
+
+	// recursive types refer to themselves
+	assert.Equal(t, int64(3), *obj.Properties["Children"].MaxItems)
+	assert.Equal(t, Ref("testObject"), obj.Properties["Children"].Items)
+
+	// dive applies to map values, numeric keys are patterns
+	assert.Equal(t, 0.0, *obj.Properties["Ethos"].AdditionalProperties.Minimum)
+	assert.Equal(t, 100.0, *obj.Properties["Ethos"].AdditionalProperties.Maximum)
+	assert.Equal(t, "^[0-9]+$", obj.Properties["Ticks"].PropertyNames.Pattern)
+	assert.Equal(t, &Schema{}, obj.Properties["Raw"])
+}
+
+func keys(in map[string]*Schema) []string {
+	out := []string{}
+	for k := range in {
+		out = append(out, k)
+	}
+	return out
+}
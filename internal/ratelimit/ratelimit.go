@@ -0,0 +1,132 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Default is the name of the limit applied to routes without a limit of their own
+	Default = "default"
+
+	// idleAfter is how long a bucket is kept without use, by then it has refilled anyway
+	idleAfter = 10 * time.Minute
+)
+
+// Limit allows Rate requests per second from each client, with bursts of up to Burst.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Parse reads limits of the form "route=rate:burst" or "route=rate" (where the burst is the
+// rate rounded up), ie. "default=50:100", "search=5:10".
+func Parse(in []string) (map[string]*Limit, error) {
+	limits := map[string]*Limit{}
+	for _, v := range in {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		route, value, ok := strings.Cut(v, "=")
+		if !ok || route == "" {
+			return nil, fmt.Errorf("rate limit %q should be route=rate:burst", v)
+		}
+		rate, burst, hasBurst := strings.Cut(value, ":")
+
+		l := &Limit{}
+		var err error
+		l.Rate, err = strconv.ParseFloat(rate, 64)
+		if err != nil || l.Rate <= 0 {
+			return nil, fmt.Errorf("rate limit %q has invalid rate %q", v, rate)
+		}
+		if hasBurst {
+			l.Burst, err = strconv.Atoi(burst)
+			if err != nil || l.Burst < 1 {
+				return nil, fmt.Errorf("rate limit %q has invalid burst %q", v, burst)
+			}
+		} else {
+			l.Burst = int(math.Ceil(l.Rate))
+		}
+		limits[route] = l
+	}
+	return limits, nil
+}
+
+// bucket holds the tokens of one client on one route
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter is a token bucket rate limiter, keeping a bucket per route & client.
+type Limiter struct {
+	limits map[string]*Limit
+
+	lock    sync.Mutex
+	buckets map[string]*bucket
+	pruned  time.Time
+	now     func() time.Time
+}
+
+// New returns a Limiter applying the given limits by route name, routes without a limit use
+// the Default limit (if any) or are not limited.
+func New(limits map[string]*Limit) *Limiter {
+	return &Limiter{
+		limits:  limits,
+		lock:    sync.Mutex{},
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token for the client on a route, if there are none it returns false along
+// with how long until there will be.
+func (l *Limiter) Allow(route, client string) (bool, time.Duration) {
+	limit, ok := l.limits[route]
+	if !ok {
+		limit, ok = l.limits[Default]
+		if !ok {
+			return true, 0
+		}
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	key := route + "," + client
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+}
+
+// prune forgets buckets that haven't been used in a while, a new bucket is full so there's
+// no difference to the client.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.pruned) < idleAfter {
+		return
+	}
+	l.pruned = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > idleAfter {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	limits, err := Parse([]string{"default=50:100", "search=2.5"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*Limit{
+		"default": {Rate: 50, Burst: 100},
+		"search":  {Rate: 2.5, Burst: 3},
+	}, limits)
+
+	for _, bad := range []string{"search", "=5", "search=0", "search=x", "search=5:0"} {
+		_, err = Parse([]string{bad})
+		assert.NotNil(t, err, bad)
+	}
+}
+
+func TestAllow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := New(map[string]*Limit{Default: {Rate: 10, Burst: 10}, "search": {Rate: 1, Burst: 2}})
+	l.now = func() time.Time { return now }
+
+	// a burst is allowed, then we wait for a token
+	for i := 0; i < 2; i++ {
+		ok, _ := l.Allow("search", "bob")
+		assert.True(t, ok)
+	}
+	ok, wait := l.Allow("search", "bob")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	// other clients & routes have their own buckets
+	ok, _ = l.Allow("search", "alice")
+	assert.True(t, ok)
+	ok, _ = l.Allow("get", "bob")
+	assert.True(t, ok)
+
+	// tokens refill over time
+	now = now.Add(500 * time.Millisecond)
+	ok, wait = l.Allow("search", "bob")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = l.Allow("search", "bob")
+	assert.True(t, ok)
+
+	// routes are unlimited without a default
+	l = New(map[string]*Limit{"search": {Rate: 1, Burst: 1}})
+	for i := 0; i < 100; i++ {
+		ok, _ = l.Allow("get", "bob")
+		assert.True(t, ok)
+	}
+}
@@ -13,6 +13,9 @@ type Search interface {
 	// Delete removes an object from the search index.
 	Delete(ctx context.Context, world, kind, id string) error
 
+	// DeleteWorld removes the search indexes of the given kinds in a world.
+	DeleteWorld(ctx context.Context, world string, kinds []string) error
+
 	// Find returns a list of IDs that match the given query.
 	// IDs are returned in order of relevance based on given scoring, most relevant first.
 	Find(ctx context.Context, world string, q *v1.Query) ([]string, error)
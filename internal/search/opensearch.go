@@ -85,6 +85,40 @@ func (s *Opensearch) Delete(ctx context.Context, world, kind, id string) error {
 	return s.delete(ctx, world_index(world, kind), id)
 }
 
+func (s *Opensearch) DeleteWorld(ctx context.Context, world string, kinds []string) error {
+	pan := log.NewSpan(ctx, "opensearch.deleteWorld", map[string]interface{}{"world": world, "kinds": len(kinds)})
+	defer pan.End()
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	indices := []string{}
+	for _, k := range kinds {
+		indices = append(indices, world_index(world, k))
+	}
+
+	// stop any bulk indexers for the indices we're about to remove
+	s.bulklock.Lock()
+	for _, index := range indices {
+		if b, ok := s.bulk[index]; ok {
+			b.Close()
+			delete(s.bulk, index)
+		}
+	}
+	s.bulklock.Unlock()
+
+	ignore := true
+	_, err := s.api.Indices.Delete(ctx, opensearchapi.IndicesDeleteReq{
+		Indices: indices,
+		Params:  opensearchapi.IndicesDeleteParams{IgnoreUnavailable: &ignore},
+	})
+	if err != nil {
+		s.l.Error().Err(err).Str("world", world).Strs("indices", indices).Msg("failed to delete indices")
+		return pan.Err(err)
+	}
+	return nil
+}
+
 func (s *Opensearch) Find(ctx context.Context, world string, q *v1.Query) ([]string, error) {
 	pan := log.NewSpan(ctx, "opensearch.find", map[string]interface{}{"world": world, "kind": q.Kind})
 	defer pan.End()
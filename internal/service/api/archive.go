@@ -0,0 +1,342 @@
+package api
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// archiveWorld is the name of the world object in an archive, which comes first
+	archiveWorld = "world.yaml"
+
+	// archivePageSize is how many objects we read (or write) at once, each page of a
+	// kind is a file in the archive
+	archivePageSize int64 = 1000
+
+	// archiveMaxLine is the largest object (in bytes) that we accept in an archive
+	archiveMaxLine = 16 * 1024 * 1024
+)
+
+// exportWorld writes a tar archive of a world to w.
+//
+// The archive holds the world object as world.yaml, followed by each kind within the world
+// as <kind>/<page>.ndjson files with one object per line, & ends with an api.ArchiveEnd. Etags
+// are removed so that the archive can be imported anywhere (see importWorld).
+//
+// An export as a whole has no deadline, so each page is given its own. If we fail having
+// begun the archive it ends with the error.
+func (s *Service) exportWorld(ctx context.Context, world string, w io.Writer) error {
+	pan := log.NewSpan(ctx, "service.exportWorld", map[string]interface{}{"world": world})
+	defer pan.End()
+
+	found := []map[string]interface{}{}
+	pctx, cancel := context.WithTimeout(ctx, s.cfg.TimeoutRead)
+	err := s.db.Get(pctx, "", kindWorld, []string{world}, &found)
+	cancel()
+	if err != nil {
+		return pan.Err(err)
+	} else if len(found) == 0 {
+		return pan.Err(fmt.Errorf("%w world %s not found", ErrNotFound, world))
+	}
+	delete(found[0], "_etag")
+	data, err := yaml.Marshal(found[0])
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	tw := tar.NewWriter(w)
+	counts, err := s.exportObjects(ctx, world, tw, data)
+	if err != nil {
+		end := &api.ArchiveEnd{Error: &api.ErrorResponse{Code: errorCodeHTTP(err), Message: err.Error()}}
+		if eerr := writeArchiveEnd(tw, end); eerr != nil {
+			s.log.Warn().Str("world", world).Err(eerr).Msg("failed to end archive")
+		}
+		return pan.Err(err)
+	}
+	return pan.Err(writeArchiveEnd(tw, &api.ArchiveEnd{Counts: counts}))
+}
+
+// exportObjects writes the world & the objects within it to an archive, returning how many
+// of each kind were written
+func (s *Service) exportObjects(ctx context.Context, world string, tw *tar.Writer, data []byte) (map[string]int64, error) {
+	counts := map[string]int64{}
+	err := writeArchiveFile(tw, archiveWorld, data)
+	if err != nil {
+		return counts, err
+	}
+	counts[kindWorld] = 1
+
+	for _, k := range archiveKinds() {
+		after := ""
+		for page := 0; ; page++ {
+			objects := []map[string]interface{}{}
+			pctx, cancel := context.WithTimeout(ctx, s.cfg.TimeoutRead)
+			err = s.db.ListAfter(pctx, world, k, nil, after, archivePageSize, &objects)
+			cancel()
+			if err != nil {
+				return counts, err
+			}
+			if len(objects) == 0 {
+				break
+			}
+
+			buf := bytes.Buffer{}
+			for _, obj := range objects {
+				delete(obj, "_etag")
+				line, err := json.Marshal(obj)
+				if err != nil {
+					return counts, err
+				}
+				buf.Write(line)
+				buf.WriteByte('\n')
+			}
+			err = writeArchiveFile(tw, fmt.Sprintf("%s/%06d.ndjson", k, page), buf.Bytes())
+			if err != nil {
+				return counts, err
+			}
+			counts[k] += int64(len(objects))
+
+			if len(objects) < int(archivePageSize) {
+				break
+			}
+			after, _ = objects[len(objects)-1]["_id"].(string)
+		}
+	}
+	return counts, nil
+}
+
+// importWorld recreates a world from an archive written by exportWorld under the given world id,
+// which must not already exist. Objects keep their ids so references between them still hold.
+//
+// Objects are written as they are read, so if an import fails part way (including if the archive
+// turns out to be incomplete) the world should be deleted before trying again. An import as a
+// whole has no deadline, so each page of objects is given its own.
+func (s *Service) importWorld(ctx context.Context, world string, r io.Reader, rsp *api.ImportResponse) error {
+	pan := log.NewSpan(ctx, "service.importWorld", map[string]interface{}{"world": world})
+	defer pan.End()
+
+	rsp.World = world
+	rsp.Counts = map[string]int64{}
+
+	tr := tar.NewReader(r)
+	imported := false
+	ended := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return pan.Err(fmt.Errorf("%w reading archive: %v", ErrInvalid, err))
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if ended {
+			return pan.Err(fmt.Errorf("%w unexpected file %s after %s", ErrInvalid, hdr.Name, api.ArchiveEndFile))
+		}
+
+		if hdr.Name == api.ArchiveEndFile {
+			err = checkArchiveEnd(tr, rsp.Counts)
+			if err != nil {
+				return pan.Err(err)
+			}
+			ended = true
+			continue
+		}
+		if hdr.Name == archiveWorld {
+			if imported {
+				return pan.Err(fmt.Errorf("%w archive holds more than one world", ErrInvalid))
+			}
+			err = s.importWorldObject(ctx, world, tr)
+			if err != nil {
+				return pan.Err(err)
+			}
+			imported = true
+			rsp.Counts[kindWorld] = 1
+			continue
+		}
+		if !imported {
+			return pan.Err(fmt.Errorf("%w archive must begin with %s", ErrInvalid, archiveWorld))
+		}
+
+		k := path.Dir(hdr.Name)
+		if !kind.IsValid(k) || kind.IsGlobal(k) {
+			return pan.Err(fmt.Errorf("%w unexpected file %s in archive", ErrInvalid, hdr.Name))
+		}
+		count, err := s.importObjects(ctx, world, k, hdr.Name, tr)
+		rsp.Counts[k] += count
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+
+	if !imported {
+		return pan.Err(fmt.Errorf("%w archive has no %s", ErrInvalid, archiveWorld))
+	} else if !ended {
+		return pan.Err(fmt.Errorf("%w archive is incomplete, it has no %s", ErrInvalid, api.ArchiveEndFile))
+	}
+	pan.SetAttributes(map[string]interface{}{"counts": rsp.Counts})
+	return nil
+}
+
+// importWorldObject creates the world from an archive & informs the tick manager of it
+func (s *Service) importWorldObject(ctx context.Context, world string, r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, archiveMaxLine))
+	if err != nil {
+		return err
+	}
+	obj, err := kind.New(kindWorld, data)
+	if err != nil {
+		return fmt.Errorf("%w reading %s: %v", ErrInvalid, archiveWorld, err)
+	}
+	obj.SetId(world)
+	obj.SetEtag("")
+
+	err = kind.Validate(kindWorld, obj)
+	if err != nil {
+		return fmt.Errorf("%w %s: %v", ErrInvalid, archiveWorld, err)
+	}
+
+	wctx, cancel := context.WithTimeout(ctx, s.cfg.TimeoutWrite)
+	defer cancel()
+	_, err = s.writeObjects(wctx, "", kindWorld, []v1.Object{obj})
+	if err != nil {
+		return err
+	}
+	return s.tickManager.seed(obj.(*v1.World))
+}
+
+// importObjects writes the objects of a kind held in one file of an archive
+func (s *Service) importObjects(ctx context.Context, world, k, name string, r io.Reader) (int64, error) {
+	var count int64
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), archiveMaxLine)
+
+	batch := []v1.Object{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		wctx, cancel := context.WithTimeout(ctx, s.cfg.TimeoutWrite)
+		defer cancel()
+		_, err := s.writeObjects(wctx, world, k, batch)
+		if err != nil {
+			return err
+		}
+		count += int64(len(batch))
+		batch = []v1.Object{}
+		return nil
+	}
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		obj, err := kind.New(k, data)
+		if err != nil {
+			return count, fmt.Errorf("%w %s line %d: %v", ErrInvalid, name, line, err)
+		}
+		obj.SetWorld(world)
+		obj.SetEtag("")
+		err = kind.Validate(k, obj)
+		if err != nil {
+			return count, fmt.Errorf("%w %s line %d: %v", ErrInvalid, name, line, err)
+		}
+
+		batch = append(batch, obj)
+		if len(batch) >= int(archivePageSize) {
+			err = flush()
+			if err != nil {
+				return count, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return count, fmt.Errorf("%w reading %s: %v", ErrInvalid, name, err)
+	}
+	err := flush()
+	return count, err
+}
+
+// checkArchiveEnd reads the end of an archive (see api.ArchiveEnd), returning an error if the
+// export failed or the archive does not hold what it should
+func checkArchiveEnd(r io.Reader, counts map[string]int64) error {
+	data, err := io.ReadAll(io.LimitReader(r, archiveMaxLine))
+	if err != nil {
+		return err
+	}
+	end := &api.ArchiveEnd{}
+	err = json.Unmarshal(data, end)
+	if err != nil {
+		return fmt.Errorf("%w reading %s: %v", ErrInvalid, api.ArchiveEndFile, err)
+	}
+	if end.Error != nil {
+		return fmt.Errorf("%w archive is of an export that failed: %s", ErrInvalid, end.Error.Message)
+	}
+	for _, k := range append(archiveKinds(), kindWorld) {
+		if end.Counts[k] != counts[k] {
+			return fmt.Errorf("%w archive is incomplete, it should hold %d %s objects but has %d", ErrInvalid, end.Counts[k], k, counts[k])
+		}
+	}
+	return nil
+}
+
+// archiveKinds returns the kinds held within a world in the order we archive them
+func archiveKinds() []string {
+	kinds := []string{}
+	for _, k := range kind.Kinds() {
+		if !kind.IsGlobal(k) {
+			kinds = append(kinds, k)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// writeArchiveEnd ends the archive (see api.ArchiveEnd)
+func writeArchiveEnd(tw *tar.Writer, end *api.ArchiveEnd) error {
+	data, err := json.Marshal(end)
+	if err != nil {
+		return err
+	}
+	err = writeArchiveFile(tw, api.ArchiveEndFile, data)
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// writeArchiveFile adds a file to the archive
+func writeArchiveFile(tw *tar.Writer, name string, data []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/voidshard/faction/internal/db"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	svc, mem := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	aslan := newTestActor(map[string]string{"class": "noble"})
+	aslan.Firstname = "Aslan"
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{aslan, newTestActor(nil)}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	exported := getActors(t, svc, "narnia")
+
+	// the race & culture of newTestWorld are not valid enough to import
+	assert.Nil(t, mem.Delete(ctx, "narnia", "race", "human", ""))
+	assert.Nil(t, mem.Delete(ctx, "narnia", "culture", "nord", ""))
+
+	buf := bytes.Buffer{}
+	err = svc.exportWorld(ctx, "narnia", &buf)
+	assert.Nil(t, err)
+	archive := buf.Bytes()
+
+	rsp := &api.ImportResponse{}
+	err = svc.importWorld(ctx, "oz", bytes.NewReader(archive), rsp)
+	assert.Nil(t, err)
+	assert.Equal(t, "oz", rsp.World)
+	assert.Equal(t, map[string]int64{"world": 1, "actor": 2}, rsp.Counts)
+
+	// objects keep their ids & fields, but not their etags
+	imported := getActors(t, svc, "oz")
+	assert.Len(t, imported, 2)
+	byId := map[string]*v1.Actor{}
+	for _, a := range imported {
+		byId[a.Id] = a
+	}
+	for _, a := range exported {
+		got, ok := byId[a.Id]
+		assert.True(t, ok)
+		if !ok {
+			continue
+		}
+		assert.Equal(t, "oz", got.World)
+		assert.Equal(t, a.Firstname, got.Firstname)
+		assert.Equal(t, a.Labels, got.Labels)
+		assert.NotEqual(t, a.Etag, got.Etag)
+	}
+
+	// the world must not already exist
+	err = svc.importWorld(ctx, "oz", bytes.NewReader(archive), &api.ImportResponse{})
+	assert.ErrorIs(t, err, db.ErrDuplicate)
+}
+
+func TestImportInvalid(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	// archives must begin with the world
+	buf := bytes.Buffer{}
+	tw := tar.NewWriter(&buf)
+	assert.Nil(t, writeArchiveFile(tw, "actor/000000.ndjson", []byte(`{"Race": "human", "Culture": "nord"}`+"\n")))
+	assert.Nil(t, tw.Close())
+
+	err := svc.importWorld(ctx, "oz", bytes.NewReader(buf.Bytes()), &api.ImportResponse{})
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	// & hold only kinds within a world
+	buf = bytes.Buffer{}
+	tw = tar.NewWriter(&buf)
+	assert.Nil(t, writeArchiveFile(tw, archiveWorld, []byte("Id: oz\n")))
+	assert.Nil(t, writeArchiveFile(tw, "world/000000.ndjson", []byte(`{"Id": "narnia"}`+"\n")))
+	assert.Nil(t, tw.Close())
+
+	err = svc.importWorld(ctx, "oz", bytes.NewReader(buf.Bytes()), &api.ImportResponse{})
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+// rewriteArchive copies an archive without its end, which is replaced by the given end (if any)
+func rewriteArchive(t *testing.T, archive []byte, end *api.ArchiveEnd) []byte {
+	buf := bytes.Buffer{}
+	tw := tar.NewWriter(&buf)
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		if hdr.Name == api.ArchiveEndFile {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		assert.Nil(t, err)
+		assert.Nil(t, writeArchiveFile(tw, hdr.Name, data))
+	}
+	if end == nil {
+		assert.Nil(t, tw.Close())
+	} else {
+		assert.Nil(t, writeArchiveEnd(tw, end))
+	}
+	return buf.Bytes()
+}
+
+func TestImportIncomplete(t *testing.T) {
+	svc, mem := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil), newTestActor(nil)}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	assert.Nil(t, mem.Delete(ctx, "narnia", "race", "human", ""))
+	assert.Nil(t, mem.Delete(ctx, "narnia", "culture", "nord", ""))
+
+	buf := bytes.Buffer{}
+	err = svc.exportWorld(ctx, "narnia", &buf)
+	assert.Nil(t, err)
+	archive := buf.Bytes()
+
+	// an archive without an end was cut short
+	err = svc.importWorld(ctx, "oz", bytes.NewReader(rewriteArchive(t, archive, nil)), &api.ImportResponse{})
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	// as is one without all that it should hold
+	end := &api.ArchiveEnd{Counts: map[string]int64{"world": 1, "actor": 3}}
+	err = svc.importWorld(ctx, "ozma", bytes.NewReader(rewriteArchive(t, archive, end)), &api.ImportResponse{})
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	// or an export that failed
+	end = &api.ArchiveEnd{Error: &api.ErrorResponse{Code: 500, Message: "boom"}}
+	err = svc.importWorld(ctx, "emerald", bytes.NewReader(rewriteArchive(t, archive, end)), &api.ImportResponse{})
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	// while a complete archive imports
+	end = &api.ArchiveEnd{Counts: map[string]int64{"world": 1, "actor": 2}}
+	err = svc.importWorld(ctx, "winkie", bytes.NewReader(rewriteArchive(t, archive, end)), &api.ImportResponse{})
+	assert.Nil(t, err)
+}
+
+func TestImportOutlastsTimeouts(t *testing.T) {
+	svc, mem := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil)}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	assert.Nil(t, mem.Delete(ctx, "narnia", "race", "human", ""))
+	assert.Nil(t, mem.Delete(ctx, "narnia", "culture", "nord", ""))
+	buf := bytes.Buffer{}
+	assert.Nil(t, svc.exportWorld(ctx, "narnia", &buf))
+	archive := buf.Bytes()
+
+	svc.cfg.TimeoutRead = 200 * time.Millisecond
+	svc.cfg.TimeoutWrite = 200 * time.Millisecond
+	srv := &Server{cfg: svc.cfg, log: log.Sublogger("test"), svc: svc}
+	router := mux.NewRouter()
+	router.HandleFunc("/{world}/import", srv.importWorld)
+	hs := httptest.NewUnstartedServer(router)
+	hs.Config.ReadTimeout = svc.cfg.TimeoutRead
+	hs.Config.WriteTimeout = svc.cfg.TimeoutWrite
+	hs.Start()
+	defer hs.Close()
+
+	// the archive takes longer to send than any timeout, but each read is well within them
+	pr, pw := io.Pipe()
+	go func() {
+		for i := 0; i < len(archive); i += len(archive) / 5 {
+			time.Sleep(100 * time.Millisecond)
+			pw.Write(archive[i:min(i+len(archive)/5, len(archive))])
+		}
+		pw.Close()
+	}()
+
+	resp, err := http.Post(hs.URL+"/oz/import", "application/x-tar", pr)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	rsp := &api.ImportResponse{}
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(rsp))
+	assert.Equal(t, http.StatusOK, resp.StatusCode, rsp.Error)
+	assert.Equal(t, map[string]int64{"world": 1, "actor": 1}, rsp.Counts)
+}
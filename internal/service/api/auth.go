@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/voidshard/faction/internal/auth"
+	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// kindRelation is the kind rules name to grant access to relations (tuples & modifiers)
+	kindRelation = "relation"
+)
+
+// bearerToken returns the token of an "Authorization: Bearer <token>" header
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// authenticate is middleware that authenticates the bearer token of each request (if auth is
+// configured), the Identity of the caller is carried in the request context for handlers
+// to authorize against.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.cfg.Auth == nil || r.URL.Path == "/_health" || r.URL.Path == openapiPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		id, err := s.cfg.Auth.Authenticate(bearerToken(r.Header.Get("Authorization")))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			resp := &api.ErrorResponse{Code: http.StatusUnauthorized, Message: err.Error()}
+			s.writeResp(w, http.StatusUnauthorized, &struct{ Error *api.ErrorResponse }{resp})
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), id)))
+	})
+}
+
+// grpcAuthenticate authenticates the bearer token in the "authorization" metadata of a call
+func (s *Server) grpcAuthenticate(ctx context.Context) (context.Context, error) {
+	if s.cfg.Auth == nil {
+		return ctx, nil
+	}
+	token := ""
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok && len(md.Get("authorization")) > 0 {
+		token = bearerToken(md.Get("authorization")[0])
+	}
+	id, err := s.cfg.Auth.Authenticate(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return auth.WithIdentity(ctx, id), nil
+}
+
+func (s *Server) unaryAuthenticate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.grpcAuthenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamAuthenticate(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.grpcAuthenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authedStream is a stream carrying the Identity of the caller in its context
+type authedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a *authedStream) Context() context.Context {
+	return a.ctx
+}
+
+// authorize checks that the caller (see auth.FromContext) may perform the verb on a kind within
+// a world. Anyone may if auth is not configured.
+func (s *Service) authorize(ctx context.Context, verb, world, k string) error {
+	if s.cfg.Auth == nil {
+		return nil
+	}
+	return s.cfg.Auth.Authorize(auth.FromContext(ctx), verb, world, k)
+}
+
+// authorizeIds is authorize for operations on objects by id, global kinds aren't within a world
+// so are authorized as such, except for worlds which are authorized by their id.
+func (s *Service) authorizeIds(ctx context.Context, verb, world, k string, ids []string) error {
+	if !kind.IsGlobal(k) {
+		return s.authorize(ctx, verb, world, k)
+	}
+	if k != kindWorld || len(ids) == 0 {
+		return s.authorize(ctx, verb, "", k)
+	}
+	for _, id := range ids {
+		err := s.authorize(ctx, verb, id, k)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectIds returns the ids of raw objects (as read from a request)
+func objectIds(data []interface{}) []string {
+	ids := []string{}
+	for _, raw := range data {
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := obj["_id"].(string)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// patchIds returns the ids of the objects patches apply to
+func patchIds(data []*api.Patch) []string {
+	ids := make([]string, len(data))
+	for i, p := range data {
+		ids[i] = p.Id
+	}
+	return ids
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/voidshard/faction/internal/auth"
+	"github.com/voidshard/faction/internal/db"
+	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+)
+
+const (
+	// bulkChunkSize is how many objects of a bulk write we write at once
+	bulkChunkSize = 1000
+)
+
+// bulkLine is an object read from a bulk write along with the line it came from
+type bulkLine struct {
+	line int64
+	obj  v1.Object
+}
+
+// bulkSet reads newline delimited objects from r and writes them a chunk at a time, calling emit
+// with the result of each line as each chunk is written.
+//
+// Each line is an object of the given kind, or if no kind is given, any kind (inferred from the
+// object's _kind). Objects within the world are written to the given world. Lines that cannot be
+// written (they are invalid, the caller may not write them, they are out of date or duplicates,
+// or their chunk failed to write) are reported & skipped. Only failing to read r or to emit a
+// result ends the write.
+func (s *Service) bulkSet(ctx context.Context, world, k string, r io.Reader, emit func(*api.BulkResult) error) error {
+	pan := log.NewSpan(ctx, "service.bulkSet", map[string]interface{}{"world": world, "kind": k})
+	defer pan.End()
+
+	var written int64
+
+	chunk := []*bulkLine{}
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		count, err := s.bulkWrite(ctx, world, chunk, emit)
+		written += count
+		chunk = []*bulkLine{}
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), archiveMaxLine)
+
+	var line int64
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		obj, err := bulkObject(world, k, data)
+		if err == nil {
+			err = s.authorizeIds(ctx, auth.VerbSet, world, obj.GetKind(), []string{obj.GetId()})
+		}
+		if err != nil {
+			err = emit(&api.BulkResult{Line: line, Error: &api.ErrorResponse{Code: errorCodeHTTP(err), Message: err.Error()}})
+			if err != nil {
+				return pan.Err(err)
+			}
+			continue
+		}
+
+		// nb. a chunk holds one kind, as we write & index by kind
+		if len(chunk) > 0 && chunk[0].obj.GetKind() != obj.GetKind() {
+			err = flush()
+			if err != nil {
+				return pan.Err(err)
+			}
+		}
+		chunk = append(chunk, &bulkLine{line: line, obj: obj})
+		if len(chunk) >= bulkChunkSize {
+			err = flush()
+			if err != nil {
+				return pan.Err(err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return pan.Err(fmt.Errorf("%w reading line %d: %v", ErrInvalid, line+1, err))
+	}
+	err := flush()
+
+	pan.SetAttributes(map[string]interface{}{"lines": line, "written": written})
+	return pan.Err(err)
+}
+
+// bulkObject reads & validates one line of a bulk write
+func bulkObject(world, k string, data []byte) (v1.Object, error) {
+	obj, err := kind.New(k, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w %v", ErrInvalid, err)
+	}
+	objKind := obj.GetKind() // nb. if a kind is given it is forced on the object
+
+	if kind.IsGlobal(objKind) {
+		obj.SetWorld("")
+	} else if world == "" {
+		return nil, fmt.Errorf("%w world required for kind %s", ErrInvalid, objKind)
+	} else {
+		obj.SetWorld(world)
+	}
+
+	err = kind.Validate(objKind, obj)
+	if err != nil {
+		return nil, fmt.Errorf("object invalid: %w %v", ErrInvalid, err)
+	}
+	return obj, nil
+}
+
+// bulkWrite writes a chunk of objects of one kind & emits the result of each, returning how many
+// were written. Write errors are reported against the lines they affect rather than returned.
+//
+// The bulk write as a whole has no deadline, so each chunk is given its own.
+func (s *Service) bulkWrite(ctx context.Context, world string, chunk []*bulkLine, emit func(*api.BulkResult) error) (int64, error) {
+	cctx, cancel := context.WithTimeout(ctx, s.cfg.TimeoutWrite)
+	defer cancel()
+
+	k := chunk[0].obj.GetKind()
+	if kind.IsGlobal(k) {
+		world = ""
+	}
+
+	objects := make([]v1.Object, len(chunk))
+	for i, l := range chunk {
+		objects[i] = l.obj
+	}
+
+	// objects with missing references (or that change their DeletionTick) are reported &
+	// skipped, rather than failing the chunk
+	invalid, err := s.keepDeletionTicks(cctx, world, k, objects)
+	if err != nil {
+		return 0, bulkFailed(ctx, k, chunk, err, emit)
+	}
+	missing, err := s.missingReferences(cctx, world, objects)
+	if err != nil {
+		return 0, bulkFailed(ctx, k, chunk, err, emit)
+	}
+	valid := []*bulkLine{}
+	objects = []v1.Object{}
+	for i, l := range chunk {
+		rerr := invalid[i]
+		if rerr == nil {
+			rerr = referencesError(missing[i])
+		}
+		if rerr == nil {
+			valid = append(valid, l)
+			objects = append(objects, l.obj)
+			continue
+		}
+		err = emit(&api.BulkResult{Line: l.line, Kind: k, Id: l.obj.GetId(), Error: &api.ErrorResponse{Code: errorCodeHTTP(rerr), Message: rerr.Error()}})
+		if err != nil {
+			return 0, err
+		}
+	}
+	if len(valid) == 0 {
+		return 0, nil
+	}
+	chunk = valid
+
+	// nb. the database sets Etags on objects as it writes them
+	creates := make([]bool, len(chunk))
+	for i, l := range chunk {
+		creates[i] = l.obj.GetEtag() == ""
+	}
+
+	s.shutdownLock.RLock()
+	var result *db.Result
+	if s.shuttingDown {
+		err = ErrShuttingDown
+	} else {
+		err = s.checkObjectQuota(cctx, world, k, objects)
+		if err == nil {
+			result, err = s.writeObjects(cctx, world, k, objects)
+		}
+	}
+	s.shutdownLock.RUnlock()
+
+	if err != nil && result == nil {
+		return 0, bulkFailed(ctx, k, chunk, err, emit)
+	}
+
+	// otherwise anything not written was either a duplicate (if it was a create) or out of date
+	var count int64
+	for i, l := range chunk {
+		res := &api.BulkResult{Line: l.line, Kind: k, Id: l.obj.GetId()}
+		etag, ok := result.Written[l.obj.GetId()]
+		if ok {
+			res.Etag = etag
+			count++
+		} else if creates[i] {
+			res.Error = &api.ErrorResponse{Code: errorCodeHTTP(db.ErrDuplicate), Message: db.ErrDuplicate.Error()}
+		} else {
+			res.Error = &api.ErrorResponse{Code: errorCodeHTTP(db.ErrEtagMismatch), Message: db.ErrEtagMismatch.Error()}
+		}
+		err = emit(res)
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// bulkFailed reports an error that failed a chunk as a whole against each of its lines, so that
+// we can carry on with the next chunk. It returns an error only if the caller has gone.
+func bulkFailed(ctx context.Context, k string, chunk []*bulkLine, err error, emit func(*api.BulkResult) error) error {
+	for _, l := range chunk {
+		eerr := emit(&api.BulkResult{Line: l.line, Kind: k, Id: l.obj.GetId(), Error: &api.ErrorResponse{Code: errorCodeHTTP(err), Message: err.Error()}})
+		if eerr != nil {
+			return eerr
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+// bulkLines encodes objects as the lines of a bulk write
+func bulkLines(t *testing.T, objects ...interface{}) []string {
+	lines := []string{}
+	for _, obj := range objects {
+		data, err := json.Marshal(obj)
+		assert.Nil(t, err)
+		lines = append(lines, string(data))
+	}
+	return lines
+}
+
+// runBulkSet runs a bulk write of the given lines & returns the results by line
+func runBulkSet(t *testing.T, svc *Service, world, k string, lines []string) map[int64]*api.BulkResult {
+	results := map[int64]*api.BulkResult{}
+	err := svc.bulkSet(context.Background(), world, k, strings.NewReader(strings.Join(lines, "\n")), func(res *api.BulkResult) error {
+		_, seen := results[res.Line]
+		assert.False(t, seen, "line %d reported twice", res.Line)
+		results[res.Line] = res
+		return nil
+	})
+	assert.Nil(t, err)
+	return results
+}
+
+func TestBulkSet(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil)}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	existing := getActors(t, svc, "narnia")[0]
+
+	dupe := newTestActor(nil)
+	dupe.Id = existing.Id
+	stale := *existing
+	stale.Etag = uuid.New()
+	stale.Firstname = "Jadis"
+
+	lines := bulkLines(t, newTestActor(nil), dupe, &stale)
+	lines = append(lines, `{"Race": `)
+	lines = append(lines, bulkLines(t, newTestActor(nil))...)
+	results := runBulkSet(t, svc, "narnia", "actor", lines)
+
+	// each line is reported on its own & a bad line doesn't stop the rest
+	assert.Len(t, results, 5)
+	for _, line := range []int64{1, 5} {
+		assert.Nil(t, results[line].Error)
+		assert.NotEmpty(t, results[line].Etag)
+	}
+	for line, code := range map[int64]int{2: http.StatusConflict, 3: http.StatusPreconditionFailed, 4: http.StatusBadRequest} {
+		if assert.NotNil(t, results[line].Error, "line %d", line) {
+			assert.Equal(t, code, results[line].Error.Code, "line %d", line)
+		}
+		assert.Empty(t, results[line].Etag)
+	}
+
+	assert.Len(t, getActors(t, svc, "narnia"), 3)
+	assert.Equal(t, existing.Etag, getActors(t, svc, "narnia", existing.Id)[0].Etag)
+}
+
+func TestBulkSetChunkFailure(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorldWith(t, svc, &v1.World{Meta: v1.Meta{Id: "narnia"}, Quota: &v1.Quota{Objects: map[string]int64{"actor": 1}}})
+	ctx := context.Background()
+
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil)}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	existing := getActors(t, svc, "narnia")[0]
+	existing.Firstname = "Aslan"
+
+	// the first chunk is over quota, the update in the next is not
+	objects := []interface{}{}
+	for i := 0; i < bulkChunkSize; i++ {
+		objects = append(objects, newTestActor(map[string]string{"n": fmt.Sprint(i)}))
+	}
+	objects = append(objects, existing)
+	results := runBulkSet(t, svc, "narnia", "actor", bulkLines(t, objects...))
+
+	assert.Len(t, results, bulkChunkSize+1)
+	for line := int64(1); line <= bulkChunkSize; line++ {
+		if assert.NotNil(t, results[line].Error) {
+			assert.Equal(t, http.StatusInsufficientStorage, results[line].Error.Code)
+		}
+	}
+	assert.Nil(t, results[bulkChunkSize+1].Error)
+	assert.Equal(t, "Aslan", getActors(t, svc, "narnia", existing.Id)[0].Firstname)
+}
+
+func TestBulkSetOutlastsTimeouts(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	svc.cfg.TimeoutRead = 200 * time.Millisecond
+	svc.cfg.TimeoutWrite = 200 * time.Millisecond
+
+	srv := &Server{cfg: svc.cfg, log: log.Sublogger("test"), svc: svc}
+	hs := httptest.NewUnstartedServer(http.HandlerFunc(srv.bulkSet))
+	hs.Config.ReadTimeout = svc.cfg.TimeoutRead
+	hs.Config.WriteTimeout = svc.cfg.TimeoutWrite
+	hs.Start()
+	defer hs.Close()
+
+	// the body takes longer to send than any timeout, but each read is well within them
+	lines := bulkLines(t, newTestActor(nil), newTestActor(nil), newTestActor(nil), newTestActor(nil), newTestActor(nil), newTestActor(nil))
+	pr, pw := io.Pipe()
+	go func() {
+		for _, line := range lines {
+			time.Sleep(100 * time.Millisecond)
+			pw.Write([]byte(line + "\n"))
+		}
+		pw.Close()
+	}()
+
+	resp, err := http.Post(hs.URL+"?world=narnia&kind=actor", "application/x-ndjson", pr)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	dec := json.NewDecoder(resp.Body)
+	written := 0
+	for dec.More() {
+		res := &api.BulkResult{}
+		if !assert.Nil(t, dec.Decode(res)) {
+			return
+		}
+		assert.Nil(t, res.Error)
+		written++
+	}
+	assert.Equal(t, len(lines), written)
+	assert.Len(t, getActors(t, svc, "narnia"), len(lines))
+}
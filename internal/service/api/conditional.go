@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/voidshard/faction/pkg/structs/api"
+)
+
+// anyEtag is the If-Match / If-None-Match value matching any (existing) object
+const anyEtag = "*"
+
+// quoteEtag returns an Etag as an HTTP entity tag
+func quoteEtag(etag string) string {
+	return fmt.Sprintf("%q", etag)
+}
+
+// noneMatch returns if an If-None-Match header matches the given Etag, in which case a GET
+// should reply 304 Not Modified. As the RFC asks, weak tags match as well as strong ones.
+func noneMatch(header, etag string) bool {
+	if header == "" || etag == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == anyEtag || strings.Trim(strings.TrimPrefix(tag, "W/"), `"`) == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatch reads an If-Match header, returning the Etag a write is conditional on (or anyEtag)
+// if the header is set. We only support a single strong entity tag, since each of our writes
+// is conditional on one Etag per object.
+func ifMatch(header string) (string, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == anyEtag {
+		return header, nil
+	}
+	if strings.Contains(header, ",") || strings.HasPrefix(header, "W/") {
+		return "", fmt.Errorf("%w If-Match must be a single strong entity tag", ErrInvalid)
+	}
+	return strings.Trim(header, `"`), nil
+}
+
+// currentEtag returns the Etag of an object, or an empty string if it doesn't exist
+func (s *Service) currentEtag(ctx context.Context, world, k, id string) (string, error) {
+	rsp := &api.GetResponse{}
+	err := s.getKind(ctx, k, &api.GetRequest{Ids: []string{id}, World: world}, rsp)
+	if err != nil || len(rsp.Data) == 0 {
+		return "", err
+	}
+	return objectEtag(rsp.Data[0]), nil
+}
+
+// conditionalSet makes the write of a single (raw) object conditional on an If-Match etag.
+//
+// With a specific etag the object is written only if it is at that Etag, with anyEtag it is
+// written only if it exists (at whatever Etag it is now).
+func (s *Service) conditionalSet(ctx context.Context, k string, req *api.SetRequest, match string) error {
+	if len(req.Data) != 1 {
+		return fmt.Errorf("%w If-Match requires a single object", ErrInvalid)
+	}
+	obj, ok := req.Data[0].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%w object is not a json object", ErrInvalid)
+	}
+	given, _ := obj["_etag"].(string)
+
+	if match == anyEtag {
+		id, _ := obj["_id"].(string)
+		if id == "" {
+			return fmt.Errorf("%w object does not exist", ErrPrecondition)
+		}
+		current, err := s.currentEtag(ctx, req.World, k, id)
+		if err != nil {
+			return err
+		} else if current == "" {
+			return fmt.Errorf("%w object does not exist", ErrPrecondition)
+		}
+		match = current
+		if given != "" {
+			match = given // nb. the object's own etag is still honoured
+		}
+	} else if given != "" && given != match {
+		return fmt.Errorf("%w object etag does not match If-Match", ErrPrecondition)
+	}
+
+	obj["_etag"] = match
+	return nil
+}
+
+// conditionalDelete makes a delete conditional on an If-Match etag (see DeleteRequest.Etag)
+func (s *Service) conditionalDelete(ctx context.Context, k string, req *api.DeleteRequest, match string) error {
+	if len(req.Ids) != 1 {
+		return fmt.Errorf("%w If-Match requires a single object", ErrInvalid)
+	}
+	if match == anyEtag {
+		current, err := s.currentEtag(ctx, req.World, k, req.Ids[0])
+		if err != nil {
+			return err
+		} else if current == "" {
+			return fmt.Errorf("%w object does not exist", ErrPrecondition)
+		}
+		return nil // deleting a missing object does nothing, so there's no need to pin the etag
+	}
+	if req.Etag != "" && req.Etag != match {
+		return fmt.Errorf("%w request etag does not match If-Match", ErrPrecondition)
+	}
+	req.Etag = match
+	return nil
+}
+
+// objectEtag returns the Etag of a raw object
+func objectEtag(in interface{}) string {
+	obj, ok := in.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	etag, _ := obj["_etag"].(string)
+	return etag
+}
+
+// writeNotModified replies 304 to a GET whose If-None-Match matched, which has no body
+func writeNotModified(w http.ResponseWriter, etag string) {
+	w.Header().Set("ETag", quoteEtag(etag))
+	w.WriteHeader(http.StatusNotModified)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/voidshard/faction/pkg/structs/api"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+func TestNoneMatch(t *testing.T) {
+	cases := map[string]struct {
+		header string
+		etag   string
+		expect bool
+	}{
+		"unset":        {"", "abc", false},
+		"no etag":      {`"abc"`, "", false},
+		"strong":       {`"abc"`, "abc", true},
+		"weak":         {`W/"abc"`, "abc", true},
+		"any":          {"*", "abc", true},
+		"list":         {`"xyz", W/"abc"`, "abc", true},
+		"list no hit":  {`"xyz", W/"uvw"`, "abc", false},
+		"other etag":   {`"xyz"`, "abc", false},
+		"unquoted tag": {"abc", "abc", true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, noneMatch(tc.header, tc.etag))
+		})
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	cases := map[string]struct {
+		header string
+		expect string
+		err    error
+	}{
+		"unset":  {"", "", nil},
+		"any":    {" * ", anyEtag, nil},
+		"strong": {`"abc"`, "abc", nil},
+		"weak":   {`W/"abc"`, "", ErrInvalid},
+		"list":   {`"abc", "xyz"`, "", ErrInvalid},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			match, err := ifMatch(tc.header)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expect, match)
+		})
+	}
+}
+
+func TestConditionalSet(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil)}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	actor := getActors(t, svc, "narnia")[0]
+	other := uuid.New()
+
+	set := func(obj map[string]interface{}) *api.SetRequest {
+		return &api.SetRequest{World: "narnia", Data: []interface{}{obj}}
+	}
+
+	// a specific etag is pinned on the object
+	req := set(map[string]interface{}{"_id": actor.Id})
+	assert.Nil(t, svc.conditionalSet(ctx, "actor", req, other))
+	assert.Equal(t, other, objectEtag(req.Data[0]))
+
+	// which may repeat the object's own etag, but not conflict with it
+	req = set(map[string]interface{}{"_id": actor.Id, "_etag": other})
+	assert.Nil(t, svc.conditionalSet(ctx, "actor", req, other))
+	req = set(map[string]interface{}{"_id": actor.Id, "_etag": actor.Etag})
+	assert.ErrorIs(t, svc.conditionalSet(ctx, "actor", req, other), ErrPrecondition)
+
+	// any etag pins the current one
+	req = set(map[string]interface{}{"_id": actor.Id})
+	assert.Nil(t, svc.conditionalSet(ctx, "actor", req, anyEtag))
+	assert.Equal(t, actor.Etag, objectEtag(req.Data[0]))
+
+	// .. unless the object gives its own
+	req = set(map[string]interface{}{"_id": actor.Id, "_etag": other})
+	assert.Nil(t, svc.conditionalSet(ctx, "actor", req, anyEtag))
+	assert.Equal(t, other, objectEtag(req.Data[0]))
+
+	// any etag requires the object exists
+	req = set(map[string]interface{}{"_id": uuid.New()})
+	assert.ErrorIs(t, svc.conditionalSet(ctx, "actor", req, anyEtag), ErrPrecondition)
+	req = set(map[string]interface{}{})
+	assert.ErrorIs(t, svc.conditionalSet(ctx, "actor", req, anyEtag), ErrPrecondition)
+
+	// only a single json object may be written
+	req = &api.SetRequest{World: "narnia", Data: []interface{}{map[string]interface{}{}, map[string]interface{}{}}}
+	assert.ErrorIs(t, svc.conditionalSet(ctx, "actor", req, other), ErrInvalid)
+	req = &api.SetRequest{World: "narnia", Data: []interface{}{"actor"}}
+	assert.ErrorIs(t, svc.conditionalSet(ctx, "actor", req, other), ErrInvalid)
+}
+
+func TestConditionalDelete(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil)}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	actor := getActors(t, svc, "narnia")[0]
+	other := uuid.New()
+
+	// a specific etag is set on the request
+	req := &api.DeleteRequest{World: "narnia", Ids: []string{actor.Id}}
+	assert.Nil(t, svc.conditionalDelete(ctx, "actor", req, other))
+	assert.Equal(t, other, req.Etag)
+
+	// which may repeat the request's own etag, but not conflict with it
+	req = &api.DeleteRequest{World: "narnia", Ids: []string{actor.Id}, Etag: other}
+	assert.Nil(t, svc.conditionalDelete(ctx, "actor", req, other))
+	req = &api.DeleteRequest{World: "narnia", Ids: []string{actor.Id}, Etag: actor.Etag}
+	assert.ErrorIs(t, svc.conditionalDelete(ctx, "actor", req, other), ErrPrecondition)
+
+	// any etag requires the object exists
+	req = &api.DeleteRequest{World: "narnia", Ids: []string{actor.Id}}
+	assert.Nil(t, svc.conditionalDelete(ctx, "actor", req, anyEtag))
+	req = &api.DeleteRequest{World: "narnia", Ids: []string{uuid.New()}}
+	assert.ErrorIs(t, svc.conditionalDelete(ctx, "actor", req, anyEtag), ErrPrecondition)
+
+	// only a single object may be deleted
+	req = &api.DeleteRequest{World: "narnia", Ids: []string{actor.Id, uuid.New()}}
+	assert.ErrorIs(t, svc.conditionalDelete(ctx, "actor", req, other), ErrInvalid)
+}
@@ -1,12 +1,26 @@
 package api
 
-import "time"
+import (
+	"time"
+
+	"github.com/voidshard/faction/internal/auth"
+	"github.com/voidshard/faction/internal/ratelimit"
+)
 
 const (
 	defaultPublishRoutines = 5
 	defaultMaxAge          = 60 * time.Minute // implies something is horribly wrong
 	defaultLimit           = 100
 	defaultMaxLimit        = 1000
+	defaultHistory         = 10
+
+	// relays poll the outbox at least this often, they're also woken after writes
+	defaultRelayInterval = time.Second
+	relayLease           = 30 * time.Second
+	relayBatchSize       = 100
+
+	// event streams hold a lease counting them against watcher quotas, which they renew
+	watcherLease = time.Minute
 )
 
 type Config struct {
@@ -20,7 +34,18 @@ type Config struct {
 	TimeoutRead  time.Duration
 	TimeoutWrite time.Duration
 
+	// PublishRoutines is the number of relays publishing events from the outbox.
 	PublishRoutines int
+
+	// History is the number of revisions kept of each object, unless set by the world.
+	History int
+
+	// Auth authenticates & authorizes callers, if nil anyone may do anything.
+	Auth *auth.Auth
+
+	// RateLimits limit the rate of requests each client makes by route name (see ratelimit.Default),
+	// if empty requests are not limited.
+	RateLimits map[string]*ratelimit.Limit
 }
 
 func (c *Config) setDefaults() {
@@ -36,4 +61,7 @@ func (c *Config) setDefaults() {
 	if c.PublishRoutines <= 0 {
 		c.PublishRoutines = defaultPublishRoutines
 	}
+	if c.History <= 0 {
+		c.History = defaultHistory
+	}
 }
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/voidshard/faction/internal/auth"
 	"github.com/voidshard/faction/internal/db"
+
+	"google.golang.org/grpc/codes"
 )
 
 var (
@@ -13,6 +16,9 @@ var (
 	ErrInvalid      = fmt.Errorf("object invalid")
 	ErrPrecondition = fmt.Errorf("precondition failed")
 	ErrNotFound     = fmt.Errorf("object not found")
+
+	// ErrQuotaExceeded is returned when a world is at its limit (see v1.Quota)
+	ErrQuotaExceeded = fmt.Errorf("quota exceeded")
 )
 
 // errorCodeHTTP returns the HTTP status code for a given error.
@@ -27,6 +33,15 @@ func errorCodeHTTP(err error) int {
 		return http.StatusNotFound
 	} else if errors.Is(err, ErrPrecondition) {
 		return http.StatusPreconditionFailed
+	} else if errors.Is(err, ErrQuotaExceeded) {
+		// nb. as WebDAV does for quotas (RFC 4918), so clients can tell this from a bad request
+		return http.StatusInsufficientStorage
+	}
+	// Auth errors
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		return http.StatusUnauthorized
+	} else if errors.Is(err, auth.ErrForbidden) {
+		return http.StatusForbidden
 	}
 	// DB errors
 	if errors.Is(err, db.ErrNotFound) {
@@ -37,6 +52,34 @@ func errorCodeHTTP(err error) int {
 		return http.StatusBadRequest
 	} else if errors.Is(err, db.ErrEtagMismatch) {
 		return http.StatusPreconditionFailed
+	} else if errors.Is(err, db.ErrExpired) {
+		// nb. as with a resync, the client must list again to learn a current sequence
+		return http.StatusGone
 	}
 	return http.StatusInternalServerError
 }
+
+// errorCodeGRPC returns the gRPC status code for a given error (see errorCodeHTTP).
+func errorCodeGRPC(err error) codes.Code {
+	switch errorCodeHTTP(err) {
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusInsufficientStorage:
+		return codes.ResourceExhausted
+	case http.StatusGone:
+		return codes.OutOfRange
+	}
+	return codes.Internal
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/voidshard/faction/pkg/structs/api"
+)
+
+func TestFinalizers(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	actor := newTestActor(nil)
+	actor.Finalizers = []string{"cleanup"}
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{actor}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	id := getActors(t, svc, "narnia")[0].Id
+
+	// deleting an object with finalizers marks it
+	err = svc.deleteKind(ctx, "actor", &api.DeleteRequest{World: "narnia", Ids: []string{id}}, &api.DeleteResponse{})
+	assert.Nil(t, err)
+	marked := getActors(t, svc, "narnia", id)
+	if !assert.Len(t, marked, 1) || !assert.NotNil(t, marked[0].DeletionTick) {
+		return
+	}
+	tick := *marked[0].DeletionTick
+
+	// writes after the mark keep it
+	update := *marked[0]
+	update.DeletionTick = nil
+	update.Firstname = "Aslan"
+	err = svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{&update}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	updated := getActors(t, svc, "narnia", id)[0]
+	assert.Equal(t, "Aslan", updated.Firstname)
+	if assert.NotNil(t, updated.DeletionTick) {
+		assert.Equal(t, tick, *updated.DeletionTick)
+	}
+
+	// but cannot change it
+	changed := *updated
+	other := tick + 1
+	changed.DeletionTick = &other
+	err = svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{&changed}}, &api.SetResponse{})
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	err = svc.patchKind(ctx, "actor", &api.PatchRequest{World: "narnia", Data: []*api.Patch{
+		{Id: id, Etag: updated.Etag, Patch: json.RawMessage(`{"DeletionTick": null}`)},
+	}}, &api.PatchResponse{})
+	assert.Nil(t, err)
+	updated = getActors(t, svc, "narnia", id)[0]
+	assert.NotNil(t, updated.DeletionTick)
+
+	// removing the last finalizer deletes the object
+	err = svc.patchKind(ctx, "actor", &api.PatchRequest{World: "narnia", Data: []*api.Patch{
+		{Id: id, Etag: updated.Etag, Patch: json.RawMessage(`{"Finalizers": null}`)},
+	}}, &api.PatchResponse{})
+	assert.Nil(t, err)
+	assert.Len(t, getActors(t, svc, "narnia", id), 0)
+}
+
+func TestDeletionTickNotSet(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	// objects are only marked by deleting them
+	var tick uint64 = 1
+	actor := newTestActor(nil)
+	actor.DeletionTick = &tick
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{actor}}, &api.SetResponse{})
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	err = svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil)}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	existing := getActors(t, svc, "narnia")[0]
+	existing.DeletionTick = &tick
+	err = svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{existing}}, &api.SetResponse{})
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	results := runBulkSet(t, svc, "narnia", "actor", bulkLines(t, existing))
+	if assert.NotNil(t, results[1].Error) {
+		assert.Equal(t, http.StatusBadRequest, results[1].Error.Code)
+	}
+	assert.Nil(t, getActors(t, svc, "narnia")[0].DeletionTick)
+}
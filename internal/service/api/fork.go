@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+)
+
+// forkWorld copies a world into a new world id at the same Tick; every object, the tuples &
+// modifiers of every relation and any events deferred to future ticks. The worlds are then
+// simulated independently.
+//
+// Objects keep their ids (as with importWorld) so that the two worlds can later be compared.
+// Writes to the source world made while a fork is in progress may or may not be copied, so
+// worlds should be forked while they are quiet.
+func (s *Service) forkWorld(ctx context.Context, from string, req *api.ForkRequest, rsp *api.ForkResponse) error {
+	pan := log.NewSpan(ctx, "service.forkWorld", map[string]interface{}{"from": from, "to": req.To})
+	defer pan.End()
+
+	if from == req.To {
+		return pan.Err(fmt.Errorf("%w cannot fork a world into itself", ErrInvalid))
+	}
+	rsp.World = req.To
+	rsp.Counts = map[string]int64{}
+
+	worlds := []*v1.World{}
+	err := s.db.Get(ctx, "", kindWorld, []string{from}, &worlds)
+	if err != nil {
+		return pan.Err(err)
+	} else if len(worlds) == 0 {
+		return pan.Err(fmt.Errorf("%w world %s not found", ErrNotFound, from))
+	}
+	world := worlds[0]
+	tick := world.Tick
+	rsp.Tick = tick
+
+	// create the new world first, so that we fail early if it already exists
+	world.SetId(req.To)
+	world.SetEtag("")
+	_, err = s.writeObjects(ctx, "", kindWorld, []v1.Object{world})
+	if err != nil {
+		return pan.Err(err)
+	}
+	err = s.tickManager.seed(world)
+	if err != nil {
+		return pan.Err(err)
+	}
+	rsp.Counts[kindWorld] = 1
+
+	for _, k := range archiveKinds() {
+		count, err := s.forkObjects(ctx, from, req.To, k)
+		rsp.Counts[k] = count
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+
+	err = s.db.CopyRelations(ctx, from, req.To)
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	events, err := s.db.DeferredEvents(ctx, from, tick)
+	if err != nil {
+		return pan.Err(err)
+	}
+	for _, deferred := range events {
+		evt := deferred.Event
+		evt.World = req.To
+		evt.AckId = ""
+
+		err = s.tickManager.recordDeferred(ctx, req.To, deferred.Tick)
+		if err != nil {
+			return pan.Err(err)
+		}
+		err = s.db.AddDeferredEvent(ctx, req.To, deferred.Tick, evt)
+		if err != nil {
+			return pan.Err(err)
+		}
+		err = s.qu.DeferEvent(ctx, evt, deferred.Tick)
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+
+	pan.SetAttributes(map[string]interface{}{"tick": tick, "counts": rsp.Counts, "deferred": len(events)})
+	return nil
+}
+
+// forkObjects copies all objects of a kind from one world to another, a page at a time
+func (s *Service) forkObjects(ctx context.Context, from, to, k string) (int64, error) {
+	var count int64
+
+	after := ""
+	for {
+		objects := []map[string]interface{}{}
+		err := s.db.ListAfter(ctx, from, k, nil, after, archivePageSize, &objects)
+		if err != nil {
+			return count, err
+		}
+		if len(objects) == 0 {
+			return count, nil
+		}
+		after, _ = objects[len(objects)-1]["_id"].(string)
+
+		batch := []v1.Object{}
+		for _, data := range objects {
+			obj, err := kind.New(k, data)
+			if err != nil {
+				return count, err
+			}
+			obj.SetWorld(to)
+			obj.SetEtag("")
+			batch = append(batch, obj)
+		}
+		_, err = s.writeObjects(ctx, to, k, batch)
+		if err != nil {
+			return count, err
+		}
+		count += int64(len(batch))
+
+		if len(objects) < int(archivePageSize) {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+)
+
+const (
+	// gcPageSize is how many dependents of an object we read at once
+	gcPageSize int64 = 1000
+)
+
+// collectGarbage deletes the dependents of deleted objects (see v1.Meta.Owners) as their delete
+// events arrive, until we shutdown.
+//
+// The subscription is shared by all API servers, so each event is handled by one of us. Deleting
+// a dependent raises an event of its own, so dependents of dependents are collected in turn.
+func (s *Service) collectGarbage() {
+	l := log.Sublogger("api.collectGarbage")
+	defer l.Debug().Msg("garbage collector stopped")
+
+	for msg := range s.garbage.Channel() {
+		evt := &v1.Event{}
+		err := json.Unmarshal(msg.Data(), evt)
+		if err != nil {
+			l.Warn().Err(err).Str("MessageId", msg.Id()).Msg("failed to decode event")
+			msg.Ack() // no point retrying
+			continue
+		}
+		if !evt.Deleted || evt.World == "" {
+			// nb. global objects hold no dependents (deleting a world removes everything in it)
+			msg.Ack()
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(msg.Context(), s.cfg.TimeoutWrite)
+		err = s.deleteDependents(ctx, evt.World, &v1.Owner{Kind: evt.Kind, Id: evt.Id}, false, map[string]bool{})
+		cancel()
+		if err != nil {
+			l.Warn().Err(err).Str("world", evt.World).Str("kind", evt.Kind).Str("id", evt.Id).Msg("failed to delete dependents")
+			msg.Reject() // requeue
+			continue
+		}
+		msg.Ack()
+	}
+}
+
+// deleteDependents deletes the objects owned by the given owner that have no other owner left.
+//
+// In the foreground each dependent's own dependents are deleted (depth first) before it, otherwise
+// they're left to collectGarbage. Owners in `seen` are being deleted & are regarded as gone.
+func (s *Service) deleteDependents(ctx context.Context, world string, owner *v1.Owner, foreground bool, seen map[string]bool) error {
+	pan := log.NewSpan(ctx, "service.deleteDependents", map[string]interface{}{"world": world, "kind": owner.Kind, "id": owner.Id, "foreground": foreground})
+	defer pan.End()
+
+	key := ownerKey(owner)
+	if seen[key] {
+		return nil // ie. owners form a cycle
+	}
+	seen[key] = true
+
+	for _, k := range archiveKinds() {
+		after := ""
+		for {
+			found := []*v1.Meta{}
+			err := s.db.Owned(ctx, world, k, owner, after, gcPageSize, &found)
+			if err != nil {
+				return pan.Err(err)
+			}
+			if len(found) == 0 {
+				break
+			}
+			after = found[len(found)-1].Id
+
+			ids := []string{}
+			for _, dep := range found {
+				orphaned, err := s.orphaned(ctx, world, dep, seen)
+				if err != nil {
+					return pan.Err(err)
+				} else if !orphaned {
+					continue
+				}
+				if foreground {
+					err = s.deleteDependents(ctx, world, &v1.Owner{Kind: k, Id: dep.Id}, true, seen)
+					if err != nil {
+						return pan.Err(err)
+					}
+				}
+				ids = append(ids, dep.Id)
+			}
+
+			err = s.deleteObjects(ctx, world, k, ids, "")
+			if err != nil {
+				return pan.Err(err)
+			}
+			if len(found) < int(gcPageSize) {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// orphaned returns if none of an object's owners remain, ignoring those in `seen` which are
+// being deleted.
+func (s *Service) orphaned(ctx context.Context, world string, obj *v1.Meta, seen map[string]bool) (bool, error) {
+	for _, o := range obj.Owners {
+		if seen[ownerKey(o)] {
+			continue
+		}
+		found := []*v1.Meta{}
+		err := s.db.Get(ctx, world, o.Kind, []string{o.Id}, &found)
+		if err != nil {
+			return false, err
+		}
+		if len(found) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func ownerKey(o *v1.Owner) string {
+	return fmt.Sprintf("%s/%s", o.Kind, o.Id)
+}
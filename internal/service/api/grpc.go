@@ -0,0 +1,351 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/voidshard/faction/internal/auth"
+	"github.com/voidshard/faction/pkg/kind"
+	pb "github.com/voidshard/faction/pkg/proto/v1"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer serves the Service as a gRPC API (see pkg/proto/v1), it mirrors the handlers
+// of the HTTP Server.
+type grpcServer struct {
+	pb.UnimplementedAPIServer
+
+	srv *Server
+}
+
+// grpcError records the error on the span & returns it as a gRPC status.
+func grpcError(pan *log.Span, code codes.Code, err error) error {
+	pan.Err(err)
+	return status.Error(code, err.Error())
+}
+
+func (g *grpcServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.srv.cfg.TimeoutRead)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "grpc.Get", map[string]interface{}{"kind": req.GetKind(), "world": req.GetWorld()})
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	if !kind.IsValid(req.GetKind()) {
+		return nil, grpcError(pan, codes.NotFound, fmt.Errorf("kind %s not found", req.GetKind()))
+	}
+
+	body := &api.GetRequest{
+		Ids:      req.GetIds(),
+		Limit:    req.GetLimit(),
+		Offset:   req.GetOffset(),
+		Labels:   req.GetLabels(),
+		World:    req.GetWorld(),
+		Selector: req.GetSelector(),
+		Token:    req.GetToken(),
+	}
+	if len(body.Ids) > 0 && (len(body.Labels) > 0 || body.Selector != "") {
+		return nil, grpcError(pan, codes.InvalidArgument, fmt.Errorf("cannot specify both IDs and labels in get"))
+	}
+	if body.Limit <= 0 {
+		body.Limit = 100 // set default if user doesn't specify
+	}
+
+	err := kind.Validate(req.GetKind(), body)
+	if err != nil {
+		return nil, grpcError(pan, codes.InvalidArgument, err)
+	}
+
+	err = g.srv.svc.authorizeIds(ctx, auth.VerbGet, body.World, req.GetKind(), body.Ids)
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+
+	resp := &api.GetResponse{}
+	err = g.srv.svc.getKind(ctx, req.GetKind(), body, resp)
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+
+	data, err := toProtoObjects(resp.Data)
+	if err != nil {
+		return nil, grpcError(pan, codes.Internal, err)
+	}
+	return &pb.GetResponse{Data: data, Token: resp.Token, Sequence: resp.Sequence}, nil
+}
+
+func (g *grpcServer) Set(ctx context.Context, req *pb.SetRequest) (*pb.SetResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.srv.cfg.TimeoutWrite)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "grpc.Set", map[string]interface{}{"world": req.GetWorld()})
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	// all objects must be of one kind, as with the HTTP API
+	k := ""
+	body := &api.SetRequest{World: req.GetWorld(), Data: []interface{}{}}
+	for _, obj := range req.GetData() {
+		if k == "" {
+			k = obj.Kind()
+		} else if obj.Kind() != k {
+			return nil, grpcError(pan, codes.InvalidArgument, fmt.Errorf("objects must all be of one kind, got %s and %s", k, obj.Kind()))
+		}
+		body.Data = append(body.Data, obj.Data())
+	}
+	if !kind.IsValid(k) {
+		return nil, grpcError(pan, codes.InvalidArgument, fmt.Errorf("kind %s not found", k))
+	}
+	pan.SetAttributes(map[string]interface{}{"kind": k})
+
+	err := kind.Validate(k, body)
+	if err != nil {
+		return nil, grpcError(pan, codes.InvalidArgument, err)
+	}
+
+	err = g.srv.svc.authorizeIds(ctx, auth.VerbSet, body.World, k, objectIds(body.Data))
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+
+	resp := &api.SetResponse{}
+	err = g.srv.svc.setKind(ctx, k, body, resp)
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+	return &pb.SetResponse{Etags: resp.Etags, Conflicts: resp.Conflicts}, nil
+}
+
+func (g *grpcServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.srv.cfg.TimeoutWrite)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "grpc.Delete", map[string]interface{}{"kind": req.GetKind(), "world": req.GetWorld(), "ids": len(req.GetIds())})
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	if !kind.IsValid(req.GetKind()) {
+		return nil, grpcError(pan, codes.NotFound, fmt.Errorf("kind %s not found", req.GetKind()))
+	}
+
+	body := &api.DeleteRequest{
+		Ids:         req.GetIds(),
+		World:       req.GetWorld(),
+		Propagation: req.GetPropagation(),
+	}
+	err := kind.Validate(req.GetKind(), body)
+	if err != nil {
+		return nil, grpcError(pan, codes.InvalidArgument, err)
+	}
+
+	err = g.srv.svc.authorizeIds(ctx, auth.VerbDelete, body.World, req.GetKind(), body.Ids)
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+
+	resp := &api.DeleteResponse{}
+	err = g.srv.svc.deleteKind(ctx, req.GetKind(), body, resp)
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+	return &pb.DeleteResponse{Pending: resp.Pending}, nil
+}
+
+func (g *grpcServer) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.srv.cfg.TimeoutRead)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "grpc.Search", map[string]interface{}{"world": req.GetWorld()})
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	if req.GetWorld() == "" {
+		return nil, grpcError(pan, codes.InvalidArgument, fmt.Errorf("world id invalid"))
+	}
+	if req.GetQuery() == nil {
+		return nil, grpcError(pan, codes.InvalidArgument, fmt.Errorf("query is required"))
+	}
+
+	q, err := req.GetQuery().ToQuery()
+	if err != nil {
+		return nil, grpcError(pan, codes.InvalidArgument, err)
+	}
+	body := &api.SearchRequest{Query: *q}
+	if !kind.IsValid(body.Kind) {
+		return nil, grpcError(pan, codes.NotFound, fmt.Errorf("kind %s not found", body.Kind))
+	}
+	pan.SetAttributes(map[string]interface{}{"kind": body.Kind, "limit": body.Limit})
+
+	err = kind.Validate(body.Kind, body)
+	if err != nil {
+		return nil, grpcError(pan, codes.InvalidArgument, err)
+	}
+
+	err = g.srv.svc.authorize(ctx, auth.VerbSearch, req.GetWorld(), body.Kind)
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+
+	resp := &api.SearchResponse{}
+	err = g.srv.svc.searchKind(ctx, req.GetWorld(), body, resp)
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+
+	data, err := toProtoObjects(resp.Data)
+	if err != nil {
+		return nil, grpcError(pan, codes.Internal, err)
+	}
+	return &pb.SearchResponse{Data: data}, nil
+}
+
+func (g *grpcServer) Defer(ctx context.Context, req *pb.DeferRequest) (*pb.DeferResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.srv.cfg.TimeoutRead)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "grpc.Defer", map[string]interface{}{"kind": req.GetKind(), "world": req.GetWorld(), "controller": req.GetController(), "id": req.GetId(), "to-tick": req.GetToTick(), "by-tick": req.GetByTick()})
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	if !kind.IsValid(req.GetKind()) {
+		return nil, grpcError(pan, codes.InvalidArgument, fmt.Errorf("kind %s not found", req.GetKind()))
+	}
+
+	body := &api.DeferEventRequest{
+		World:      req.GetWorld(),
+		Kind:       req.GetKind(),
+		Controller: req.GetController(),
+		Id:         req.GetId(),
+		ToTick:     req.GetToTick(),
+		ByTick:     req.GetByTick(),
+	}
+	err := kind.Validate(body.Kind, body)
+	if err != nil {
+		return nil, grpcError(pan, codes.InvalidArgument, err)
+	}
+
+	err = g.srv.svc.authorize(ctx, auth.VerbDefer, body.World, body.Kind)
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+
+	resp := &api.DeferEventResponse{}
+	err = g.srv.svc.deferEvent(ctx, body, resp)
+	if err != nil {
+		return nil, grpcError(pan, errorCodeGRPC(err), err)
+	}
+	return &pb.DeferResponse{ToTick: resp.ToTick}, nil
+}
+
+func (g *grpcServer) OnChange(req *pb.OnChangeRequest, stream grpc.ServerStreamingServer[pb.OnChangeResponse]) error {
+	pan := log.NewSpan(stream.Context(), "grpc.OnChange")
+	defer pan.End()
+
+	body := &api.StreamEvents{
+		World:      req.GetData().GetWorld(),
+		Kind:       req.GetData().GetKind(),
+		Controller: req.GetData().GetController(),
+		Id:         req.GetData().GetId(),
+		Selector:   req.GetSelector(),
+		Queue:      req.GetQueue(),
+
+		FromSequence: req.GetFromSequence(),
+	}
+	pan.SetAttributes(map[string]interface{}{"world": body.World, "kind": body.Kind, "controller": body.Controller, "id": body.Id, "queue": body.Queue, "from_sequence": body.FromSequence})
+
+	if body.Kind != "" && !kind.IsValid(body.Kind) {
+		return grpcError(pan, codes.InvalidArgument, fmt.Errorf("kind %s not found", body.Kind))
+	}
+	err := kind.Validate(body.Kind, body)
+	if err != nil {
+		return grpcError(pan, codes.InvalidArgument, err)
+	}
+
+	err = g.srv.svc.authorize(stream.Context(), auth.VerbWatch, body.World, body.Kind)
+	if err != nil {
+		return grpcError(pan, errorCodeGRPC(err), err)
+	}
+
+	events, kill, err := g.srv.svc.subscribeToEvents(stream.Context(), body)
+	if err != nil {
+		return grpcError(pan, errorCodeGRPC(err), err)
+	}
+	defer func() { go stopEvents(events, kill) }()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case evt, ok := <-events:
+			if !ok {
+				return grpcError(pan, codes.Unavailable, fmt.Errorf("event subscription closed"))
+			}
+			err = stream.Send(&pb.OnChangeResponse{Data: pb.NewChange(evt), Ack: evt.AckId})
+			if err != nil {
+				return pan.Err(err)
+			}
+		}
+	}
+}
+
+func (g *grpcServer) AckStream(stream grpc.ClientStreamingServer[pb.AckRequest, pb.AckResponse]) error {
+	pan := log.NewSpan(stream.Context(), "grpc.AckStream")
+	defer pan.End()
+
+	acked := int64(0)
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			pan.SetAttributes(map[string]interface{}{"acked": acked})
+			return stream.SendAndClose(&pb.AckResponse{Acked: acked})
+		} else if err != nil {
+			return pan.Err(err)
+		}
+		for _, ackId := range req.GetAck() {
+			// nb. callers not allowed to ack are refused as they would be by any other call
+			err = g.srv.svc.ackEvent(stream.Context(), ackId)
+			if errors.Is(err, auth.ErrUnauthenticated) || errors.Is(err, auth.ErrForbidden) {
+				return grpcError(pan, errorCodeGRPC(err), err)
+			} else if err != nil {
+				// as with the websocket, a bad ack shouldn't end the stream
+				log.Warn().Err(err).Str("AckId", ackId).Msg("Failed to ack event")
+				continue
+			}
+			acked++
+		}
+	}
+}
+
+// stopEvents ends an event subscription, events may be mid send so they're drained
+// until the subscription stops.
+func stopEvents(events <-chan *v1.Event, kill chan<- bool) {
+	for {
+		select {
+		case kill <- true:
+			return
+		case <-events:
+		}
+	}
+}
+
+// toProtoObjects converts objects (as returned by the Service) to their proto messages.
+func toProtoObjects(in []interface{}) ([]*pb.Object, error) {
+	out := make([]*pb.Object, len(in))
+	for i, obj := range in {
+		o, err := pb.NewObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = o
+	}
+	return out, nil
+}
@@ -0,0 +1,314 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/voidshard/faction/internal/db"
+	"github.com/voidshard/faction/internal/openapi"
+	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	routeOpenAPI = "openapi"
+
+	contentJSON   = "application/json"
+	contentNDJSON = "application/x-ndjson"
+	contentTar    = "application/x-tar"
+
+	patternUUID4 = "^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$"
+)
+
+var openapiPath = fmt.Sprintf("/%s/openapi.json", apiVersion)
+
+// apiOperation describes what a route takes & returns, for the OpenAPI document.
+type apiOperation struct {
+	summary string
+
+	// query parameters (all optional strings)
+	query []string
+
+	// headers are the optional (conditional request) headers the route honours
+	headers []string
+
+	// request & response are prototypes of the JSON bodies (if any), where these have Data
+	// holding objects the document describes the objects of the kind in the path. Bodies
+	// that aren't JSON are described in openapiDocument.
+	request  interface{}
+	response interface{}
+
+	// websocket routes upgrade the connection, the response describes each message
+	websocket bool
+}
+
+// apiOperations describe our routes by name (see NewServer), routes without one are left
+// out of the document.
+var apiOperations = map[string]*apiOperation{
+	routeOpenAPI:     {summary: "OpenAPI document describing this API"},
+	routeDefer:       {summary: "Defer an event on an object until a later tick", request: &api.DeferEventRequest{}, response: &api.DeferEventResponse{}},
+	routeWatch:       {summary: "Stream events of changes over a websocket", query: []string{"world", "kind", "id", "controller", "selector", "queue", "sequence"}, response: &v1.Event{}, websocket: true},
+	routeBulk:        {summary: "Write newline delimited objects, streaming a result per line", query: []string{"world", "kind"}},
+	routeSearch:      {summary: "Search objects of a world", request: &api.SearchRequest{}, response: &api.SearchResponse{}},
+	routeTransaction: {summary: "Write objects of any kinds, all or nothing", request: &api.TransactionRequest{}, response: &api.TransactionResponse{}},
+	routeDeletion:    {summary: "Progress of the deletion of a world", response: &api.WorldDeletionResponse{}},
+	routeUsage:       {summary: "Usage of a world against its quota", response: &api.QuotaUsageResponse{}},
+	routeExport:      {summary: "Export a world as a tar archive, ending with end.json (which holds the error if the export fails part way)"},
+	routeImport:      {summary: "Import a world from a tar archive", response: &api.ImportResponse{}},
+	routeFork:        {summary: "Copy a world into a new world", request: &api.ForkRequest{}, response: &api.ForkResponse{}},
+	routeGetRelation: {summary: "Get tuples of a relation", request: &api.GetTuplesRequest{}, response: &api.GetTuplesResponse{}},
+	routeSetRelation: {summary: "Set tuples of a relation", request: &api.SetTuplesRequest{}, response: &api.SetTuplesResponse{}},
+	routeModifier:    {summary: "Add modifiers to tuples of a relation", request: &api.AddModifiersRequest{}, response: &api.AddModifiersResponse{}},
+	routeHistory:     {summary: "Get revisions of an object", request: &api.HistoryRequest{}, response: &api.HistoryResponse{}},
+	routeRollback:    {summary: "Restore an object to a previous revision", request: &api.RollbackRequest{}, response: &api.RollbackResponse{}},
+	routeGet:         {summary: "Get or list objects", request: &api.GetRequest{}, response: &api.GetResponse{}, headers: []string{"If-None-Match"}},
+	routeSet:         {summary: "Create or update objects", request: &api.SetRequest{}, response: &api.SetResponse{}, headers: []string{"If-Match"}},
+	routePatch:       {summary: "Patch objects", request: &api.PatchRequest{}, response: &api.PatchResponse{}},
+	routeDelete:      {summary: "Delete objects", request: &api.DeleteRequest{}, response: &api.DeleteResponse{}, headers: []string{"If-Match"}},
+}
+
+// openapiDocument builds an OpenAPI document from our routes & registered kinds, routes taking
+// a {kind} are given a path for each kind so that objects are described exactly.
+func (s *Server) openapiDocument() (*openapi.Document, error) {
+	kinds := kind.Kinds()
+	sort.Strings(kinds)
+
+	g := openapi.NewGenerator()
+	current := "" // kind we're describing, as some validations depend on it
+	g.Rules = map[string]openapi.Rule{
+		"alphanum-or-empty": func(s *openapi.Schema, _ string) { s.Pattern = "^[a-zA-Z0-9]*$" },
+		"alphanumsymbol":    func(s *openapi.Schema, _ string) { s.Pattern = "^[a-zA-Z0-9\\-_./]*$" },
+		"uuid4":             func(s *openapi.Schema, _ string) { s.Pattern = patternUUID4 },
+		"uuid4-or-empty":    func(s *openapi.Schema, _ string) { s.Pattern = orEmpty(patternUUID4) },
+		"valid_id": func(s *openapi.Schema, _ string) {
+			switch {
+			case current == "":
+				s.Pattern = "^([a-zA-Z0-9]*|" + strings.Trim(patternUUID4, "^$") + ")$"
+			case kind.AlphanumericIds(current):
+				s.Pattern = "^[a-zA-Z0-9]*$"
+			default:
+				s.Pattern = orEmpty(patternUUID4)
+			}
+		},
+		"alphanum-if-non-global": func(s *openapi.Schema, _ string) {
+			if current != "" && kind.IsGlobal(current) {
+				s.Pattern = "^[a-zA-Z0-9]*$"
+			}
+		},
+	}
+
+	// kinds first, so their schemas are generated with their own rules
+	objects := []*openapi.Schema{}
+	local := []*openapi.Schema{}
+	searchable := []*openapi.Schema{}
+	for _, k := range kinds {
+		current = k
+		ref := openapi.Ref(g.Named(kind.Type(k)))
+		g.Schemas[strings.TrimPrefix(ref.Ref, "#/components/schemas/")].Description = kind.Doc(k)
+		objects = append(objects, ref)
+		if !kind.IsGlobal(k) {
+			local = append(local, ref)
+		}
+		if kind.IsSearchable(k) {
+			searchable = append(searchable, ref)
+		}
+	}
+	current = ""
+
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: &openapi.Info{
+			Title:       "faction",
+			Description: "Objects (of each kind) within worlds, their relations & events on them.",
+			Version:     apiVersion,
+		},
+		Paths:      map[string]map[string]*openapi.Operation{},
+		Components: &openapi.Components{Schemas: g.Schemas},
+	}
+	if s.cfg.Auth != nil {
+		doc.Components.SecuritySchemes = map[string]*openapi.SecurityScheme{"bearer": {Type: "http", Scheme: "bearer"}}
+		doc.Security = []map[string][]string{{"bearer": {}}}
+	}
+
+	err := s.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		op, ok := apiOperations[route.GetName()]
+		if !ok {
+			return nil
+		}
+		tmpl, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+
+		if !strings.Contains(tmpl, "{kind}") {
+			for _, method := range methods {
+				o := s.openapiOperation(g, route.GetName(), "", tmpl, op)
+				switch route.GetName() {
+				case routeTransaction:
+					setData(o.RequestBody, &openapi.Schema{OneOf: local})
+				case routeSearch:
+					setData(o.Responses["200"], &openapi.Schema{OneOf: searchable})
+				case routeBulk:
+					o.RequestBody = &openapi.RequestBody{
+						Description: "newline delimited objects",
+						Required:    true,
+						Content:     map[string]*openapi.MediaType{contentNDJSON: {Schema: &openapi.Schema{OneOf: objects}}},
+					}
+					o.Responses["200"].Content = map[string]*openapi.MediaType{contentNDJSON: {Schema: g.Schema(reflect.TypeOf(&api.BulkResult{}))}}
+				case routeExport:
+					o.Responses["200"].Content = map[string]*openapi.MediaType{contentTar: {Schema: &openapi.Schema{Type: "string", Format: "binary"}}}
+				case routeOpenAPI:
+					o.Responses["200"].Content = openapi.JSON(&openapi.Schema{Type: "object"})
+				case routeImport:
+					o.RequestBody = &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{contentTar: {Schema: &openapi.Schema{Type: "string", Format: "binary"}}}}
+				}
+				addOperation(doc, tmpl, method, o)
+			}
+			return nil
+		}
+
+		for _, k := range kinds {
+			current = k
+			path := strings.ReplaceAll(tmpl, "{kind}", k)
+			for _, method := range methods {
+				o := s.openapiOperation(g, route.GetName(), k, path, op)
+				ref := openapi.Ref(g.Named(kind.Type(k)))
+				switch route.GetName() {
+				case routeGet:
+					setData(o.Responses["200"], ref)
+				case routeSet:
+					setData(o.RequestBody, ref)
+				}
+				addOperation(doc, path, method, o)
+			}
+		}
+		current = ""
+		return nil
+	})
+	return doc, err
+}
+
+// openapiOperation describes a route (of a kind, if given) in the document
+func (s *Server) openapiOperation(g *openapi.Generator, name, k, path string, op *apiOperation) *openapi.Operation {
+	id := name
+	if k != "" {
+		id = fmt.Sprintf("%s-%s", name, k)
+	}
+	o := &openapi.Operation{
+		OperationId: id,
+		Summary:     op.summary,
+		Parameters:  []*openapi.Parameter{},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "OK"},
+			"default": {
+				Description: "Error",
+				Content:     openapi.JSON(g.Inline(reflect.TypeOf(&struct{ Error *api.ErrorResponse }{}))),
+			},
+		},
+	}
+	if k != "" {
+		o.Tags = []string{k}
+	}
+	if s.limiter != nil {
+		o.Responses["429"] = &openapi.Response{
+			Description: "Rate limited",
+			Headers:     map[string]*openapi.Header{"Retry-After": {Description: "seconds to wait", Schema: &openapi.Schema{Type: "integer"}}},
+		}
+	}
+
+	if strings.Contains(path, "{world}") {
+		o.Parameters = append(o.Parameters, &openapi.Parameter{Name: "world", In: "path", Required: true, Schema: &openapi.Schema{Type: "string", Pattern: "^[a-zA-Z0-9]+$"}})
+	}
+	if strings.Contains(path, "{relation}") {
+		rel := &openapi.Schema{Type: "string"}
+		for _, r := range db.Relations() {
+			rel.Enum = append(rel.Enum, string(r))
+		}
+		o.Parameters = append(o.Parameters, &openapi.Parameter{Name: "relation", In: "path", Required: true, Schema: rel})
+	}
+	for _, q := range op.query {
+		o.Parameters = append(o.Parameters, &openapi.Parameter{Name: q, In: "query", Schema: &openapi.Schema{Type: "string"}})
+	}
+	for _, h := range op.headers {
+		o.Parameters = append(o.Parameters, &openapi.Parameter{Name: h, In: "header", Description: "applies to requests of a single object", Schema: &openapi.Schema{Type: "string"}})
+		switch h {
+		case "If-None-Match":
+			o.Responses["304"] = &openapi.Response{Description: "Not Modified"}
+		case "If-Match":
+			o.Responses["412"] = &openapi.Response{Description: "Precondition Failed", Content: o.Responses["default"].Content}
+		}
+	}
+
+	if op.request != nil {
+		o.RequestBody = &openapi.RequestBody{Required: true, Content: openapi.JSON(g.Inline(reflect.TypeOf(op.request)))}
+	}
+	if op.response != nil {
+		o.Responses["200"].Content = openapi.JSON(g.Inline(reflect.TypeOf(op.response)))
+	}
+	if op.websocket {
+		o.Description = "Upgrades to a websocket, each message is a JSON object of the response schema."
+		o.Responses["101"] = &openapi.Response{Description: "Switching Protocols", Content: o.Responses["200"].Content}
+		delete(o.Responses, "200")
+	}
+	return o
+}
+
+// setData sets the items of the Data field of a JSON body
+func setData(body interface{}, items *openapi.Schema) {
+	var content map[string]*openapi.MediaType
+	switch b := body.(type) {
+	case *openapi.RequestBody:
+		content = b.Content
+	case *openapi.Response:
+		content = b.Content
+	}
+	media, ok := content[contentJSON]
+	if !ok {
+		return
+	}
+	data, ok := media.Schema.Properties["Data"]
+	if ok && data.Items != nil {
+		data.Items = items
+	}
+}
+
+func addOperation(doc *openapi.Document, path, method string, o *openapi.Operation) {
+	item, ok := doc.Paths[path]
+	if !ok {
+		item = map[string]*openapi.Operation{}
+		doc.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = o
+}
+
+// orEmpty returns a pattern that also matches an empty string
+func orEmpty(pattern string) string {
+	return "^(" + strings.Trim(pattern, "^$") + ")?$"
+}
+
+func (s *Server) openapi(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentJSON)
+	w.WriteHeader(http.StatusOK)
+	w.Write(s.openapiDoc)
+}
+
+// marshalOpenAPI builds the OpenAPI document, this is done once when the server is created
+func (s *Server) marshalOpenAPI() error {
+	doc, err := s.openapiDocument()
+	if err != nil {
+		return err
+	}
+	s.openapiDoc, err = json.Marshal(doc)
+	return err
+}
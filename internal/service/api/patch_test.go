@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/voidshard/faction/internal/db"
+	"github.com/voidshard/faction/pkg/structs/api"
+)
+
+func TestPatchKind(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(map[string]string{"class": "noble"})}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	actor := getActors(t, svc, "narnia")[0]
+
+	// merge patches change only the given fields
+	rsp := &api.PatchResponse{}
+	err = svc.patchKind(ctx, "actor", &api.PatchRequest{World: "narnia", Data: []*api.Patch{
+		{Id: actor.Id, Etag: actor.Etag, Patch: json.RawMessage(`{"Firstname": "Aslan", "Labels": {"class": null, "king": "yes"}}`)},
+	}}, rsp)
+	assert.Nil(t, err)
+	assert.Len(t, rsp.Etags, 1)
+
+	patched := getActors(t, svc, "narnia", actor.Id)[0]
+	assert.Equal(t, "Aslan", patched.Firstname)
+	assert.Equal(t, "human", patched.Race)
+	assert.Equal(t, map[string]string{"king": "yes"}, patched.Labels)
+	assert.Equal(t, rsp.Etags[actor.Id], patched.Etag)
+
+	// patches at an old etag conflict
+	rsp = &api.PatchResponse{}
+	err = svc.patchKind(ctx, "actor", &api.PatchRequest{World: "narnia", Data: []*api.Patch{
+		{Id: actor.Id, Etag: actor.Etag, Patch: json.RawMessage(`{"Firstname": "Jadis"}`)},
+	}}, rsp)
+	assert.ErrorIs(t, err, db.ErrEtagMismatch)
+	assert.Equal(t, []string{actor.Id}, rsp.Conflicts)
+
+	// json patches apply operations in order
+	err = svc.patchKind(ctx, "actor", &api.PatchRequest{World: "narnia", Data: []*api.Patch{
+		{Id: actor.Id, Etag: patched.Etag, Type: api.PatchJSON, Patch: json.RawMessage(`[{"op": "replace", "path": "/Lastname", "value": "Lion"}]`)},
+	}}, &api.PatchResponse{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Lion", getActors(t, svc, "narnia", actor.Id)[0].Lastname)
+
+	// patched objects must still be valid
+	current := getActors(t, svc, "narnia", actor.Id)[0]
+	err = svc.patchKind(ctx, "actor", &api.PatchRequest{World: "narnia", Data: []*api.Patch{
+		{Id: actor.Id, Etag: current.Etag, Patch: json.RawMessage(`{"Firstname": "not alphanumeric!"}`)},
+	}}, &api.PatchResponse{})
+	assert.ErrorIs(t, err, ErrInvalid)
+}
@@ -68,7 +68,7 @@ func newQueue(qu queue.Queue) (*Queue, error) {
 		}
 		// otherwise reject so it is redelivered
 		item.Value().Reject()
-		log.Warn().Str("AckId", item.Key()).Str("reason", fmt.Sprintf("%s", reason)).Msg("AckId evicted from cache")
+		log.Warn().Str("AckId", item.Key()).Int("reason", int(reason)).Msg("AckId evicted from cache")
 	})
 	go cache.Start() // cache cleaning
 
@@ -82,34 +82,58 @@ func (q *Queue) Close() {
 }
 
 func (q *Queue) Ack(id string) error {
-	bits := strings.SplitN(id, ".", 2)
-	if len(bits) != 2 {
-		return fmt.Errorf("invalid ack id %s", id)
+	bits, err := splitAckId(id)
+	if err != nil {
+		return err
 	}
-	if uuid.IsValidUUID(bits[0]) && uuid.IsValidUUID(bits[1]) {
-		if bits[0] == q.id { // this host sent the original message
-			item, ok := q.ackCache.GetAndDelete(bits[1])
-			if ok {
-				return item.Value().Ack()
-			}
-			return nil // ack already processed
-		} else { // another host sent the message: publish
-			q.log.Debug().Str("AckId", id).Msg("Non-local ack, forwarding to topic")
-			return q.qu.Publish(context.Background(), topicEventsAck, bits, []byte(id))
-		}
+	if bits[0] != q.id { // another host sent the message: publish
+		q.log.Debug().Str("AckId", id).Msg("Non-local ack, forwarding to topic")
+		return q.qu.Publish(context.Background(), topicEventsAck, bits[:2], []byte(id))
 	}
-	return fmt.Errorf("invalid ack id %s", id)
+
+	// this host sent the original message
+	item := q.ackCache.Get(bits[1])
+	if item == nil {
+		return nil // ack already processed
+	}
+	// nb. acks are authorized by the world & kind in the id, so they must be the event's
+	evt := &v1.Event{}
+	err = json.Unmarshal(item.Value().Data(), evt)
+	if err != nil || evt.World != bits[2] || evt.Kind != bits[3] {
+		return fmt.Errorf("ack id %s does not match its event", id)
+	}
+	q.ackCache.Delete(bits[1])
+	return item.Value().Ack()
 }
 
 // NewAckId generates a new ack id for a given message.
 //
-// We keep tabs on this Id & msg so that the caller can send us an 'ack' for it later.
+// We keep tabs on this Id & msg so that the caller can send us an 'ack' for it later. The id
+// holds the world & kind of the event so that any server can authorize the ack (see ackTarget).
 func (q *Queue) NewAckId(msg queue.Message, event *v1.Event) string {
-	ackId := fmt.Sprintf("%s.%s", q.id, msg.Id())
+	ackId := fmt.Sprintf("%s.%s.%s.%s", q.id, msg.Id(), event.World, event.Kind)
 	q.ackCache.Set(msg.Id(), msg, ttlcache.DefaultTTL)
 	return ackId
 }
 
+// ackTarget returns the world & kind of the event an ack id is for
+func ackTarget(id string) (string, string, error) {
+	bits, err := splitAckId(id)
+	if err != nil {
+		return "", "", err
+	}
+	return bits[2], bits[3], nil
+}
+
+// splitAckId returns the server, message, world & kind of an ack id (see NewAckId)
+func splitAckId(id string) ([]string, error) {
+	bits := strings.SplitN(id, ".", 4)
+	if len(bits) != 4 || !uuid.IsValidUUID(bits[0]) || !uuid.IsValidUUID(bits[1]) {
+		return nil, fmt.Errorf("invalid ack id %s", id)
+	}
+	return bits, nil
+}
+
 // PublishEvent publishes a event to the event stream.
 func (q *Queue) PublishEvent(ctx context.Context, ch *v1.Event) error {
 	key := toQueueKey(ch)
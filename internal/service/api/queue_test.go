@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/voidshard/faction/internal/auth"
+	"github.com/voidshard/faction/internal/queue"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+// memoryQueue is an in-process queue.Queue for tests; topics fan out to subscribers with a
+// matching key & queues hand each message to one subscriber.
+type memoryQueue struct {
+	lock   sync.Mutex
+	subs   []*memorySubscription
+	queues map[string]chan queue.Message
+}
+
+func newMemoryQueue() *memoryQueue {
+	return &memoryQueue{queues: map[string]chan queue.Message{}}
+}
+
+func (q *memoryQueue) Request(ctx context.Context, name string, data []byte) (queue.Subscription, error) {
+	return nil, fmt.Errorf("request not supported")
+}
+
+func (q *memoryQueue) Enqueue(ctx context.Context, name string, data []byte) error {
+	q.queue(name) <- &memoryMessage{id: uuid.New(), data: data}
+	return nil
+}
+
+func (q *memoryQueue) Dequeue(name string) (queue.Subscription, error) {
+	return &memorySubscription{ch: q.queue(name), shared: true}, nil
+}
+
+func (q *memoryQueue) Publish(ctx context.Context, topic string, key []string, data []byte) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for _, sub := range q.subs {
+		if sub.matches(topic, key) {
+			sub.send(&memoryMessage{id: uuid.New(), subject: topic, data: data})
+		}
+	}
+	return nil
+}
+
+func (q *memoryQueue) Subscribe(name, topic string, key []string, durable bool) (queue.Subscription, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	sub := &memorySubscription{ch: make(chan queue.Message, 1024), topic: topic, key: key}
+	q.subs = append(q.subs, sub)
+	return sub, nil
+}
+
+func (q *memoryQueue) DeleteQueue(name string) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	delete(q.queues, name)
+	return nil
+}
+
+func (q *memoryQueue) Close() error {
+	return nil
+}
+
+func (q *memoryQueue) queue(name string) chan queue.Message {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	ch, ok := q.queues[name]
+	if !ok {
+		ch = make(chan queue.Message, 1024)
+		q.queues[name] = ch
+	}
+	return ch
+}
+
+type memorySubscription struct {
+	lock   sync.Mutex
+	ch     chan queue.Message
+	topic  string
+	key    []string
+	closed bool
+
+	// shared subscriptions read from a queue, which outlives them
+	shared bool
+}
+
+// matches returns if a message published with the key is for us, empty keys match anything
+func (s *memorySubscription) matches(topic string, key []string) bool {
+	if s.topic != topic || len(s.key) != len(key) {
+		return false
+	}
+	for i, k := range s.key {
+		if k != "" && k != key[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *memorySubscription) send(msg queue.Message) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.ch <- msg:
+	default: // nobody is listening, as with a full rabbit queue we drop it
+	}
+}
+
+func (s *memorySubscription) Channel() <-chan queue.Message {
+	return s.ch
+}
+
+func (s *memorySubscription) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.closed && !s.shared {
+		close(s.ch)
+	}
+	s.closed = true
+	return nil
+}
+
+type memoryMessage struct {
+	id      string
+	subject string
+	data    []byte
+	acked   bool
+}
+
+func (m *memoryMessage) Id() string                                { return m.id }
+func (m *memoryMessage) CorrelationId() string                     { return "" }
+func (m *memoryMessage) Reply(ctx context.Context, b []byte) error { return nil }
+func (m *memoryMessage) Subject() string                           { return m.subject }
+func (m *memoryMessage) Data() []byte                              { return m.data }
+func (m *memoryMessage) Ack() error                                { m.acked = true; return nil }
+func (m *memoryMessage) Reject() error                             { return nil }
+func (m *memoryMessage) Timestamp() time.Time                      { return time.Time{} }
+func (m *memoryMessage) Context() context.Context                  { return context.Background() }
+
+func TestAckEvent(t *testing.T) {
+	svc, _ := newTestService(t)
+	a, err := auth.New(&auth.Config{
+		Tokens: []*auth.StaticToken{{Token: "reader-token", Subject: "reader", Roles: []string{"narnia-reader"}}},
+		Roles: map[string][]*auth.Rule{
+			"narnia-reader": {{Worlds: []string{"narnia"}, Kinds: []string{"actor"}, Verbs: []string{auth.VerbWatch}}},
+		},
+	})
+	assert.Nil(t, err)
+	svc.cfg.Auth = a
+	reader, err := a.Authenticate("reader-token")
+	assert.Nil(t, err)
+	ctx := auth.WithIdentity(context.Background(), reader)
+
+	delivered := func(evt *v1.Event) (*memoryMessage, string) {
+		data, err := json.Marshal(evt)
+		assert.Nil(t, err)
+		msg := &memoryMessage{id: uuid.New(), data: data}
+		return msg, svc.qu.NewAckId(msg, evt)
+	}
+
+	// watchers may ack events they may watch
+	msg, ackId := delivered(&v1.Event{World: "narnia", Kind: "actor", Id: uuid.New()})
+	assert.ErrorIs(t, svc.ackEvent(context.Background(), ackId), auth.ErrUnauthenticated)
+	assert.False(t, msg.acked)
+	assert.Nil(t, svc.ackEvent(ctx, ackId))
+	assert.True(t, msg.acked)
+
+	// but not others
+	msg, ackId = delivered(&v1.Event{World: "oz", Kind: "actor", Id: uuid.New()})
+	assert.ErrorIs(t, svc.ackEvent(ctx, ackId), auth.ErrForbidden)
+	assert.False(t, msg.acked)
+
+	// even by claiming to be for a world they may watch
+	forged := strings.Replace(ackId, ".oz.", ".narnia.", 1)
+	assert.NotNil(t, svc.ackEvent(ctx, forged))
+	assert.False(t, msg.acked)
+
+	assert.ErrorIs(t, svc.ackEvent(ctx, "nonsense"), ErrInvalid)
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+// checkObjectQuota returns ErrQuotaExceeded if creating the given objects of a kind would take
+// the world over its quota for the kind (see World.Quota). Updates don't count against the quota.
+//
+// nb. the count & the write are not atomic, so concurrent writers may overshoot the quota
+// slightly; it is intended to stop runaway scripts, not to be exact.
+func (s *Service) checkObjectQuota(ctx context.Context, world, k string, objects []v1.Object) error {
+	if world == "" {
+		return nil // global kinds aren't within a world
+	}
+	quota := s.tickManager.Quota(world)
+	if quota == nil || quota.Objects[k] <= 0 {
+		return nil
+	}
+
+	var creates int64
+	for _, obj := range objects {
+		if obj.GetEtag() == "" {
+			creates++
+		}
+	}
+	if creates == 0 {
+		return nil
+	}
+
+	count, err := s.db.Count(ctx, world, k)
+	if err != nil {
+		return err
+	}
+	if count+creates > quota.Objects[k] {
+		return fmt.Errorf("%w world %s allows %d %s objects, has %d, creating %d", ErrQuotaExceeded, world, quota.Objects[k], k, count, creates)
+	}
+	return nil
+}
+
+// checkDeferredQuota returns ErrQuotaExceeded if the world has reached its quota of events
+// deferred to the given tick.
+func (s *Service) checkDeferredQuota(ctx context.Context, world string, tick uint64) error {
+	quota := s.tickManager.Quota(world)
+	if quota == nil || quota.DeferredPerTick <= 0 {
+		return nil
+	}
+	count, err := s.db.CountDeferredEvents(ctx, world, tick)
+	if err != nil {
+		return err
+	}
+	if count >= quota.DeferredPerTick {
+		return fmt.Errorf("%w world %s allows %d events deferred to tick %d", ErrQuotaExceeded, world, quota.DeferredPerTick, tick)
+	}
+	return nil
+}
+
+// addWatcher counts a new event stream watching a world, returning ErrQuotaExceeded if the world
+// already has as many as its quota allows. A stream that names no world may be sent events of
+// every world, so it counts against (& must be within) the quota of every world. Streams of
+// global kinds are sent no events of any world, so aren't counted.
+//
+// Watchers are counted in the database so that quotas hold across API servers. The stream's
+// lease is renewed until it is released with the returned func, should we die it expires.
+func (s *Service) addWatcher(ctx context.Context, world, k string) (func(), error) {
+	if k != "" && kind.IsGlobal(k) {
+		return func() {}, nil
+	}
+
+	quotas := s.tickManager.WatcherQuotas()
+	if world != "" {
+		quota, ok := quotas[world]
+		quotas = map[string]int64{}
+		if ok {
+			quotas[world] = quota
+		}
+	}
+	for w, quota := range quotas {
+		count, err := s.countWatchers(ctx, w)
+		if err != nil {
+			return nil, err
+		}
+		if count >= quota {
+			return nil, fmt.Errorf("%w world %s allows %d watchers", ErrQuotaExceeded, w, quota)
+		}
+	}
+
+	id := uuid.New()
+	err := s.db.SetWatcher(ctx, world, id, watcherLease)
+	if err != nil {
+		return nil, err
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(watcherLease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				rctx, cancel := context.WithTimeout(context.Background(), s.cfg.TimeoutWrite)
+				err := s.db.SetWatcher(rctx, world, id, watcherLease)
+				cancel()
+				if err != nil {
+					s.log.Warn().Str("world", world).Err(err).Msg("failed to renew watcher lease")
+				}
+			}
+		}
+	}()
+
+	once := sync.Once{}
+	return func() {
+		once.Do(func() {
+			close(stop)
+			rctx, cancel := context.WithTimeout(context.Background(), s.cfg.TimeoutWrite)
+			defer cancel()
+			err := s.db.RemoveWatcher(rctx, id)
+			if err != nil {
+				s.log.Warn().Str("world", world).Err(err).Msg("failed to release watcher lease")
+			}
+		})
+	}, nil
+}
+
+// countWatchers returns the number of event streams watching a world, including those that
+// watch every world
+func (s *Service) countWatchers(ctx context.Context, world string) (int64, error) {
+	count, err := s.db.CountWatchers(ctx, world)
+	if err != nil {
+		return 0, err
+	}
+	all, err := s.db.CountWatchers(ctx, "")
+	return count + all, err
+}
+
+// quotaUsage reports the current use of a world against its quota.
+func (s *Service) quotaUsage(ctx context.Context, world string, rsp *api.QuotaUsageResponse) error {
+	pan := log.NewSpan(ctx, "service.quotaUsage", map[string]interface{}{"world": world})
+	defer pan.End()
+
+	worlds := []*v1.World{}
+	err := s.db.Get(ctx, "", kindWorld, []string{world}, &worlds)
+	if err != nil {
+		return pan.Err(err)
+	} else if len(worlds) == 0 {
+		return pan.Err(fmt.Errorf("%w world %s", ErrNotFound, world))
+	}
+
+	usage := &v1.QuotaUsage{
+		World:    world,
+		Quota:    worlds[0].Quota,
+		Objects:  map[string]int64{},
+		Deferred: map[uint64]int64{},
+	}
+
+	for _, k := range kind.Kinds() {
+		if kind.IsGlobal(k) {
+			continue
+		}
+		count, err := s.db.Count(ctx, world, k)
+		if err != nil {
+			return pan.Err(err)
+		}
+		usage.Objects[k] = count
+	}
+
+	ticks, err := s.db.DeferredTicks(ctx, world)
+	if err != nil {
+		return pan.Err(err)
+	}
+	for _, tick := range ticks {
+		count, err := s.db.CountDeferredEvents(ctx, world, tick)
+		if err != nil {
+			return pan.Err(err)
+		}
+		usage.Deferred[tick] = count
+	}
+
+	usage.Watchers, err = s.countWatchers(ctx, world)
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	rsp.Data = usage
+	return nil
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voidshard/faction/internal/auth"
+	"github.com/voidshard/faction/pkg/structs/api"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Route names, rate limits are configured by these (see Config.RateLimits).
+const (
+	routeDefer       = "defer"
+	routeWatch       = "watch"
+	routeAck         = "ack"
+	routeBulk        = "bulk"
+	routeSearch      = "search"
+	routeTransaction = "transaction"
+	routeDeletion    = "deletion"
+	routeUsage       = "usage"
+	routeExport      = "export"
+	routeImport      = "import"
+	routeFork        = "fork"
+	routeGetRelation = "get-relation"
+	routeSetRelation = "set-relation"
+	routeModifier    = "modifier"
+	routeHistory     = "history"
+	routeRollback    = "rollback"
+	routeGet         = "get"
+	routeSet         = "set"
+	routePatch       = "patch"
+	routeDelete      = "delete"
+)
+
+// grpcRoutes maps gRPC methods to the route of the same operation over HTTP, so that limits
+// apply to both alike.
+var grpcRoutes = map[string]string{
+	"Get":       routeGet,
+	"Set":       routeSet,
+	"Delete":    routeDelete,
+	"Search":    routeSearch,
+	"Defer":     routeDefer,
+	"OnChange":  routeWatch,
+	"AckStream": routeAck,
+}
+
+// retryAfter returns the seconds to send in a Retry-After header, which must be a whole number
+func retryAfter(wait time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(wait.Seconds())))
+}
+
+// rateLimit is middleware that limits the rate of requests each client makes to each route,
+// clients are identified by their Identity (if auth is configured) or remote address.
+//
+// Requests over the limit are refused with 429 & a Retry-After header.
+func (s *Server) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := mux.CurrentRoute(r)
+		if s.limiter == nil || route == nil || route.GetName() == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client := remoteHost(r.RemoteAddr)
+		id := auth.FromContext(r.Context())
+		if id != nil {
+			client = id.Subject
+		}
+
+		ok, wait := s.limiter.Allow(route.GetName(), client)
+		if !ok {
+			w.Header().Set("Retry-After", retryAfter(wait))
+			resp := &api.ErrorResponse{Code: http.StatusTooManyRequests, Message: fmt.Sprintf("rate limit exceeded for %s, retry in %s", route.GetName(), wait)}
+			s.writeResp(w, http.StatusTooManyRequests, &struct{ Error *api.ErrorResponse }{resp})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// grpcRateLimit is rateLimit for gRPC calls, the wait is sent in the "retry-after" header
+func (s *Server) grpcRateLimit(ctx context.Context, method string) error {
+	if s.limiter == nil {
+		return nil
+	}
+	route, ok := grpcRoutes[path.Base(method)]
+	if !ok {
+		return nil
+	}
+
+	client := ""
+	p, ok := peer.FromContext(ctx)
+	if ok && p.Addr != nil {
+		client = remoteHost(p.Addr.String())
+	}
+	id := auth.FromContext(ctx)
+	if id != nil {
+		client = id.Subject
+	}
+
+	ok, wait := s.limiter.Allow(route, client)
+	if ok {
+		return nil
+	}
+	grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter(wait)))
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s, retry in %s", route, wait)
+}
+
+func (s *Server) unaryRateLimit(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	err := s.grpcRateLimit(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamRateLimit(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := s.grpcRateLimit(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// remoteHost returns the host of a remote address, without the port
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return strings.TrimSpace(addr)
+	}
+	return host
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/voidshard/faction/pkg/kind"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/log"
+)
+
+// checkReferences ensures that every object referenced by the given objects (see
+// kind.References) exists, returning ErrPrecondition listing any that do not.
+//
+// Objects being written together may reference each other. Checks are skipped if the world
+// has turned them off (see World.SkipReferenceChecks).
+func (s *Service) checkReferences(ctx context.Context, world string, objects []v1.Object) error {
+	missing, err := s.missingReferences(ctx, world, objects)
+	if err != nil {
+		return err
+	}
+	all := []string{}
+	for _, m := range missing {
+		all = append(all, m...)
+	}
+	return referencesError(all)
+}
+
+// missingReferences returns the referenced objects that do not exist for each of the given
+// objects (in the same order), as "kind id".
+func (s *Service) missingReferences(ctx context.Context, world string, objects []v1.Object) ([][]string, error) {
+	missing := make([][]string, len(objects))
+	if world != "" && s.tickManager.SkipReferenceChecks(world) {
+		return missing, nil
+	}
+
+	// the references of each object, and kind -> id -> exists of everything referenced
+	refs := make([][]*kind.Reference, len(objects))
+	values := make([][]string, len(objects))
+	wanted := map[string]map[string]bool{}
+	for i, obj := range objects {
+		declared := kind.References(obj.GetKind())
+		if len(declared) == 0 {
+			continue
+		}
+		fields, err := objectFields(obj)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range declared {
+			id := fieldString(fields, ref.Field)
+			if id == "" {
+				continue
+			}
+			refs[i] = append(refs[i], ref)
+			values[i] = append(values[i], id)
+			if _, ok := wanted[ref.Kind]; !ok {
+				wanted[ref.Kind] = map[string]bool{}
+			}
+			wanted[ref.Kind][id] = false
+		}
+	}
+	if len(wanted) == 0 {
+		return missing, nil
+	}
+
+	pan := log.NewSpan(ctx, "service.missingReferences", map[string]interface{}{"world": world, "kinds": len(wanted)})
+	defer pan.End()
+
+	for _, obj := range objects {
+		if ids, ok := wanted[obj.GetKind()]; ok && obj.GetId() != "" {
+			ids[obj.GetId()] = true
+		}
+	}
+	for k, ids := range wanted {
+		space := world
+		if kind.IsGlobal(k) {
+			space = ""
+		}
+		lookup := []string{}
+		for id, exists := range ids {
+			if !exists {
+				lookup = append(lookup, id)
+			}
+		}
+		if len(lookup) == 0 {
+			continue
+		}
+
+		found := []*v1.Meta{}
+		err := s.db.Get(ctx, space, k, lookup, &found)
+		if err != nil {
+			return nil, pan.Err(err)
+		}
+		for _, f := range found {
+			ids[f.Id] = true
+		}
+	}
+
+	for i := range objects {
+		for j, ref := range refs[i] {
+			if !wanted[ref.Kind][values[i][j]] {
+				missing[i] = append(missing[i], fmt.Sprintf("%s %s", ref.Kind, values[i][j]))
+			}
+		}
+	}
+	return missing, nil
+}
+
+// referencesError returns ErrPrecondition listing missing references, if there are any
+func referencesError(missing []string) error {
+	if len(missing) == 0 {
+		return nil
+	}
+	unique := map[string]bool{}
+	for _, m := range missing {
+		unique[m] = true
+	}
+	sorted := []string{}
+	for m := range unique {
+		sorted = append(sorted, m)
+	}
+	sort.Strings(sorted)
+	return fmt.Errorf("%w referenced objects not found: %s", ErrPrecondition, strings.Join(sorted, ", "))
+}
+
+// objectFields returns an object as a map of its fields by json name
+func objectFields(obj v1.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+// fieldString returns the string value of a (possibly nested, "." separated) field, or ""
+func fieldString(fields map[string]interface{}, field string) string {
+	parts := strings.Split(field, ".")
+	for _, p := range parts[:len(parts)-1] {
+		fields, _ = fields[p].(map[string]interface{})
+	}
+	value, _ := fields[parts[len(parts)-1]].(string)
+	return value
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+func TestCheckReferences(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	elf := newTestActor(nil)
+	elf.Race = "elf"
+	rsp := &api.SetResponse{}
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil), elf}}, rsp)
+	assert.ErrorIs(t, err, ErrPrecondition)
+	assert.Contains(t, err.Error(), "race elf")
+	assert.Len(t, rsp.Etags, 0)
+	assert.Len(t, getActors(t, svc, "narnia"), 0)
+
+	// references are checked within the object's world
+	newTestWorld(t, svc, "oz")
+	err = svc.checkReferences(ctx, "oz", []v1.Object{newTestActor(nil)})
+	assert.Nil(t, err)
+	err = svc.checkReferences(ctx, "narnia", []v1.Object{elf})
+	assert.ErrorIs(t, err, ErrPrecondition)
+}
+
+func TestMissingReferences(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	elf := newTestActor(nil)
+	elf.Race = "elf"
+	dwarf := newTestActor(nil)
+	dwarf.Race = "dwarf"
+	dwarf.Culture = "mountain"
+
+	// objects written together may reference each other
+	missing, err := svc.missingReferences(ctx, "narnia", []v1.Object{
+		newTestActor(nil),
+		elf,
+		dwarf,
+		&v1.Race{Meta: v1.Meta{Kind: "race", Id: "elf"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{nil, nil, {"race dwarf", "culture mountain"}, nil}, missing)
+	assert.Equal(t, "precondition failed referenced objects not found: culture mountain, race dwarf", referencesError(missing[2]).Error())
+	assert.Nil(t, referencesError(missing[0]))
+}
+
+func TestSkipReferenceChecks(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorldWith(t, svc, &v1.World{Meta: v1.Meta{Id: "narnia"}, SkipReferenceChecks: true})
+	ctx := context.Background()
+
+	elf := newTestActor(nil)
+	elf.Race = "elf"
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{elf}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	assert.Len(t, getActors(t, svc, "narnia"), 1)
+}
+
+func TestFieldString(t *testing.T) {
+	obj := &v1.Faction{Meta: v1.Meta{Kind: "faction"}, Headquarters: v1.Headquarters{Area: "a"}}
+	fields, err := objectFields(obj)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "a", fieldString(fields, "Headquarters.Area"))
+	assert.Equal(t, "", fieldString(fields, "Headquarters.Building"))
+	assert.Equal(t, "", fieldString(fields, "Missing.Area"))
+	assert.Equal(t, "faction", fieldString(fields, "_kind"))
+}
@@ -4,30 +4,44 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/voidshard/faction/internal/auth"
 	"github.com/voidshard/faction/internal/db"
 	"github.com/voidshard/faction/internal/queue"
+	"github.com/voidshard/faction/internal/ratelimit"
 	"github.com/voidshard/faction/internal/search"
 	"github.com/voidshard/faction/pkg/kind"
+	pb "github.com/voidshard/faction/pkg/proto/v1"
 	"github.com/voidshard/faction/pkg/structs/api"
 	"github.com/voidshard/faction/pkg/util/log"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
 )
 
 const (
 	apiVersion = "v1"
 )
 
-// Server encapsulates serving our service as an HTTP API.
+// Server encapsulates serving our service as an HTTP & gRPC API.
 type Server struct {
 	cfg *Config
 	log log.Logger
 
 	srv    *http.Server
 	router *mux.Router
+	grpc   *grpc.Server
+
+	// limiter limits the rate of requests from each client, if rate limits are configured
+	limiter *ratelimit.Limiter
+
+	// openapiDoc is our OpenAPI document (JSON), built from our routes & registered kinds
+	openapiDoc []byte
 
 	shuttingDown bool
 
@@ -51,14 +65,43 @@ func NewServer(cfg *Config, db db.Database, qu queue.Queue, sb search.Search) (*
 		router: mux.NewRouter(),
 		svc:    svc,
 	}
+	if len(cfg.RateLimits) > 0 {
+		me.limiter = ratelimit.New(cfg.RateLimits)
+	}
+	me.grpc = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(me.unaryAuthenticate, me.unaryRateLimit),
+		grpc.ChainStreamInterceptor(me.streamAuthenticate, me.streamRateLimit),
+	)
+	pb.RegisterAPIServer(me.grpc, &grpcServer{srv: me})
+
+	me.router.Use(me.authenticate, me.rateLimit)
 
 	me.router.HandleFunc(fmt.Sprintf("/_health"), me.health).Methods("GET")
-	me.router.HandleFunc(fmt.Sprintf("/%s/event", apiVersion), me.deferEvent).Methods("POST")
-	me.router.HandleFunc(fmt.Sprintf("/%s/event", apiVersion), me.onChangeEvent).Methods("GET") // Websocket
-	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/search", apiVersion), me.search).Methods("GET")
-	me.router.HandleFunc(fmt.Sprintf("/%s/{kind}", apiVersion), me.getKind).Methods("GET")
-	me.router.HandleFunc(fmt.Sprintf("/%s/{kind}", apiVersion), me.setKind).Methods("POST")
-	me.router.HandleFunc(fmt.Sprintf("/%s/{kind}", apiVersion), me.delKind).Methods("DELETE")
+	me.router.HandleFunc(fmt.Sprintf("/%s/event", apiVersion), me.deferEvent).Methods("POST").Name(routeDefer)
+	me.router.HandleFunc(fmt.Sprintf("/%s/event", apiVersion), me.onChangeEvent).Methods("GET").Name(routeWatch) // Websocket
+	me.router.HandleFunc(fmt.Sprintf("/%s/bulk", apiVersion), me.bulkSet).Methods("POST").Name(routeBulk)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/search", apiVersion), me.search).Methods("GET").Name(routeSearch)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/transaction", apiVersion), me.transaction).Methods("POST").Name(routeTransaction)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/deletion", apiVersion), me.worldDeletion).Methods("GET").Name(routeDeletion)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/usage", apiVersion), me.quotaUsage).Methods("GET").Name(routeUsage)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/export", apiVersion), me.exportWorld).Methods("GET").Name(routeExport)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/import", apiVersion), me.importWorld).Methods("POST").Name(routeImport)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/fork", apiVersion), me.forkWorld).Methods("POST").Name(routeFork)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/relation/{relation}", apiVersion), me.getTuples).Methods("GET").Name(routeGetRelation)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/relation/{relation}", apiVersion), me.setTuples).Methods("POST").Name(routeSetRelation)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{world}/relation/{relation}/modifier", apiVersion), me.addModifiers).Methods("POST").Name(routeModifier)
+	me.router.HandleFunc(openapiPath, me.openapi).Methods("GET").Name(routeOpenAPI) // before {kind}
+	me.router.HandleFunc(fmt.Sprintf("/%s/{kind}/history", apiVersion), me.historyKind).Methods("GET").Name(routeHistory)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{kind}/rollback", apiVersion), me.rollbackKind).Methods("POST").Name(routeRollback)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{kind}", apiVersion), me.getKind).Methods("GET").Name(routeGet)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{kind}", apiVersion), me.setKind).Methods("POST").Name(routeSet)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{kind}", apiVersion), me.patchKind).Methods("PATCH").Name(routePatch)
+	me.router.HandleFunc(fmt.Sprintf("/%s/{kind}", apiVersion), me.delKind).Methods("DELETE").Name(routeDelete)
+
+	err = me.marshalOpenAPI()
+	if err != nil {
+		return nil, err
+	}
 
 	return me, nil
 }
@@ -108,9 +151,20 @@ func (s *Server) onChangeEvent(w http.ResponseWriter, r *http.Request) {
 		Kind:       qvars.Get("kind"),
 		Id:         qvars.Get("id"),
 		Controller: qvars.Get("controller"),
+		Selector:   qvars.Get("selector"),
 		Queue:      qvars.Get("queue"),
 	}
 
+	// authorize before upgrading, so that we can reply with a status
+	err := s.svc.authorize(ctx, auth.VerbWatch, req.World, req.Kind)
+	if err != nil {
+		pan.Err(err)
+		resp.Code = errorCodeHTTP(err)
+		resp.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), &struct{ Error *api.ErrorResponse }{resp})
+		return
+	}
+
 	// upgrade to a websocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -120,7 +174,7 @@ func (s *Server) onChangeEvent(w http.ResponseWriter, r *http.Request) {
 		s.writeResp(w, http.StatusInternalServerError, resp)
 		return
 	}
-	sock := newWebSocket(s.svc, conn)
+	sock := newWebSocket(ctx, s.svc, conn)
 
 	// usual validations
 	if req.Kind != "" && !kind.IsValid(req.Kind) {
@@ -138,13 +192,26 @@ func (s *Server) onChangeEvent(w http.ResponseWriter, r *http.Request) {
 		sock.Close(resp)
 		return
 	}
+	if from := qvars.Get("sequence"); from != "" {
+		req.FromSequence, err = strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			pan.Err(err)
+			resp.Code = http.StatusBadRequest
+			resp.Message = "invalid sequence"
+			sock.Close(resp)
+			return
+		}
+	}
 
 	// subscribe to events
-	events, kill, err := s.svc.subscribeToEvents(req)
+	events, kill, err := s.svc.subscribeToEvents(ctx, req)
 	if err != nil {
 		pan.Err(err)
-		resp.Code = http.StatusInternalServerError
+		resp.Code = errorCodeHTTP(err)
 		resp.Message = "failed to subscribe to events"
+		if resp.Code == http.StatusBadRequest || resp.Code == http.StatusGone {
+			resp.Message = err.Error()
+		}
 		sock.Close(resp)
 		return
 	}
@@ -194,6 +261,15 @@ func (s *Server) deferEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = s.svc.authorize(ctx, auth.VerbDefer, req.World, req.Kind)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
 	err = s.svc.deferEvent(ctx, req, resp)
 	if err != nil {
 		pan.Err(err)
@@ -265,6 +341,15 @@ func (s *Server) search(w http.ResponseWriter, r *http.Request) {
 		"score":         len(req.Score),
 	})
 
+	err = s.svc.authorize(ctx, auth.VerbSearch, world, req.Kind)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
 	err = s.svc.searchKind(ctx, world, req, resp)
 	if err != nil {
 		pan.Err(err)
@@ -278,34 +363,28 @@ func (s *Server) search(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
-// nb. technically dictating our reply based on a GET body is considered anti-html best practice
-// but opensearch / elasticsearch do this because it makes more sense than forcing users to use
-// a POST to get data OR forcing a boatload of query params .. so .. eh.
-func (s *Server) getKind(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutRead)
+func (s *Server) transaction(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutWrite)
 	defer cancel()
 
-	pan := log.NewSpan(ctx, "api.Get")
+	pan := log.NewSpan(ctx, "api.transaction")
 	ctx = pan.Context // make sure the root span is in the context
 	defer pan.End()
 
-	resp := &api.GetResponse{Error: &api.ErrorResponse{}}
+	req := &api.TransactionRequest{}
+	resp := &api.TransactionResponse{Error: &api.ErrorResponse{}}
 
-	// read out the kind
 	vars := mux.Vars(r)
-	k, ok := vars["kind"]
-	if !ok || !kind.IsValid(k) {
-		pan.Err(fmt.Errorf("kind %s not found", k))
-		resp.Error.Code = http.StatusNotFound
-		resp.Error.Message = "not found"
-		s.writeResp(w, http.StatusNotFound, resp)
+	world, ok := vars["world"]
+	if !ok || world == "" {
+		pan.Err(fmt.Errorf("world id invalid"))
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid world"
+		s.writeResp(w, http.StatusBadRequest, resp)
 		return
 	}
-	pan.SetAttributes(map[string]interface{}{"kind": k})
 
-	// parse the body of the request
-	body := &api.GetRequest{}
-	err := readJson(r, body)
+	err := readJson(r, req)
 	if err != nil {
 		pan.Err(err)
 		resp.Error.Code = http.StatusBadRequest
@@ -314,38 +393,61 @@ func (s *Server) getKind(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// we could just ignore this, but it might confuse a caller if we ignore inputs
-	if len(body.Ids) > 0 && len(body.Labels) > 0 {
-		pan.Err(fmt.Errorf("cannot specify both IDs and labels in get"))
+	err = kind.Validate("", req)
+	if err != nil {
+		pan.Err(err)
 		resp.Error.Code = http.StatusBadRequest
-		resp.Error.Message = "cannot specify both IDs and labels in get"
+		resp.Error.Message = err.Error()
 		s.writeResp(w, http.StatusBadRequest, resp)
 		return
 	}
-	if body.Limit <= 0 {
-		body.Limit = 100 // set default if user doesn't specify
-	}
 
-	// set some attributes for the span
-	pan.SetAttributes(map[string]interface{}{
-		"ids":    len(body.Ids),
-		"limit":  body.Limit,
-		"offset": body.Offset,
-		"labels": len(body.Labels),
-		"world":  body.World,
-	})
+	pan.SetAttributes(map[string]interface{}{"world": world, "data": len(req.Data)})
 
-	// validate the GetRequest Fields
-	err = kind.Validate(k, body)
+	err = s.svc.transaction(ctx, world, req, resp)
 	if err != nil {
 		pan.Err(err)
-		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Code = errorCodeHTTP(err)
 		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) worldDeletion(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutRead)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.worldDeletion")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	resp := &api.WorldDeletionResponse{Error: &api.ErrorResponse{}}
+
+	vars := mux.Vars(r)
+	world, ok := vars["world"]
+	if !ok || world == "" {
+		pan.Err(fmt.Errorf("world id invalid"))
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid world"
 		s.writeResp(w, http.StatusBadRequest, resp)
 		return
 	}
+	pan.SetAttributes(map[string]interface{}{"world": world})
 
-	err = s.svc.getKind(ctx, k, body, resp)
+	err := s.svc.authorize(ctx, auth.VerbGet, world, kindWorld)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.worldDeletion(ctx, world, resp)
 	if err != nil {
 		pan.Err(err)
 		resp.Error.Code = errorCodeHTTP(err)
@@ -358,50 +460,129 @@ func (s *Server) getKind(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
-func (s *Server) setKind(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutWrite)
+func (s *Server) quotaUsage(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutRead)
 	defer cancel()
 
-	pan := log.NewSpan(ctx, "api.setKind")
+	pan := log.NewSpan(ctx, "api.quotaUsage")
 	ctx = pan.Context // make sure the root span is in the context
 	defer pan.End()
 
-	resp := &api.SetResponse{Error: &api.ErrorResponse{}}
+	resp := &api.QuotaUsageResponse{Error: &api.ErrorResponse{}}
 
-	// read out the kind
 	vars := mux.Vars(r)
-	k, ok := vars["kind"]
-	if !ok || !kind.IsValid(k) {
-		pan.Err(fmt.Errorf("kind %s not found", k))
-		resp.Error.Code = http.StatusNotFound
-		resp.Error.Message = "not found"
-		s.writeResp(w, http.StatusNotFound, resp)
+	world, ok := vars["world"]
+	if !ok || world == "" {
+		pan.Err(fmt.Errorf("world id invalid"))
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid world"
+		s.writeResp(w, http.StatusBadRequest, resp)
 		return
 	}
-	pan.SetAttributes(map[string]interface{}{"kind": k})
+	pan.SetAttributes(map[string]interface{}{"world": world})
 
-	// parse the body of the request
-	body := &api.SetRequest{}
-	err := readJson(r, body)
+	err := s.svc.authorize(ctx, auth.VerbGet, world, kindWorld)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.quotaUsage(ctx, world, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) exportWorld(w http.ResponseWriter, r *http.Request) {
+	// nb. as with bulkSet an export may take as long as it needs (see Service.exportWorld)
+	ctx := r.Context()
+
+	pan := log.NewSpan(ctx, "api.exportWorld")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	resp := &api.ErrorResponse{}
+
+	vars := mux.Vars(r)
+	world, ok := vars["world"]
+	if !ok || world == "" {
+		pan.Err(fmt.Errorf("world id invalid"))
+		resp.Code = http.StatusBadRequest
+		resp.Message = "invalid world"
+		s.writeResp(w, http.StatusBadRequest, &struct{ Error *api.ErrorResponse }{resp})
+		return
+	}
+	pan.SetAttributes(map[string]interface{}{"world": world})
+
+	err := s.svc.authorize(ctx, auth.VerbGet, world, auth.Any)
 	if err != nil {
 		pan.Err(err)
+		resp.Code = errorCodeHTTP(err)
+		resp.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), &struct{ Error *api.ErrorResponse }{resp})
+		return
+	}
+
+	stream := &streamWriter{w: w, contentType: "application/x-tar", rc: http.NewResponseController(w), timeout: s.cfg.TimeoutWrite}
+	err = s.svc.exportWorld(ctx, world, stream)
+	if err != nil && !stream.started {
+		pan.Err(err)
+		resp.Code = errorCodeHTTP(err)
+		resp.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), &struct{ Error *api.ErrorResponse }{resp})
+		return
+	} else if err != nil {
+		// we've already sent the headers, so the archive ends with the error (see api.ArchiveEnd)
+		pan.Err(err)
+		s.log.Error().Str("world", world).Err(err).Msg("failed to export world")
+	}
+	return
+}
+
+func (s *Server) importWorld(w http.ResponseWriter, r *http.Request) {
+	// nb. as with bulkSet an import may take as long as it needs (see Service.importWorld)
+	ctx := r.Context()
+
+	pan := log.NewSpan(ctx, "api.importWorld")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	resp := &api.ImportResponse{Error: &api.ErrorResponse{}}
+	defer r.Body.Close()
+
+	vars := mux.Vars(r)
+	world, ok := vars["world"]
+	if !ok || !kind.IsValidId(kindWorld, world) {
+		pan.Err(fmt.Errorf("world id invalid"))
 		resp.Error.Code = http.StatusBadRequest
-		resp.Error.Message = "invalid request json"
+		resp.Error.Message = "invalid world"
 		s.writeResp(w, http.StatusBadRequest, resp)
 		return
 	}
+	pan.SetAttributes(map[string]interface{}{"world": world})
 
-	// validate request Fields
-	err = kind.Validate(k, body)
+	err := s.svc.authorize(ctx, auth.VerbSet, world, auth.Any)
 	if err != nil {
 		pan.Err(err)
-		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Code = errorCodeHTTP(err)
 		resp.Error.Message = err.Error()
-		s.writeResp(w, http.StatusBadRequest, resp)
+		s.writeResp(w, errorCodeHTTP(err), resp)
 		return
 	}
 
-	err = s.svc.setKind(ctx, k, body, resp)
+	rc := http.NewResponseController(w)
+	err = s.svc.importWorld(ctx, world, &deadlineReader{r: r.Body, rc: rc, timeout: s.cfg.TimeoutRead}, resp)
+	rc.SetWriteDeadline(time.Now().Add(s.cfg.TimeoutWrite)) // nb. not every writer supports this
 	if err != nil {
 		pan.Err(err)
 		resp.Error.Code = errorCodeHTTP(err)
@@ -414,31 +595,18 @@ func (s *Server) setKind(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
-func (s *Server) delKind(w http.ResponseWriter, r *http.Request) {
+func (s *Server) forkWorld(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutWrite)
 	defer cancel()
 
-	pan := log.NewSpan(ctx, "api.delKind")
+	pan := log.NewSpan(ctx, "api.forkWorld")
 	ctx = pan.Context // make sure the root span is in the context
 	defer pan.End()
 
-	resp := &api.DeleteResponse{Error: &api.ErrorResponse{}}
-
-	// read out the kind
-	vars := mux.Vars(r)
-	k, ok := vars["kind"]
-	if !ok || !kind.IsValid(k) {
-		pan.Err(fmt.Errorf("kind %s not found", k))
-		resp.Error.Code = http.StatusNotFound
-		resp.Error.Message = "not found"
-		s.writeResp(w, http.StatusNotFound, resp)
-		return
-	}
-	pan.SetAttributes(map[string]interface{}{"kind": k})
+	resp := &api.ForkResponse{Error: &api.ErrorResponse{}}
+	req := &api.ForkRequest{}
 
-	// parse the body of the request
-	body := &api.DeleteRequest{}
-	err := readJson(r, body)
+	err := readJson(r, req)
 	if err != nil {
 		pan.Err(err)
 		resp.Error.Code = http.StatusBadRequest
@@ -447,19 +615,38 @@ func (s *Server) delKind(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// validate request Fields
-	err = kind.Validate(k, body)
-	if err != nil {
-		pan.Err(err)
+	vars := mux.Vars(r)
+	world, ok := vars["world"]
+	if !ok || world == "" {
+		pan.Err(fmt.Errorf("world id invalid"))
 		resp.Error.Code = http.StatusBadRequest
-		resp.Error.Message = err.Error()
+		resp.Error.Message = "invalid world"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+	if !kind.IsValidId(kindWorld, req.To) {
+		pan.Err(fmt.Errorf("fork world id invalid"))
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid world to fork to"
 		s.writeResp(w, http.StatusBadRequest, resp)
 		return
 	}
+	pan.SetAttributes(map[string]interface{}{"world": world, "to": req.To})
 
-	pan.SetAttributes(map[string]interface{}{"ids": len(body.Ids), "world": body.World})
+	// a fork reads all of one world & writes all of another
+	err = s.svc.authorize(ctx, auth.VerbGet, world, auth.Any)
+	if err == nil {
+		err = s.svc.authorize(ctx, auth.VerbSet, req.To, auth.Any)
+	}
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
 
-	err = s.svc.deleteKind(ctx, k, body, resp)
+	err = s.svc.forkWorld(ctx, world, req, resp)
 	if err != nil {
 		pan.Err(err)
 		resp.Error.Code = errorCodeHTTP(err)
@@ -472,26 +659,760 @@ func (s *Server) delKind(w http.ResponseWriter, r *http.Request) {
 	return
 }
 
-func (s *Server) health(w http.ResponseWriter, r *http.Request) {
-	if s.shuttingDown {
-		w.WriteHeader(http.StatusServiceUnavailable)
-	} else {
-		w.WriteHeader(http.StatusOK)
-	}
-}
+func (s *Server) bulkSet(w http.ResponseWriter, r *http.Request) {
+	// nb. a bulk write may take as long as it needs, each chunk of it has a deadline of its own
+	// (see Service.bulkWrite) & the connection deadlines are pushed forward as we go
+	ctx := r.Context()
 
-func (s *Server) Stop() {
-	s.shuttingDown = true
-	s.svc.Shutdown()
-	s.srv.Shutdown(context.Background())
-}
+	pan := log.NewSpan(ctx, "api.bulkSet")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
 
-func (s *Server) Serve(port int) error {
-	s.srv = &http.Server{
-		Addr:         fmt.Sprintf(":%d", port),
-		Handler:      handlers.CompressHandler(s.router),
-		ReadTimeout:  s.cfg.TimeoutRead,
-		WriteTimeout: s.cfg.TimeoutWrite,
-	}
-	return s.srv.ListenAndServe()
+	resp := &api.ErrorResponse{}
+	defer r.Body.Close()
+
+	world := r.URL.Query().Get("world")
+	k := r.URL.Query().Get("kind")
+	if k != "" && !kind.IsValid(k) {
+		pan.Err(fmt.Errorf("kind %s not found", k))
+		resp.Code = http.StatusNotFound
+		resp.Message = "not found"
+		s.writeResp(w, http.StatusNotFound, &struct{ Error *api.ErrorResponse }{resp})
+		return
+	}
+	if world != "" && !kind.IsValidId(kindWorld, world) {
+		pan.Err(fmt.Errorf("world id invalid"))
+		resp.Code = http.StatusBadRequest
+		resp.Message = "invalid world"
+		s.writeResp(w, http.StatusBadRequest, &struct{ Error *api.ErrorResponse }{resp})
+		return
+	}
+	pan.SetAttributes(map[string]interface{}{"world": world, "kind": k})
+
+	// we write results while still reading the body, which HTTP/1 needs to be told about
+	rc := http.NewResponseController(w)
+	err := rc.EnableFullDuplex()
+	if err != nil {
+		s.log.Debug().Err(err).Msg("full duplex not enabled")
+	}
+
+	stream := &streamWriter{w: w, contentType: "application/x-ndjson", rc: rc, timeout: s.cfg.TimeoutWrite}
+	body := &deadlineReader{r: r.Body, rc: rc, timeout: s.cfg.TimeoutRead}
+	enc := json.NewEncoder(stream)
+	err = s.svc.bulkSet(ctx, world, k, body, func(res *api.BulkResult) error {
+		err := enc.Encode(res)
+		if err != nil {
+			return err
+		}
+		return rc.Flush()
+	})
+	if err != nil && !stream.started {
+		pan.Err(err)
+		resp.Code = errorCodeHTTP(err)
+		resp.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), &struct{ Error *api.ErrorResponse }{resp})
+		return
+	} else if err != nil {
+		// we've already sent the headers, so we report the error as a final result
+		pan.Err(err)
+		s.log.Error().Str("world", world).Err(err).Msg("failed bulk write")
+		enc.Encode(&api.BulkResult{Error: &api.ErrorResponse{Code: errorCodeHTTP(err), Message: err.Error()}})
+	}
+	return
+}
+
+// relationVars reads out the world & relation from the URL
+func relationVars(r *http.Request) (string, db.Relation, error) {
+	vars := mux.Vars(r)
+	world, ok := vars["world"]
+	if !ok || world == "" {
+		return "", "", fmt.Errorf("%w world id invalid", ErrInvalid)
+	}
+	rel, err := db.ParseRelation(vars["relation"])
+	return world, rel, err
+}
+
+func (s *Server) getTuples(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutRead)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.getTuples")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	req := &api.GetTuplesRequest{}
+	resp := &api.GetTuplesResponse{Error: &api.ErrorResponse{}}
+
+	world, rel, err := relationVars(r)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	err = readJson(r, req)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid request json"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	err = kind.Validate("", req)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	pan.SetAttributes(map[string]interface{}{"world": world, "relation": string(rel), "subject": req.Subject, "object": req.Object, "tick": req.Tick})
+
+	err = s.svc.authorize(ctx, auth.VerbGet, world, kindRelation)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.getTuples(ctx, world, rel, req, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) setTuples(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutWrite)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.setTuples")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	req := &api.SetTuplesRequest{}
+	resp := &api.SetTuplesResponse{Error: &api.ErrorResponse{}}
+
+	world, rel, err := relationVars(r)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	err = readJson(r, req)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid request json"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	err = kind.Validate("", req)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	pan.SetAttributes(map[string]interface{}{"world": world, "relation": string(rel), "data": len(req.Data)})
+
+	err = s.svc.authorize(ctx, auth.VerbSet, world, kindRelation)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.setTuples(ctx, world, rel, req, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) addModifiers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutWrite)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.addModifiers")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	req := &api.AddModifiersRequest{}
+	resp := &api.AddModifiersResponse{Error: &api.ErrorResponse{}}
+
+	world, rel, err := relationVars(r)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	err = readJson(r, req)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid request json"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	err = kind.Validate("", req)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	pan.SetAttributes(map[string]interface{}{"world": world, "relation": string(rel), "data": len(req.Data)})
+
+	err = s.svc.authorize(ctx, auth.VerbSet, world, kindRelation)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.addModifiers(ctx, world, rel, req, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+// nb. technically dictating our reply based on a GET body is considered anti-html best practice
+// but opensearch / elasticsearch do this because it makes more sense than forcing users to use
+// a POST to get data OR forcing a boatload of query params .. so .. eh.
+func (s *Server) getKind(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutRead)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.Get")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	resp := &api.GetResponse{Error: &api.ErrorResponse{}}
+
+	// read out the kind
+	vars := mux.Vars(r)
+	k, ok := vars["kind"]
+	if !ok || !kind.IsValid(k) {
+		pan.Err(fmt.Errorf("kind %s not found", k))
+		resp.Error.Code = http.StatusNotFound
+		resp.Error.Message = "not found"
+		s.writeResp(w, http.StatusNotFound, resp)
+		return
+	}
+	pan.SetAttributes(map[string]interface{}{"kind": k})
+
+	// parse the body of the request
+	body := &api.GetRequest{}
+	err := readJson(r, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid request json"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	// we could just ignore this, but it might confuse a caller if we ignore inputs
+	if len(body.Ids) > 0 && (len(body.Labels) > 0 || body.Selector != "") {
+		pan.Err(fmt.Errorf("cannot specify both IDs and labels in get"))
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "cannot specify both IDs and labels in get"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+	if body.Limit <= 0 {
+		body.Limit = 100 // set default if user doesn't specify
+	}
+
+	// set some attributes for the span
+	pan.SetAttributes(map[string]interface{}{
+		"ids":      len(body.Ids),
+		"limit":    body.Limit,
+		"offset":   body.Offset,
+		"labels":   len(body.Labels),
+		"selector": body.Selector,
+		"world":    body.World,
+	})
+
+	// validate the GetRequest Fields
+	err = kind.Validate(k, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	err = s.svc.authorizeIds(ctx, auth.VerbGet, body.World, k, body.Ids)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.getKind(ctx, k, body, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	// a get of a single object can be cached by its Etag
+	if len(body.Ids) == 1 && len(resp.Data) == 1 {
+		etag := objectEtag(resp.Data[0])
+		if noneMatch(r.Header.Get("If-None-Match"), etag) {
+			writeNotModified(w, etag)
+			return
+		} else if etag != "" {
+			w.Header().Set("ETag", quoteEtag(etag))
+		}
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) setKind(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutWrite)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.setKind")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	resp := &api.SetResponse{Error: &api.ErrorResponse{}}
+
+	// read out the kind
+	vars := mux.Vars(r)
+	k, ok := vars["kind"]
+	if !ok || !kind.IsValid(k) {
+		pan.Err(fmt.Errorf("kind %s not found", k))
+		resp.Error.Code = http.StatusNotFound
+		resp.Error.Message = "not found"
+		s.writeResp(w, http.StatusNotFound, resp)
+		return
+	}
+	pan.SetAttributes(map[string]interface{}{"kind": k})
+
+	// parse the body of the request
+	body := &api.SetRequest{}
+	err := readJson(r, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid request json"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	// validate request Fields
+	err = kind.Validate(k, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	err = s.svc.authorizeIds(ctx, auth.VerbSet, body.World, k, objectIds(body.Data))
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	match, err := ifMatch(r.Header.Get("If-Match"))
+	if err == nil && match != "" {
+		err = s.svc.conditionalSet(ctx, k, body, match)
+	}
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.setKind(ctx, k, body, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	// a write of a single object returns its new Etag (as a get would)
+	if len(resp.Etags) == 1 {
+		for _, etag := range resp.Etags {
+			w.Header().Set("ETag", quoteEtag(etag))
+		}
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) patchKind(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutWrite)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.patchKind")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	resp := &api.PatchResponse{Error: &api.ErrorResponse{}}
+
+	// read out the kind
+	vars := mux.Vars(r)
+	k, ok := vars["kind"]
+	if !ok || !kind.IsValid(k) {
+		pan.Err(fmt.Errorf("kind %s not found", k))
+		resp.Error.Code = http.StatusNotFound
+		resp.Error.Message = "not found"
+		s.writeResp(w, http.StatusNotFound, resp)
+		return
+	}
+	pan.SetAttributes(map[string]interface{}{"kind": k})
+
+	// parse the body of the request
+	body := &api.PatchRequest{}
+	err := readJson(r, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid request json"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	// validate request Fields
+	err = kind.Validate(k, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	pan.SetAttributes(map[string]interface{}{"data": len(body.Data), "world": body.World})
+
+	err = s.svc.authorizeIds(ctx, auth.VerbSet, body.World, k, patchIds(body.Data))
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.patchKind(ctx, k, body, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) historyKind(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutRead)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.historyKind")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	resp := &api.HistoryResponse{Error: &api.ErrorResponse{}}
+
+	// read out the kind
+	vars := mux.Vars(r)
+	k, ok := vars["kind"]
+	if !ok || !kind.IsValid(k) {
+		pan.Err(fmt.Errorf("kind %s not found", k))
+		resp.Error.Code = http.StatusNotFound
+		resp.Error.Message = "not found"
+		s.writeResp(w, http.StatusNotFound, resp)
+		return
+	}
+	pan.SetAttributes(map[string]interface{}{"kind": k})
+
+	// parse the body of the request
+	body := &api.HistoryRequest{}
+	err := readJson(r, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid request json"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	// validate request Fields
+	err = kind.Validate(k, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	pan.SetAttributes(map[string]interface{}{"id": body.Id, "world": body.World})
+
+	err = s.svc.authorizeIds(ctx, auth.VerbGet, body.World, k, []string{body.Id})
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.historyKind(ctx, k, body, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) rollbackKind(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutWrite)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.rollbackKind")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	resp := &api.RollbackResponse{Error: &api.ErrorResponse{}}
+
+	// read out the kind
+	vars := mux.Vars(r)
+	k, ok := vars["kind"]
+	if !ok || !kind.IsValid(k) {
+		pan.Err(fmt.Errorf("kind %s not found", k))
+		resp.Error.Code = http.StatusNotFound
+		resp.Error.Message = "not found"
+		s.writeResp(w, http.StatusNotFound, resp)
+		return
+	}
+	pan.SetAttributes(map[string]interface{}{"kind": k})
+
+	// parse the body of the request
+	body := &api.RollbackRequest{}
+	err := readJson(r, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid request json"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	// validate request Fields
+	err = kind.Validate(k, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	pan.SetAttributes(map[string]interface{}{"id": body.Id, "world": body.World, "to_etag": body.ToEtag})
+
+	err = s.svc.authorizeIds(ctx, auth.VerbSet, body.World, k, []string{body.Id})
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.rollbackKind(ctx, k, body, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) delKind(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.TimeoutWrite)
+	defer cancel()
+
+	pan := log.NewSpan(ctx, "api.delKind")
+	ctx = pan.Context // make sure the root span is in the context
+	defer pan.End()
+
+	resp := &api.DeleteResponse{Error: &api.ErrorResponse{}}
+
+	// read out the kind
+	vars := mux.Vars(r)
+	k, ok := vars["kind"]
+	if !ok || !kind.IsValid(k) {
+		pan.Err(fmt.Errorf("kind %s not found", k))
+		resp.Error.Code = http.StatusNotFound
+		resp.Error.Message = "not found"
+		s.writeResp(w, http.StatusNotFound, resp)
+		return
+	}
+	pan.SetAttributes(map[string]interface{}{"kind": k})
+
+	// parse the body of the request
+	body := &api.DeleteRequest{}
+	err := readJson(r, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = "invalid request json"
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	// validate request Fields
+	err = kind.Validate(k, body)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = http.StatusBadRequest
+		resp.Error.Message = err.Error()
+		s.writeResp(w, http.StatusBadRequest, resp)
+		return
+	}
+
+	pan.SetAttributes(map[string]interface{}{"ids": len(body.Ids), "world": body.World})
+
+	err = s.svc.authorizeIds(ctx, auth.VerbDelete, body.World, k, body.Ids)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	match, err := ifMatch(r.Header.Get("If-Match"))
+	if err == nil && match != "" {
+		err = s.svc.conditionalDelete(ctx, k, body, match)
+	}
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	err = s.svc.deleteKind(ctx, k, body, resp)
+	if err != nil {
+		pan.Err(err)
+		resp.Error.Code = errorCodeHTTP(err)
+		resp.Error.Message = err.Error()
+		s.writeResp(w, errorCodeHTTP(err), resp)
+		return
+	}
+
+	s.writeResp(w, http.StatusOK, resp)
+	return
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	if s.shuttingDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s *Server) Stop() {
+	s.shuttingDown = true
+	s.svc.Shutdown()
+	s.srv.Shutdown(context.Background())
+	s.grpc.Stop()
+}
+
+func (s *Server) Serve(port int) error {
+	s.srv = &http.Server{
+		Addr:         fmt.Sprintf(":%d", port),
+		Handler:      handlers.CompressHandler(s.router),
+		ReadTimeout:  s.cfg.TimeoutRead,
+		WriteTimeout: s.cfg.TimeoutWrite,
+	}
+	return s.srv.ListenAndServe()
+}
+
+// ServeGRPC serves the gRPC API (see pkg/proto/v1), which shares the service of the HTTP API.
+func (s *Server) ServeGRPC(port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	return s.grpc.Serve(lis)
 }
@@ -2,11 +2,16 @@ package api
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"github.com/voidshard/faction/internal/auth"
 	"github.com/voidshard/faction/internal/db"
 	"github.com/voidshard/faction/internal/queue"
 	"github.com/voidshard/faction/internal/search"
@@ -14,9 +19,21 @@ import (
 	"github.com/voidshard/faction/pkg/structs/api"
 	v1 "github.com/voidshard/faction/pkg/structs/v1"
 	"github.com/voidshard/faction/pkg/util/log"
+	"github.com/voidshard/faction/pkg/util/selector"
 	"github.com/voidshard/faction/pkg/util/uuid"
 )
 
+const (
+	kindWorld         = "world"
+	kindWorldDeletion = "worlddeletion"
+
+	// deleteWorldPageSize is how many objects we read at once when deleting a world
+	deleteWorldPageSize int64 = 1000
+
+	// changesPage is how many changes we read at once when replaying changes to a watcher
+	changesPage int64 = 1000
+)
+
 // Service handles the business logic of the API.
 type Service struct {
 	cfg *Config
@@ -26,18 +43,19 @@ type Service struct {
 	qu *Queue
 	sb search.Search
 
-	publisher chan (*publishWork)
+	// relayWake nudges a relay to publish events after a write, rather than waiting to poll
+	relayWake chan struct{}
+	relayStop chan struct{}
 
 	tickManager *tickManager
 
-	shutdownLock       sync.RWMutex
-	shuttingDown       bool
-	shutdownPublishers sync.WaitGroup
-}
+	// garbage is a (shared) subscription to events, from which we collect dependents of
+	// deleted objects
+	garbage queue.Subscription
 
-type publishWork struct {
-	ctx    context.Context
-	events []*v1.Event
+	shutdownLock   sync.RWMutex
+	shuttingDown   bool
+	shutdownRelays sync.WaitGroup
 }
 
 func newService(cfg *Config, db db.Database, qu queue.Queue, sb search.Search) (*Service, error) {
@@ -52,22 +70,30 @@ func newService(cfg *Config, db db.Database, qu queue.Queue, sb search.Search) (
 	}
 	go tm.Run()
 
+	garbage, err := apiQueue.SubscribeEvent(&v1.Event{}, "internal.garbage-collector", true)
+	if err != nil {
+		return nil, err
+	}
+
 	me := &Service{
-		cfg:                cfg,
-		log:                log.Sublogger("api.service", map[string]interface{}{}),
-		db:                 db,
-		qu:                 apiQueue,
-		sb:                 sb,
-		publisher:          make(chan (*publishWork)),
-		tickManager:        tm,
-		shutdownLock:       sync.RWMutex{},
-		shutdownPublishers: sync.WaitGroup{},
+		cfg:            cfg,
+		log:            log.Sublogger("api.service", map[string]interface{}{}),
+		db:             db,
+		qu:             apiQueue,
+		sb:             sb,
+		relayWake:      make(chan struct{}, 1),
+		relayStop:      make(chan struct{}),
+		tickManager:    tm,
+		garbage:        garbage,
+		shutdownLock:   sync.RWMutex{},
+		shutdownRelays: sync.WaitGroup{},
 	}
 
 	for i := 0; i < cfg.PublishRoutines; i++ {
-		me.shutdownPublishers.Add(1)
-		go me.publishEvents()
+		me.shutdownRelays.Add(1)
+		go me.relayEvents(fmt.Sprintf("relay.%s", uuid.New()))
 	}
+	go me.collectGarbage()
 
 	return me, nil
 }
@@ -81,36 +107,92 @@ func (s *Service) Shutdown() {
 	s.shutdownLock.Lock()
 	defer s.shutdownLock.Unlock()
 
-	close(s.publisher)
-	s.shutdownPublishers.Wait()
+	close(s.relayStop)
+	s.shutdownRelays.Wait()
+	s.garbage.Close()
 	s.tickManager.Shutdown()
 }
 
-func (s *Service) publishEvents() {
-	defer s.shutdownPublishers.Done()
+// relayEvents publishes events from the outbox until we shutdown. Events are written to the
+// outbox along with the change that raised them, so they are published even if the server
+// that made the change dies before doing so.
+func (s *Service) relayEvents(owner string) {
+	defer s.shutdownRelays.Done()
+	l := log.Sublogger("api.relayEvents", map[string]interface{}{"owner": owner})
 
-	for work := range s.publisher {
-		if work.events == nil || len(work.events) == 0 {
+	for {
+		select {
+		case <-s.relayStop:
 			return
+		case <-s.relayWake:
+		case <-time.After(defaultRelayInterval):
 		}
-		l := log.Sublogger("api.publishEvents", map[string]interface{}{"world": work.events[0].World, "kind": work.events[0].Kind})
-		for _, evt := range work.events {
-			for {
-				// if the queue is down we will keep retrying. Note that this
-				// could result in our write operations blocking on pushing to
-				// the publisher channel, this is intentional.
-				err := s.qu.PublishEvent(work.ctx, evt)
-				l.Debug().Err(err).Str("id", evt.Id).Str("controller", evt.Controller).Msg("publish event")
-				if err == nil {
-					break
-				}
-				time.Sleep(1 * time.Second)
+
+		for {
+			count, err := s.relayBatch(owner)
+			if err != nil {
+				// if the db or queue are down we'll try again on the next poll, anything
+				// we've claimed is retried by us (or another relay once the lease expires)
+				l.Warn().Err(err).Msg("failed to relay events")
+				break
+			}
+			if count < relayBatchSize {
+				break
 			}
 		}
 	}
 }
 
-func (s *Service) subscribeToEvents(req *api.StreamEvents) (<-chan *v1.Event, chan<- bool, error) {
+// relayBatch claims, publishes & removes a batch of events from the outbox
+func (s *Service) relayBatch(owner string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), relayLease)
+	defer cancel()
+
+	claimed, err := s.db.ClaimEvents(ctx, owner, relayLease, relayBatchSize)
+	if err != nil || len(claimed) == 0 {
+		return 0, err
+	}
+
+	published := []string{}
+	for _, evt := range claimed {
+		err = s.qu.PublishEvent(ctx, evt.Event)
+		s.log.Debug().Err(err).Str("id", evt.Event.Id).Str("controller", evt.Event.Controller).Msg("publish event")
+		if err != nil {
+			break
+		}
+		published = append(published, evt.Id)
+	}
+
+	rerr := s.db.RemoveEvents(ctx, published)
+	if err == nil {
+		err = rerr
+	}
+	return len(claimed), err
+}
+
+// wakeRelay prompts a relay to publish newly written events
+func (s *Service) wakeRelay() {
+	select {
+	case s.relayWake <- struct{}{}:
+	default: // a relay is already due to wake
+	}
+}
+
+func (s *Service) subscribeToEvents(ctx context.Context, req *api.StreamEvents) (<-chan *v1.Event, chan<- bool, error) {
+	sel, err := labelSelector(nil, req.Selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	if req.FromSequence > 0 && req.World == "" && !kind.IsGlobal(req.Kind) {
+		// nb. sequences are per world
+		return nil, nil, fmt.Errorf("%w from sequence requires a world", ErrInvalid)
+	}
+
+	release, err := s.addWatcher(ctx, req.World, req.Kind)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	durable := true
 	if req.Queue == "" {
 		durable = false
@@ -122,16 +204,44 @@ func (s *Service) subscribeToEvents(req *api.StreamEvents) (<-chan *v1.Event, ch
 		durable,
 	)
 	if err != nil {
+		release()
 		return nil, nil, err
 	}
 
+	// nb. we read changes having subscribed, so that nothing is missed in between. Live events
+	// up to the highest sequence we replay have been sent already.
+	replay := []*v1.Event{}
+	sent := req.FromSequence
+	if req.FromSequence > 0 {
+		replay, err = s.changesSince(ctx, req, sel)
+		if err != nil {
+			sub.Close()
+			release()
+			return nil, nil, err
+		}
+		for _, evt := range replay {
+			sent = max(sent, evt.Sequence)
+		}
+	}
+
 	events := make(chan *v1.Event)
 	kill := make(chan bool)
 
 	go func() {
-		attrs := map[string]interface{}{"world": req.World, "kind": req.Kind, "id": req.Id, "controller": req.Controller, "queue": req.Queue, "durable": durable}
+		defer release()
+
+		attrs := map[string]interface{}{"world": req.World, "kind": req.Kind, "id": req.Id, "controller": req.Controller, "queue": req.Queue, "durable": durable, "from_sequence": req.FromSequence}
 		l := log.Sublogger("api.subscribeToEvents", attrs)
 
+		for _, evt := range replay {
+			select {
+			case <-kill:
+				sub.Close()
+				return
+			case events <- evt:
+			}
+		}
+
 		for {
 			select {
 			case <-kill:
@@ -156,6 +266,14 @@ func (s *Service) subscribeToEvents(req *api.StreamEvents) (<-chan *v1.Event, ch
 					continue
 				}
 
+				if !sel.Matches(evt.Labels) || (req.FromSequence > 0 && evt.World == req.World && evt.Sequence <= sent) {
+					if durable {
+						msg.Ack() // not for us, or already sent
+					}
+					pan.End()
+					continue
+				}
+
 				if durable {
 					evt.AckId = s.qu.NewAckId(msg, evt)
 				}
@@ -169,7 +287,50 @@ func (s *Service) subscribeToEvents(req *api.StreamEvents) (<-chan *v1.Event, ch
 	return events, kill, nil
 }
 
-func (s *Service) ackEvent(ackId string) error {
+// changesSince returns the recent changes of a world after req.FromSequence that match the
+// subscription.
+func (s *Service) changesSince(ctx context.Context, req *api.StreamEvents, sel selector.Selector) ([]*v1.Event, error) {
+	pan := log.NewSpan(ctx, "service.changesSince", map[string]interface{}{"world": req.World, "from_sequence": req.FromSequence})
+	defer pan.End()
+
+	found := []*v1.Event{}
+	after := req.FromSequence
+	for {
+		changes, err := s.db.Changes(ctx, req.World, after, changesPage)
+		if err != nil {
+			return nil, pan.Err(err)
+		}
+		for _, evt := range changes {
+			after = evt.Sequence
+			if matchesEvent(req, evt) && sel.Matches(evt.Labels) {
+				found = append(found, evt)
+			}
+		}
+		if int64(len(changes)) < changesPage {
+			pan.SetAttributes(map[string]interface{}{"replayed": len(found)})
+			return found, nil
+		}
+	}
+}
+
+// matchesEvent returns if an event matches the filters of a subscription, as the queue does
+func matchesEvent(req *api.StreamEvents, evt *v1.Event) bool {
+	return (req.Kind == "" || req.Kind == evt.Kind) &&
+		(req.Id == "" || req.Id == evt.Id) &&
+		(req.Controller == "" || req.Controller == evt.Controller)
+}
+
+// ackEvent acknowledges an event sent to a watcher, who must be allowed to watch the world &
+// kind of the event.
+func (s *Service) ackEvent(ctx context.Context, ackId string) error {
+	world, k, err := ackTarget(ackId)
+	if err != nil {
+		return fmt.Errorf("%w %v", ErrInvalid, err)
+	}
+	err = s.authorize(ctx, auth.VerbWatch, world, k)
+	if err != nil {
+		return err
+	}
 	return s.qu.Ack(ackId)
 }
 
@@ -205,13 +366,48 @@ func (s *Service) deferEvent(ctx context.Context, req *api.DeferEventRequest, rs
 	rsp.ToTick = toTick
 	pan.SetAttributes(map[string]interface{}{"world": req.World, "kind": req.Kind, "controller": req.Controller, "id": req.Id, "to_tick": toTick})
 
-	// queue the event
-	return s.qu.DeferEvent(ctx, &v1.Event{
+	err = s.checkDeferredQuota(ctx, req.World, toTick)
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	// note the tick so that the queue can be found if the world is deleted
+	err = s.tickManager.recordDeferred(ctx, req.World, toTick)
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	// carry the object labels so that the event passes subscribers' selectors
+	space := req.World
+	if kind.IsGlobal(req.Kind) {
+		space = ""
+	}
+	found := []map[string]interface{}{}
+	err = s.db.Get(ctx, space, req.Kind, []string{req.Id}, &found)
+	if err != nil {
+		return pan.Err(err)
+	}
+	labels := map[string]string{}
+	if len(found) > 0 {
+		labels = labelsOf(found[0])
+	}
+
+	evt := &v1.Event{
 		World:      req.World,
 		Kind:       req.Kind,
 		Controller: req.Controller,
 		Id:         req.Id,
-	}, toTick)
+		Labels:     labels,
+	}
+
+	// note the event itself so that it can be copied if the world is forked
+	err = s.db.AddDeferredEvent(ctx, req.World, toTick, evt)
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	// queue the event
+	return s.qu.DeferEvent(ctx, evt, toTick)
 }
 
 func (s *Service) searchKind(ctx context.Context, world string, req *api.SearchRequest, rsp *api.SearchResponse) error {
@@ -244,6 +440,56 @@ func (s *Service) searchKind(ctx context.Context, world string, req *api.SearchR
 	return nil
 }
 
+func (s *Service) setTuples(ctx context.Context, world string, r db.Relation, req *api.SetTuplesRequest, rsp *api.SetTuplesResponse) error {
+	pan := log.NewSpan(ctx, "service.setTuples", map[string]interface{}{"world": world, "relation": string(r), "data": len(req.Data)})
+	defer pan.End()
+
+	s.shutdownLock.RLock()
+	defer s.shutdownLock.RUnlock()
+	if s.shuttingDown {
+		return ErrShuttingDown
+	}
+
+	return pan.Err(s.db.SetTuples(ctx, world, r, req.Data))
+}
+
+func (s *Service) addModifiers(ctx context.Context, world string, r db.Relation, req *api.AddModifiersRequest, rsp *api.AddModifiersResponse) error {
+	pan := log.NewSpan(ctx, "service.addModifiers", map[string]interface{}{"world": world, "relation": string(r), "data": len(req.Data)})
+	defer pan.End()
+
+	s.shutdownLock.RLock()
+	defer s.shutdownLock.RUnlock()
+	if s.shuttingDown {
+		return ErrShuttingDown
+	}
+
+	return pan.Err(s.db.AddModifiers(ctx, world, r, req.Data))
+}
+
+func (s *Service) getTuples(ctx context.Context, world string, r db.Relation, req *api.GetTuplesRequest, rsp *api.GetTuplesResponse) error {
+	pan := log.NewSpan(ctx, "service.getTuples", map[string]interface{}{"world": world, "relation": string(r), "subject": req.Subject, "object": req.Object})
+	defer pan.End()
+
+	// if we're not told a tick, we compute values as of the current world tick
+	tick := req.Tick
+	if tick == 0 {
+		var err error
+		tick, err = s.tickManager.Tick(world)
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+	pan.SetAttributes(map[string]interface{}{"tick": tick})
+
+	tuples, err := s.db.Tuples(ctx, world, r, req.Subject, req.Object, tick)
+	if err != nil {
+		return pan.Err(err)
+	}
+	rsp.Data = tuples
+
+	return nil
+}
+
 func (s *Service) getKind(ctx context.Context, k string, req *api.GetRequest, rsp *api.GetResponse) error {
 	pan := log.NewSpan(ctx, "service.getKind", map[string]interface{}{"world": req.World, "kind": k})
 
@@ -253,6 +499,17 @@ func (s *Service) getKind(ctx context.Context, k string, req *api.GetRequest, rs
 		req.World = ""
 	}
 
+	sel, err := labelSelector(req.Labels, req.Selector)
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	// nb. read before the objects, so that objects reflect at least this sequence
+	rsp.Sequence, err = s.db.Sequence(ctx, req.World)
+	if err != nil {
+		return pan.Err(err)
+	}
+
 	// either get by ID(s) or list w/ labels + pagination
 	result := []map[string]interface{}{}
 	if len(req.Ids) > 0 {
@@ -261,8 +518,21 @@ func (s *Service) getKind(ctx context.Context, k string, req *api.GetRequest, rs
 			pan.Err(err)
 			return err
 		}
+	} else if req.Offset > 0 {
+		if req.Token != "" {
+			return pan.Err(fmt.Errorf("%w only one of token or offset can be set", ErrInvalid))
+		}
+		err := s.db.List(ctx, req.World, k, sel, req.Limit, req.Offset, &result)
+		if err != nil {
+			pan.Err(err)
+			return err
+		}
 	} else {
-		err := s.db.List(ctx, req.World, k, req.Labels, req.Limit, req.Offset, &result)
+		after, err := decodeToken(req.Token)
+		if err != nil {
+			return pan.Err(err)
+		}
+		err = s.db.ListAfter(ctx, req.World, k, sel, after, req.Limit, &result)
 		if err != nil {
 			pan.Err(err)
 			return err
@@ -270,6 +540,12 @@ func (s *Service) getKind(ctx context.Context, k string, req *api.GetRequest, rs
 	}
 	pan.SetAttributes(map[string]interface{}{"data": len(result)})
 
+	// a full page implies there may be more to list
+	if len(req.Ids) == 0 && req.Limit > 0 && len(result) == int(req.Limit) {
+		last, _ := result[len(result)-1]["_id"].(string)
+		rsp.Token = encodeToken(last)
+	}
+
 	objects := []interface{}{}
 	for _, obj := range result {
 		objects = append(objects, obj)
@@ -293,7 +569,6 @@ func (s *Service) setKind(ctx context.Context, k string, req *api.SetRequest, rs
 		return ErrShuttingDown
 	}
 
-	events := []*v1.Event{}
 	objects := []v1.Object{}
 	for _, rawObj := range req.Data {
 		// ensure each object is valid kind
@@ -309,31 +584,377 @@ func (s *Service) setKind(ctx context.Context, k string, req *api.SetRequest, rs
 			return fmt.Errorf("object invalid: %w %v", ErrInvalid, err)
 		}
 		objects = append(objects, obj)
-		events = append(events, &v1.Event{World: req.World, Kind: k, Controller: obj.GetController(), Id: obj.GetId()})
 	}
 
 	// set some attributes for the span
 	pan.SetAttributes(map[string]interface{}{"data": len(req.Data), "world": req.World})
 
+	err := s.checkDeletionTicks(ctx, req.World, k, objects)
+	if err != nil {
+		return pan.Err(err)
+	}
+	err = s.checkReferences(ctx, req.World, objects)
+	if err != nil {
+		return pan.Err(err)
+	}
+	err = s.checkObjectQuota(ctx, req.World, k, objects)
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	result, err := s.writeObjects(ctx, req.World, k, objects)
+	if result != nil {
+		rsp.Etags = result.Written
+		rsp.Conflicts = result.Conflicts
+	}
+	return pan.Err(err)
+}
+
+// writeObjects writes validated objects of a kind to the database, indexes them (if the kind is
+// searchable) and publishes events for them.
+//
+// If some objects fail the Etag check (or are duplicates) we still index & publish those that
+// were written, the Result reports which were written and which were not.
+func (s *Service) writeObjects(ctx context.Context, world, k string, objects []v1.Object) (*db.Result, error) {
 	// write data to the database
 	etag := uuid.New()
-	_, err := s.db.Set(ctx, req.World, etag, objects)
-	if err != nil {
-		pan.Err(err)
-		return err
+	result, err := s.db.Set(ctx, world, etag, objects)
+	partial := errors.Is(err, db.ErrEtagMismatch) || errors.Is(err, db.ErrDuplicate)
+	if partial && result != nil && len(result.Written) > 0 {
+		// some objects were written; we still need to index & publish those
+		objects = writtenOnly(result, objects)
+	} else if err != nil {
+		return result, err
 	}
+
+	s.afterWrite(ctx, world, k, objects)
+
+	return result, err
+}
+
+// afterWrite records revisions of written objects of a kind, indexes them (if the kind is
+// searchable), publishes events for them and deletes any that have been finalized.
+func (s *Service) afterWrite(ctx context.Context, world, k string, objects []v1.Object) {
+	s.recordRevisions(ctx, world, k, objects)
+
 	if kind.IsSearchable(k) {
-		err = s.sb.Index(ctx, req.World, objects, false)
+		ierr := s.sb.Index(ctx, world, objects, false)
+		if ierr != nil {
+			s.log.Warn().Str("world", world).Str("kind", k).Err(ierr).Msg("failed to index")
+		}
+	}
+
+	// nb. the db records events along with the objects
+	s.wakeRelay()
+
+	s.finalize(ctx, world, k, objects)
+}
+
+// recordRevisions adds written objects to their history. Since the objects have already been
+// written we log failures here rather than failing the write.
+func (s *Service) recordRevisions(ctx context.Context, world, k string, objects []v1.Object) {
+	keep := s.tickManager.History(world)
+	if keep <= 0 {
+		keep = s.cfg.History
+	}
+
+	tick, _ := s.tickManager.Tick(world) // nb. global objects have no world tick
+	now := time.Now().UnixNano()
+
+	revisions := []*v1.Revision{}
+	for _, obj := range objects {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			s.log.Warn().Str("world", world).Str("kind", k).Str("id", obj.GetId()).Err(err).Msg("failed to encode revision")
+			continue
+		}
+		body := map[string]interface{}{}
+		err = json.Unmarshal(data, &body)
 		if err != nil {
-			s.log.Warn().Str("world", req.World).Str("kind", k).Err(err).Msg("failed to index")
+			s.log.Warn().Str("world", world).Str("kind", k).Str("id", obj.GetId()).Err(err).Msg("failed to encode revision")
+			continue
 		}
+		revisions = append(revisions, &v1.Revision{Id: obj.GetId(), Etag: obj.GetEtag(), Tick: tick, Time: now, Object: body})
+	}
+
+	err := s.db.AddRevisions(ctx, world, k, revisions, keep)
+	if err != nil {
+		s.log.Warn().Str("world", world).Str("kind", k).Err(err).Msg("failed to record revisions")
+	}
+}
+
+func (s *Service) historyKind(ctx context.Context, k string, req *api.HistoryRequest, rsp *api.HistoryResponse) error {
+	pan := log.NewSpan(ctx, "service.historyKind", map[string]interface{}{"world": req.World, "kind": k, "id": req.Id})
+	defer pan.End()
+
+	// we don't need a worldspace if it's a global kind
+	if kind.IsGlobal(k) {
+		req.World = ""
 	}
 
-	s.publisher <- &publishWork{ctx: ctx, events: events}
+	revisions, err := s.db.Revisions(ctx, req.World, k, req.Id)
+	if err != nil {
+		return pan.Err(err)
+	}
+	pan.SetAttributes(map[string]interface{}{"data": len(revisions)})
 
+	rsp.Data = revisions
 	return nil
 }
 
+// rollbackKind writes a previous revision of an object as a new write; so the rollback itself
+// is recorded in the history & controllers are informed as usual.
+func (s *Service) rollbackKind(ctx context.Context, k string, req *api.RollbackRequest, rsp *api.RollbackResponse) error {
+	pan := log.NewSpan(ctx, "service.rollbackKind", map[string]interface{}{"world": req.World, "kind": k, "id": req.Id, "to_etag": req.ToEtag})
+	defer pan.End()
+
+	// we don't need a worldspace if it's a global kind
+	if kind.IsGlobal(k) {
+		req.World = ""
+	}
+
+	s.shutdownLock.RLock()
+	defer s.shutdownLock.RUnlock()
+	if s.shuttingDown {
+		return ErrShuttingDown
+	}
+
+	revisions, err := s.db.Revisions(ctx, req.World, k, req.Id)
+	if err != nil {
+		return pan.Err(err)
+	}
+	var target *v1.Revision
+	for _, rev := range revisions {
+		if rev.Etag == req.ToEtag {
+			target = rev
+			break
+		}
+	}
+	if target == nil {
+		return pan.Err(fmt.Errorf("%w revision %s of %s %s", ErrNotFound, req.ToEtag, k, req.Id))
+	}
+
+	obj, err := kind.New(k, target.Object)
+	if err != nil {
+		return pan.Err(err)
+	}
+	obj.SetId(req.Id)
+	obj.SetEtag(req.Etag) // nb. the write is conditional on the current etag
+
+	err = kind.Validate(k, obj)
+	if err != nil {
+		return pan.Err(fmt.Errorf("object invalid: %w %v", ErrInvalid, err))
+	}
+	// nb. a revision from before the object was marked for deletion does not unmark it
+	obj.SetDeletionTick(nil)
+	err = s.checkDeletionTicks(ctx, req.World, k, []v1.Object{obj})
+	if err != nil {
+		return pan.Err(err)
+	}
+	err = s.checkReferences(ctx, req.World, []v1.Object{obj})
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	result, err := s.writeObjects(ctx, req.World, k, []v1.Object{obj})
+	if result != nil {
+		rsp.Etag = result.Written[req.Id]
+	}
+	return pan.Err(err)
+}
+
+// transaction writes objects of several kinds within a world all-or-nothing. Objects are only
+// indexed & events published once the write has been committed.
+func (s *Service) transaction(ctx context.Context, world string, req *api.TransactionRequest, rsp *api.TransactionResponse) error {
+	pan := log.NewSpan(ctx, "service.transaction", map[string]interface{}{"world": world, "data": len(req.Data)})
+	defer pan.End()
+
+	s.shutdownLock.RLock()
+	defer s.shutdownLock.RUnlock()
+	if s.shuttingDown {
+		return ErrShuttingDown
+	}
+
+	objects := []v1.Object{}
+	byKind := map[string][]v1.Object{}
+	for _, rawObj := range req.Data {
+		// the kind is read from each object
+		obj, err := kind.New("", rawObj)
+		if err != nil {
+			return pan.Err(fmt.Errorf("%w %v", ErrInvalid, err))
+		}
+		k := obj.GetKind()
+		if kind.IsGlobal(k) {
+			return pan.Err(fmt.Errorf("%w kind %s is global and cannot be written in a world transaction", ErrInvalid, k))
+		}
+		err = s.authorize(ctx, auth.VerbSet, world, k)
+		if err != nil {
+			return pan.Err(err)
+		}
+		if obj.GetWorld() == "" {
+			obj.SetWorld(world)
+		} else if obj.GetWorld() != world {
+			return pan.Err(fmt.Errorf("%w object %s is in world %s not %s", ErrInvalid, obj.GetId(), obj.GetWorld(), world))
+		}
+
+		err = kind.Validate(k, obj)
+		if err != nil {
+			return pan.Err(fmt.Errorf("object invalid: %w %v", ErrInvalid, err))
+		}
+		objects = append(objects, obj)
+		byKind[k] = append(byKind[k], obj)
+	}
+
+	for k, ofKind := range byKind {
+		err := s.checkDeletionTicks(ctx, world, k, ofKind)
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+	err := s.checkReferences(ctx, world, objects)
+	if err != nil {
+		return pan.Err(err)
+	}
+	for k, ofKind := range byKind {
+		err = s.checkObjectQuota(ctx, world, k, ofKind)
+		if err != nil {
+			return pan.Err(err)
+		}
+	}
+
+	result, err := s.db.SetAll(ctx, world, uuid.New(), objects)
+	if result != nil {
+		rsp.Etags = result.Written
+		rsp.Conflicts = result.Conflicts
+	}
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	for k, written := range byKind {
+		s.afterWrite(ctx, world, k, written)
+	}
+	return nil
+}
+
+func (s *Service) patchKind(ctx context.Context, k string, req *api.PatchRequest, rsp *api.PatchResponse) error {
+	pan := log.NewSpan(ctx, "service.patchKind", map[string]interface{}{"world": req.World, "kind": k, "data": len(req.Data)})
+	defer pan.End()
+
+	// we don't need a worldspace if it's a global kind
+	if kind.IsGlobal(k) {
+		req.World = ""
+	}
+
+	s.shutdownLock.RLock()
+	defer s.shutdownLock.RUnlock()
+	if s.shuttingDown {
+		return ErrShuttingDown
+	}
+
+	// read the current objects
+	ids := []string{}
+	for _, p := range req.Data {
+		ids = append(ids, p.Id)
+	}
+	result := []map[string]interface{}{}
+	err := s.db.Get(ctx, req.World, k, ids, &result)
+	if err != nil {
+		return pan.Err(err)
+	}
+	current := map[string]v1.Object{}
+	for _, raw := range result {
+		obj, err := kind.New(k, raw)
+		if err != nil {
+			return pan.Err(err)
+		}
+		current[obj.GetId()] = obj
+	}
+
+	// apply patches to objects that are still at the expected etag
+	conflicts := []string{}
+	objects := []v1.Object{}
+	for _, p := range req.Data {
+		obj, ok := current[p.Id]
+		if !ok {
+			return pan.Err(fmt.Errorf("%w %s %s", ErrNotFound, k, p.Id))
+		}
+		if obj.GetEtag() != p.Etag {
+			conflicts = append(conflicts, p.Id)
+			continue
+		}
+
+		patched, err := applyPatch(k, obj, p)
+		if err != nil {
+			return pan.Err(err)
+		}
+		err = keepDeletionTick(patched, obj.GetDeletionTick())
+		if err != nil {
+			return pan.Err(err)
+		}
+		objects = append(objects, patched)
+	}
+
+	rsp.Conflicts = conflicts
+	if len(objects) == 0 {
+		return pan.Err(fmt.Errorf("%w all objects have been modified", db.ErrEtagMismatch))
+	}
+
+	err = s.checkReferences(ctx, req.World, objects)
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	written, err := s.writeObjects(ctx, req.World, k, objects)
+	if written != nil {
+		rsp.Etags = written.Written
+		rsp.Conflicts = append(rsp.Conflicts, written.Conflicts...)
+	}
+	if err == nil && len(conflicts) > 0 {
+		err = db.ErrEtagMismatch
+	}
+	return pan.Err(err)
+}
+
+// applyPatch returns a new object with the given patch applied. The result keeps the identity
+// (id, etag, world) of the original object and is validated as the given kind.
+func applyPatch(k string, obj v1.Object, p *api.Patch) (v1.Object, error) {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	switch p.Type {
+	case api.PatchJSON:
+		var patch jsonpatch.Patch
+		patch, err = jsonpatch.DecodePatch(p.Patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w invalid json patch %v", ErrInvalid, err)
+		}
+		data, err = patch.Apply(original)
+	default:
+		data, err = jsonpatch.MergePatch(original, p.Patch)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w failed to apply patch to %s: %v", ErrInvalid, p.Id, err)
+	}
+
+	patched, err := kind.New(k, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w patched object invalid: %v", ErrInvalid, err)
+	}
+	patched.SetId(obj.GetId())
+	patched.SetEtag(obj.GetEtag())
+	patched.SetWorld(obj.GetWorld())
+
+	err = kind.Validate(k, patched)
+	if err != nil {
+		return nil, fmt.Errorf("object invalid: %w %v", ErrInvalid, err)
+	}
+	return patched, nil
+}
+
 func (s *Service) deleteKind(ctx context.Context, k string, req *api.DeleteRequest, rsp *api.DeleteResponse) error {
 	pan := log.NewSpan(ctx, "service.deleteKind", map[string]interface{}{"world": req.World, "kind": k})
 
@@ -342,52 +963,404 @@ func (s *Service) deleteKind(ctx context.Context, k string, req *api.DeleteReque
 		req.World = ""
 	}
 
+	if req.Etag != "" && len(req.Ids) != 1 {
+		return pan.Err(fmt.Errorf("%w a delete with an etag must be of a single object", ErrInvalid))
+	}
+
 	s.shutdownLock.RLock()
 	defer s.shutdownLock.RUnlock()
 	if s.shuttingDown {
 		return ErrShuttingDown
 	}
 
-	// get data from the DB - we need the Controller fields
+	// worlds hold everything within them, so these are deleted in the background
+	if k == kindWorld {
+		for _, id := range req.Ids {
+			err := s.deleteWorld(ctx, id, req.Etag)
+			if err != nil {
+				return pan.Err(err)
+			}
+			rsp.Pending = append(rsp.Pending, id)
+		}
+		return nil
+	}
+
+	// dependents go first if asked, otherwise they're collected after (see collectGarbage)
+	if req.Propagation == api.PropagationForeground && req.World != "" {
+		seen := map[string]bool{}
+		for _, id := range req.Ids {
+			err := s.deleteDependents(ctx, req.World, &v1.Owner{Kind: k, Id: id}, true, seen)
+			if err != nil {
+				return pan.Err(err)
+			}
+		}
+	}
+
+	return pan.Err(s.deleteObjects(ctx, req.World, k, req.Ids, req.Etag))
+}
+
+// deleteObjects deletes objects of a kind from the database (which records their events) & the
+// search index.
+//
+// Objects with finalizers are instead marked with a DeletionTick (an update) and are deleted once
+// their finalizers are removed (see finalize).
+//
+// If an etag is given objects are only deleted (or marked) if they are at that Etag.
+func (s *Service) deleteObjects(ctx context.Context, world, k string, ids []string, etag string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// get data from the DB - we need the Controller & Finalizers fields
 	result := []map[string]interface{}{}
-	err := s.db.Get(ctx, req.World, k, req.Ids, &result)
+	err := s.db.Get(ctx, world, k, ids, &result)
 	if err != nil {
-		pan.Err(err)
 		return err
 	}
+	if etag != "" && len(result) == 0 {
+		return fmt.Errorf("%w object not found", db.ErrEtagMismatch)
+	}
 
-	events := []*v1.Event{}
+	tick, _ := s.tickManager.Tick(world) // nb. global objects have no world tick
+	marking := []v1.Object{}
 	for _, item := range result {
 		id, ok := item["_id"].(string)
 		if !ok {
 			s.log.Warn().Msg("missing _id field in object")
 			continue
 		}
-		controller, ok := item["_controller"].(string)
-		if !ok {
-			s.log.Warn().Msg("missing _controller field in object")
-			controller = "default"
+
+		obj, err := kind.New(k, item)
+		if err != nil {
+			return err
+		}
+		if etag != "" && obj.GetEtag() != etag {
+			return db.ErrEtagMismatch
+		}
+		if len(obj.GetFinalizers()) > 0 {
+			if obj.GetDeletionTick() == nil {
+				obj.SetDeletionTick(&tick)
+				marking = append(marking, obj)
+			}
+			continue
 		}
 
-		// delete from db
-		err = s.db.Delete(ctx, req.World, k, id)
+		// delete from db (which records the event)
+		err = s.db.Delete(ctx, world, k, id, etag)
 		if err != nil {
 			return err
 		}
 
 		// delete from search
 		if kind.IsSearchable(k) {
-			err = s.sb.Delete(ctx, req.World, k, id)
+			err = s.sb.Delete(ctx, world, k, id)
 			if err != nil {
-				s.log.Warn().Str("world", req.World).Str("kind", k).Str("id", id).Err(err).Msg("failed to delete from search")
+				s.log.Warn().Str("world", world).Str("kind", k).Str("id", id).Err(err).Msg("failed to delete from search")
 			}
 		}
+	}
 
-		// queue event for publishing
-		events = append(events, &v1.Event{World: req.World, Kind: k, Id: id, Controller: controller})
+	s.wakeRelay()
+	if len(marking) == 0 {
+		return nil
 	}
 
-	s.publisher <- &publishWork{ctx: ctx, events: events}
+	// nb. this is conditional on the etags we read
+	_, err = s.writeObjects(ctx, world, k, marking)
+	return err
+}
+
+// finalize deletes written objects that are marked for deletion & have had their last finalizer
+// removed. Since the objects have already been written we log failures here rather than failing
+// the write.
+func (s *Service) finalize(ctx context.Context, world, k string, objects []v1.Object) {
+	ids := []string{}
+	for _, obj := range objects {
+		if obj.GetDeletionTick() != nil && len(obj.GetFinalizers()) == 0 {
+			ids = append(ids, obj.GetId())
+		}
+	}
+	err := s.deleteObjects(ctx, world, k, ids, "")
+	if err != nil {
+		s.log.Warn().Str("world", world).Str("kind", k).Err(err).Msg("failed to delete finalized objects")
+	}
+}
+
+// keepDeletionTicks copies the DeletionTick of each stored object onto the given objects of a
+// kind that update it. DeletionTick is only set by deleteObjects, so writes may leave it out but
+// cannot set or change it; the objects that try to are given an ErrInvalid (in the same order).
+func (s *Service) keepDeletionTicks(ctx context.Context, world, k string, objects []v1.Object) ([]error, error) {
+	ids := []string{}
+	for _, obj := range objects {
+		if obj.GetEtag() != "" {
+			ids = append(ids, obj.GetId())
+		}
+	}
+	stored := map[string]*uint64{}
+	if len(ids) > 0 {
+		found := []*v1.Meta{}
+		err := s.db.Get(ctx, world, k, ids, &found)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range found {
+			stored[f.Id] = f.DeletionTick
+		}
+	}
 
+	invalid := make([]error, len(objects))
+	for i, obj := range objects {
+		invalid[i] = keepDeletionTick(obj, stored[obj.GetId()])
+	}
+	return invalid, nil
+}
+
+// checkDeletionTicks is keepDeletionTicks for writes that fail as a whole
+func (s *Service) checkDeletionTicks(ctx context.Context, world, k string, objects []v1.Object) error {
+	invalid, err := s.keepDeletionTicks(ctx, world, k, objects)
+	if err != nil {
+		return err
+	}
+	for _, err := range invalid {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keepDeletionTick sets the DeletionTick of an object to that stored (nil if it is not stored
+// or not marked), unless the object has a different one
+func keepDeletionTick(obj v1.Object, stored *uint64) error {
+	tick := obj.GetDeletionTick()
+	if tick != nil && (stored == nil || *tick != *stored) {
+		return fmt.Errorf("%w DeletionTick of %s %s is set when it is deleted and cannot be changed", ErrInvalid, obj.GetKind(), obj.GetId())
+	}
+	obj.SetDeletionTick(stored)
+	return nil
+}
+
+// deleteWorld starts deleting a world & everything within it, the progress of which is recorded
+// as a WorldDeletion (see worldDeletion).
+func (s *Service) deleteWorld(ctx context.Context, world, etag string) error {
+	pan := log.NewSpan(ctx, "service.deleteWorld", map[string]interface{}{"world": world, "etag": etag})
+	defer pan.End()
+
+	worlds := []*v1.World{}
+	err := s.db.Get(ctx, "", kindWorld, []string{world}, &worlds)
+	if err != nil {
+		return pan.Err(err)
+	} else if len(worlds) == 0 {
+		return pan.Err(fmt.Errorf("%w world %s", ErrNotFound, world))
+	} else if etag != "" && worlds[0].Etag != etag {
+		return pan.Err(fmt.Errorf("%w world %s", db.ErrEtagMismatch, world))
+	}
+
+	existing := []*v1.WorldDeletion{}
+	err = s.db.Get(ctx, "", kindWorldDeletion, []string{world}, &existing)
+	if err != nil {
+		return pan.Err(err)
+	}
+	record := &v1.WorldDeletion{Meta: v1.Meta{Kind: kindWorldDeletion, Id: world}}
+	if len(existing) > 0 {
+		if existing[0].Status == v1.WorldDeletionRunning {
+			return nil // already in progress
+		}
+		record.Etag = existing[0].Etag
+	}
+	record.Status = v1.WorldDeletionRunning
+	record.Started = time.Now().Unix()
+
+	// nb. if someone else has started deleting the world this fails on the Etag
+	_, err = s.db.Set(ctx, "", uuid.New(), []v1.Object{record})
+	if err != nil {
+		return pan.Err(err)
+	}
+
+	go s.teardownWorld(worlds[0], record)
 	return nil
 }
+
+// teardownWorld removes a world & all data within it, recording the outcome
+func (s *Service) teardownWorld(world *v1.World, record *v1.WorldDeletion) {
+	ctx := context.Background()
+	pan := log.NewSpan(ctx, "service.teardownWorld", map[string]interface{}{"world": world.Id})
+	defer pan.End()
+
+	// we hold the lock for the duration so that Shutdown waits for us
+	s.shutdownLock.RLock()
+	defer s.shutdownLock.RUnlock()
+
+	var err error
+	if s.shuttingDown {
+		err = ErrShuttingDown
+	} else {
+		err = s.removeWorld(pan.Context, world)
+	}
+
+	record.Finished = time.Now().Unix()
+	if err != nil {
+		pan.Err(err)
+		s.log.Error().Str("world", world.Id).Err(err).Msg("failed to delete world")
+		record.Status = v1.WorldDeletionFailed
+		record.Message = err.Error()
+	} else {
+		record.Status = v1.WorldDeletionComplete
+	}
+
+	_, err = s.db.Set(ctx, "", uuid.New(), []v1.Object{record})
+	if err != nil {
+		s.log.Error().Str("world", world.Id).Err(err).Msg("failed to record world deletion status")
+	}
+}
+
+// removeWorld deletes every object, relation, search index & deferred event queue of a world,
+// followed by the world itself. Delete events are published for all removed objects.
+func (s *Service) removeWorld(ctx context.Context, world *v1.World) error {
+	// collect events for everything in the world before we remove it
+	events := []*v1.Event{}
+	searchable := []string{}
+	for _, k := range kind.Kinds() {
+		if kind.IsGlobal(k) {
+			continue
+		}
+		if kind.IsSearchable(k) {
+			searchable = append(searchable, k)
+		}
+
+		after := ""
+		for {
+			found := []*v1.Meta{}
+			err := s.db.ListAfter(ctx, world.Id, k, nil, after, deleteWorldPageSize, &found)
+			if err != nil {
+				return err
+			}
+			for _, obj := range found {
+				events = append(events, &v1.Event{World: world.Id, Kind: k, Controller: obj.GetController(), Id: obj.GetId(), Labels: obj.GetLabels(), Deleted: true})
+			}
+			if len(found) < int(deleteWorldPageSize) {
+				break
+			}
+			after = found[len(found)-1].GetId()
+		}
+	}
+
+	// stop handling deferred events & remove all of their queues
+	ticks := map[uint64]bool{}
+	for _, tick := range s.tickManager.forget(world.Id) {
+		ticks[tick] = true
+	}
+	deferred, err := s.db.DeferredTicks(ctx, world.Id)
+	if err != nil {
+		return err
+	}
+	for _, tick := range deferred {
+		ticks[tick] = true
+	}
+	for tick := range ticks {
+		err = s.qu.DeleteDeferredEventQueue(world.Id, tick)
+		if err != nil {
+			s.log.Warn().Str("world", world.Id).Uint64("tick", tick).Err(err).Msg("failed to delete deferred event queue")
+		}
+	}
+
+	// nb. events are stamped with the world's sequence & logged as changes, so we add them
+	// before the world's data (sequence & change log included) is dropped
+	err = s.db.AddEvents(ctx, events)
+	if err != nil {
+		return err
+	}
+	err = s.sb.DeleteWorld(ctx, world.Id, searchable)
+	if err != nil {
+		return err
+	}
+	err = s.db.DeleteWorld(ctx, world.Id)
+	if err != nil {
+		return err
+	}
+
+	// nb. the world event informs other tick managers that the world is gone
+	err = s.db.Delete(ctx, "", kindWorld, world.Id, "")
+	if err != nil {
+		return err
+	}
+
+	s.wakeRelay()
+	return nil
+}
+
+func (s *Service) worldDeletion(ctx context.Context, world string, rsp *api.WorldDeletionResponse) error {
+	pan := log.NewSpan(ctx, "service.worldDeletion", map[string]interface{}{"world": world})
+	defer pan.End()
+
+	found := []*v1.WorldDeletion{}
+	err := s.db.Get(ctx, "", kindWorldDeletion, []string{world}, &found)
+	if err != nil {
+		return pan.Err(err)
+	} else if len(found) == 0 {
+		return pan.Err(fmt.Errorf("%w no deletion of world %s", ErrNotFound, world))
+	}
+
+	rsp.Data = found[0]
+	return nil
+}
+
+// listToken is the content of our (opaque) continuation token
+type listToken struct {
+	After string `json:"After"`
+}
+
+// encodeToken returns a continuation token to list objects after the given Id
+func encodeToken(after string) string {
+	data, _ := json.Marshal(&listToken{After: after})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeToken returns the Id to list objects after, an empty token starts from the beginning
+func decodeToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("%w invalid token", ErrInvalid)
+	}
+	t := &listToken{}
+	err = json.Unmarshal(data, t)
+	if err != nil {
+		return "", fmt.Errorf("%w invalid token", ErrInvalid)
+	}
+	return t.After, nil
+}
+
+// writtenOnly filters objects down to those in the write Result
+func writtenOnly(result *db.Result, objects []v1.Object) []v1.Object {
+	written := []v1.Object{}
+	for _, obj := range objects {
+		if _, ok := result.Written[obj.GetId()]; ok {
+			written = append(written, obj)
+		}
+	}
+	return written
+}
+
+// labelSelector combines exact match labels & a selector string into one selector
+func labelSelector(labels map[string]string, in string) (selector.Selector, error) {
+	sel, err := selector.Parse(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w %v", ErrInvalid, err)
+	}
+	return append(selector.FromLabels(labels), sel...), nil
+}
+
+// labelsOf returns the labels of an object read from the db as a map
+func labelsOf(obj map[string]interface{}) map[string]string {
+	labels := map[string]string{}
+	found, _ := obj["Labels"].(map[string]interface{})
+	for k, v := range found {
+		if s, ok := v.(string); ok {
+			labels[k] = s
+		}
+	}
+	return labels
+}
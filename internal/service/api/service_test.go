@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/voidshard/faction/internal/db"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+// nullSearch is a search.Search that indexes nothing
+type nullSearch struct{}
+
+func (n *nullSearch) Index(ctx context.Context, world string, in []v1.Object, flush bool) error {
+	return nil
+}
+
+func (n *nullSearch) Delete(ctx context.Context, world, kind, id string) error {
+	return nil
+}
+
+func (n *nullSearch) DeleteWorld(ctx context.Context, world string, kinds []string) error {
+	return nil
+}
+
+func (n *nullSearch) Find(ctx context.Context, world string, q *v1.Query) ([]string, error) {
+	return []string{}, nil
+}
+
+// newTestService returns a Service backed by an in-memory database & queue
+func newTestService(t *testing.T) (*Service, *db.Memory) {
+	cfg := &Config{}
+	cfg.setDefaults()
+	mem := db.NewMemory()
+	svc, err := newService(cfg, mem, newMemoryQueue(), &nullSearch{})
+	assert.Nil(t, err)
+	t.Cleanup(svc.Shutdown)
+	return svc, mem
+}
+
+// newTestWorld writes a world along with the race & culture of newTestActor. Races & cultures
+// need a lot filled in to be valid, so we write those to the database directly.
+func newTestWorld(t *testing.T, svc *Service, world string) {
+	newTestWorldWith(t, svc, &v1.World{Meta: v1.Meta{Id: world}})
+}
+
+// newTestWorldWith is newTestWorld for a world with settings, which the tick manager is told of
+// straight away rather than waiting for the world's event.
+func newTestWorldWith(t *testing.T, svc *Service, w *v1.World) {
+	ctx := context.Background()
+	world := w.Id
+	err := svc.setKind(ctx, "world", &api.SetRequest{Data: []interface{}{w}}, &api.SetResponse{})
+	assert.Nil(t, err)
+	assert.Nil(t, svc.tickManager.seed(w))
+	_, err = svc.db.Set(ctx, world, uuid.New(), []v1.Object{&v1.Race{Meta: v1.Meta{Kind: "race", Id: "human"}}})
+	assert.Nil(t, err)
+	_, err = svc.db.Set(ctx, world, uuid.New(), []v1.Object{&v1.Culture{Meta: v1.Meta{Kind: "culture", Id: "nord"}}})
+	assert.Nil(t, err)
+}
+
+func newTestActor(labels map[string]string) *v1.Actor {
+	return &v1.Actor{Meta: v1.Meta{Kind: "actor", Labels: labels}, Race: "human", Culture: "nord"}
+}
+
+// getActors returns actors of a world by id, or all of them if no ids are given
+func getActors(t *testing.T, svc *Service, world string, ids ...string) []*v1.Actor {
+	rsp := &api.GetResponse{}
+	err := svc.getKind(context.Background(), "actor", &api.GetRequest{World: world, Ids: ids, Limit: 100}, rsp)
+	assert.Nil(t, err)
+	found := []*v1.Actor{}
+	for _, raw := range rsp.Data {
+		obj := &v1.Actor{}
+		a, err := obj.New(raw)
+		assert.Nil(t, err)
+		found = append(found, a.(*v1.Actor))
+	}
+	return found
+}
+
+func TestServiceSetGet(t *testing.T) {
+	svc, _ := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	newTestWorld(t, svc, "oz")
+	ctx := context.Background()
+
+	rsp := &api.SetResponse{}
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{
+		newTestActor(map[string]string{"class": "noble"}),
+		newTestActor(map[string]string{"class": "serf"}),
+	}}, rsp)
+	assert.Nil(t, err)
+	assert.Len(t, rsp.Etags, 2)
+
+	// objects are listed by world
+	assert.Len(t, getActors(t, svc, "narnia"), 2)
+	assert.Len(t, getActors(t, svc, "oz"), 0)
+
+	got := &api.GetResponse{}
+	err = svc.getKind(ctx, "actor", &api.GetRequest{World: "narnia", Selector: "class=noble", Limit: 100}, got)
+	assert.Nil(t, err)
+	assert.Len(t, got.Data, 1)
+
+	// updates must be at the current etag
+	actor := getActors(t, svc, "narnia")[0]
+	etag := actor.Etag
+	actor.Firstname = "Aslan"
+	err = svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{actor}}, &api.SetResponse{})
+	assert.Nil(t, err)
+
+	actor.Etag = etag
+	rsp = &api.SetResponse{}
+	err = svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{actor}}, rsp)
+	assert.ErrorIs(t, err, db.ErrEtagMismatch)
+	assert.Equal(t, []string{actor.Id}, rsp.Conflicts)
+	assert.Equal(t, "Aslan", getActors(t, svc, "narnia", actor.Id)[0].Firstname)
+}
+
+// waitWorldDeletion waits for the deletion of a world to finish & returns its status
+func waitWorldDeletion(t *testing.T, svc *Service, world string) string {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		rsp := &api.WorldDeletionResponse{}
+		err := svc.worldDeletion(context.Background(), world, rsp)
+		assert.Nil(t, err)
+		if rsp.Data != nil && rsp.Data.Status != v1.WorldDeletionRunning {
+			return rsp.Data.Status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("deletion of world %s did not finish", world)
+	return ""
+}
+
+func TestDeleteWorld(t *testing.T) {
+	svc, mem := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	rsp := &api.SetResponse{}
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil), newTestActor(nil)}}, rsp)
+	assert.Nil(t, err)
+
+	sub, err := svc.qu.SubscribeEvent(&v1.Event{World: "narnia", Kind: "actor"}, "", false)
+	assert.Nil(t, err)
+	defer sub.Close()
+
+	err = svc.deleteWorld(ctx, "narnia", "")
+	assert.Nil(t, err)
+	assert.Equal(t, v1.WorldDeletionComplete, waitWorldDeletion(t, svc, "narnia"))
+
+	// a delete event is published for each object in the world
+	deleted := []string{}
+	timeout := time.After(5 * time.Second)
+	for len(deleted) < len(rsp.Etags) {
+		select {
+		case msg := <-sub.Channel():
+			evt := &v1.Event{}
+			assert.Nil(t, json.Unmarshal(msg.Data(), evt))
+			if evt.Deleted {
+				deleted = append(deleted, evt.Id)
+			}
+		case <-timeout:
+			t.Fatalf("expected %d delete events, got %d", len(rsp.Etags), len(deleted))
+		}
+	}
+	for id := range rsp.Etags {
+		assert.Contains(t, deleted, id)
+	}
+
+	worlds := []*v1.World{}
+	err = svc.db.Get(ctx, "", kindWorld, []string{"narnia"}, &worlds)
+	assert.Nil(t, err)
+	assert.Len(t, worlds, 0)
+	assert.Len(t, getActors(t, svc, "narnia"), 0)
+
+	// nothing of the world is left behind, including by the delete events
+	for _, k := range []string{"actor", "race", "culture"} {
+		count, err := mem.Count(ctx, "narnia", k)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), count, k)
+	}
+	seq, err := mem.Sequence(ctx, "narnia")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), seq)
+	changes, err := mem.Changes(ctx, "narnia", 0, 100)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 0)
+}
+
+func TestSubscribeFromSequence(t *testing.T) {
+	svc, mem := newTestService(t)
+	newTestWorld(t, svc, "narnia")
+	ctx := context.Background()
+
+	rsp := &api.SetResponse{}
+	err := svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil), newTestActor(nil)}}, rsp)
+	assert.Nil(t, err)
+	changes, err := mem.Changes(ctx, "narnia", 0, 100)
+	assert.Nil(t, err)
+	actors := []*v1.Event{}
+	for _, evt := range changes {
+		if evt.Kind == "actor" {
+			actors = append(actors, evt)
+		}
+	}
+	if !assert.Len(t, actors, 2) {
+		return
+	}
+
+	// the watcher has seen the first actor, so is sent the second
+	events, kill, err := svc.subscribeToEvents(ctx, &api.StreamEvents{World: "narnia", Kind: "actor", FromSequence: actors[0].Sequence})
+	assert.Nil(t, err)
+	defer close(kill)
+
+	// then live events, except those it has already been sent
+	for _, evt := range changes {
+		assert.Nil(t, svc.qu.PublishEvent(ctx, evt))
+	}
+	later := &api.SetResponse{}
+	err = svc.setKind(ctx, "actor", &api.SetRequest{World: "narnia", Data: []interface{}{newTestActor(nil)}}, later)
+	assert.Nil(t, err)
+
+	sent := []string{}
+	timeout := time.After(5 * time.Second)
+	for len(sent) < 2 {
+		select {
+		case evt := <-events:
+			sent = append(sent, evt.Id)
+		case <-timeout:
+			t.Fatalf("expected 2 events, got %d", len(sent))
+		}
+	}
+	select {
+	case evt := <-events:
+		sent = append(sent, evt.Id)
+	case <-time.After(100 * time.Millisecond):
+	}
+	for id := range later.Etags {
+		assert.Equal(t, []string{actors[1].Id, id}, sent)
+	}
+}
+
+func TestWatcherQuota(t *testing.T) {
+	svc, mem := newTestService(t)
+	world := &v1.World{Meta: v1.Meta{Id: "narnia"}, Quota: &v1.Quota{Watchers: 2}}
+	newTestWorldWith(t, svc, world)
+	newTestWorld(t, svc, "archenland")
+	ctx := context.Background()
+
+	// a second server sharing the database
+	other, err := newService(svc.cfg, mem, newMemoryQueue(), &nullSearch{})
+	assert.Nil(t, err)
+	t.Cleanup(other.Shutdown)
+	assert.Nil(t, other.tickManager.seed(world))
+
+	// a watch of every world counts against narnia
+	all, err := svc.addWatcher(ctx, "", "")
+	assert.Nil(t, err)
+	one, err := other.addWatcher(ctx, "narnia", "actor")
+	assert.Nil(t, err)
+
+	_, err = svc.addWatcher(ctx, "narnia", "")
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+	_, err = other.addWatcher(ctx, "", "actor")
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+
+	// worlds without a quota & global kinds are not limited
+	free, err := other.addWatcher(ctx, "archenland", "")
+	assert.Nil(t, err)
+	defer free()
+	global, err := other.addWatcher(ctx, "", "world")
+	assert.Nil(t, err)
+	defer global()
+
+	rsp := &api.QuotaUsageResponse{}
+	assert.Nil(t, svc.quotaUsage(ctx, "narnia", rsp))
+	assert.Equal(t, int64(2), rsp.Data.Watchers)
+
+	// releasing a watch frees its place, on either server
+	all()
+	all()
+	_, err = svc.addWatcher(ctx, "narnia", "")
+	assert.Nil(t, err)
+	one()
+	_, err = other.addWatcher(ctx, "", "")
+	assert.Nil(t, err)
+}
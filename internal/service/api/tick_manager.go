@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -41,9 +42,22 @@ type tickManager struct {
 	cache     map[string]uint64
 	cacheLock sync.Mutex
 
+	// cache of world id -> revisions to keep of each object (see World.History)
+	history map[string]int
+
+	// cache of world id -> if reference checks are off (see World.SkipReferenceChecks)
+	skipReferences map[string]bool
+
+	// cache of world id -> quota (see World.Quota)
+	quotas map[string]*v1.Quota
+
 	// worldid,tick -> subscription (subscriptions to deferred events for a given world/tick)
 	subs     map[string]queue.Subscription
 	subsLock sync.Mutex
+
+	// worldid,tick -> if we've recorded that events are deferred to this world/tick
+	deferred     map[string]bool
+	deferredLock sync.Mutex
 }
 
 func newTickManager(name string, db db.Database, qu *Queue) (*tickManager, error) {
@@ -63,9 +77,15 @@ func newTickManager(name string, db db.Database, qu *Queue) (*tickManager, error
 		qu:           qu,
 		worldChanges: sub,
 		cache:        make(map[string]uint64),
+		history:      make(map[string]int),
 		cacheLock:    sync.Mutex{},
 		subs:         make(map[string]queue.Subscription),
 		subsLock:     sync.Mutex{},
+		deferred:     make(map[string]bool),
+		deferredLock: sync.Mutex{},
+
+		skipReferences: make(map[string]bool),
+		quotas:         make(map[string]*v1.Quota),
 	}, nil
 }
 
@@ -89,14 +109,18 @@ func (tc *tickManager) handleWorldChange(msg queue.Message) {
 		msg.Reject() // requeue
 		return
 	} else if worlds == nil || len(worlds) == 0 {
-		tc.log.Error().Str("id", ch.Id).Msg("World not found")
-		pan.Err(fmt.Errorf("world not found %s", ch.Id))
+		// the world has been deleted, we no longer need anything we hold for it
+		tc.log.Info().Str("id", ch.Id).Msg("World not found, forgetting world")
+		tc.forget(ch.Id)
 		msg.Ack() // no point retrying, world doesn't exist
 		return
 	}
 	pan.SetAttributes(map[string]interface{}{"world": worlds[0].Id, "tick": worlds[0].Tick})
 
 	tc.cacheLock.Lock()
+	tc.history[ch.Id] = worlds[0].History
+	tc.skipReferences[ch.Id] = worlds[0].SkipReferenceChecks
+	tc.quotas[ch.Id] = worlds[0].Quota
 	v, _ := tc.cache[ch.Id]
 	if worlds[0].Tick > v { // we only ever increase
 		tc.cache[ch.Id] = worlds[0].Tick
@@ -115,6 +139,42 @@ func (tc *tickManager) handleWorldChange(msg queue.Message) {
 	tc.log.Info().Str("MessageId", msg.Id()).Str("Id", ch.Id).Uint64("Tick", worlds[0].Tick).Msg("Updated tick cache")
 }
 
+// History returns the number of revisions to keep for objects in a world, or 0 if the world
+// does not set this.
+func (tc *tickManager) History(worldId string) int {
+	tc.cacheLock.Lock()
+	defer tc.cacheLock.Unlock()
+	return tc.history[worldId]
+}
+
+// SkipReferenceChecks returns if the world has turned off checking references on writes.
+func (tc *tickManager) SkipReferenceChecks(worldId string) bool {
+	tc.cacheLock.Lock()
+	defer tc.cacheLock.Unlock()
+	return tc.skipReferences[worldId]
+}
+
+// Quota returns the quota of a world, or nil if the world has none.
+func (tc *tickManager) Quota(worldId string) *v1.Quota {
+	tc.cacheLock.Lock()
+	defer tc.cacheLock.Unlock()
+	return tc.quotas[worldId]
+}
+
+// WatcherQuotas returns world id -> watcher quota of the worlds that limit their watchers.
+func (tc *tickManager) WatcherQuotas() map[string]int64 {
+	tc.cacheLock.Lock()
+	defer tc.cacheLock.Unlock()
+
+	found := map[string]int64{}
+	for world, quota := range tc.quotas {
+		if quota != nil && quota.Watchers > 0 {
+			found[world] = quota.Watchers
+		}
+	}
+	return found
+}
+
 // Tick returns the current tick for a given world from our cache
 func (tc *tickManager) Tick(worldId string) (uint64, error) {
 	tc.cacheLock.Lock()
@@ -206,11 +266,82 @@ func (tc *tickManager) maybeAlterSubscriptions(worldId string, tick uint64) erro
 		if err != nil {
 			tc.log.Warn().Str("Id", worldId).Uint64("Tick", tick-4).Err(err).Msg("Failed to delete deferred event queue")
 		}
+
+		tc.deferredLock.Lock()
+		delete(tc.deferred, key)
+		tc.deferredLock.Unlock()
+		err = tc.db.RemoveDeferredTick(context.Background(), worldId, tick-4)
+		if err != nil {
+			tc.log.Warn().Str("Id", worldId).Uint64("Tick", tick-4).Err(err).Msg("Failed to remove deferred tick")
+		}
 	}
 
 	return nil
 }
 
+// recordDeferred notes that events are being deferred to the given world tick, so that we
+// know which deferred event queues exist should the world be deleted.
+//
+// We only need to write this once per world tick, so we avoid hitting the DB for every defer.
+func (tc *tickManager) recordDeferred(ctx context.Context, worldId string, tick uint64) error {
+	key := fmt.Sprintf("%s,%d", worldId, tick)
+
+	tc.deferredLock.Lock()
+	defer tc.deferredLock.Unlock()
+
+	if tc.deferred[key] {
+		return nil
+	}
+	err := tc.db.AddDeferredTick(ctx, worldId, tick)
+	if err != nil {
+		return err
+	}
+	tc.deferred[key] = true
+	return nil
+}
+
+// forget drops everything we hold for a world (ie. because it has been deleted); the cached
+// tick, our subscriptions to deferred events and notes of deferred ticks.
+//
+// Returns the ticks we were subscribed to.
+func (tc *tickManager) forget(worldId string) []uint64 {
+	tc.cacheLock.Lock()
+	delete(tc.cache, worldId)
+	delete(tc.history, worldId)
+	delete(tc.skipReferences, worldId)
+	delete(tc.quotas, worldId)
+	tc.cacheLock.Unlock()
+
+	// nb. world ids are alphanumeric, so cannot contain ','
+	prefix := fmt.Sprintf("%s,", worldId)
+
+	tc.deferredLock.Lock()
+	for key := range tc.deferred {
+		if strings.HasPrefix(key, prefix) {
+			delete(tc.deferred, key)
+		}
+	}
+	tc.deferredLock.Unlock()
+
+	tc.subsLock.Lock()
+	defer tc.subsLock.Unlock()
+
+	ticks := []uint64{}
+	for key, sub := range tc.subs {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		var tick uint64
+		_, err := fmt.Sscanf(strings.TrimPrefix(key, prefix), "%d", &tick)
+		if err == nil {
+			ticks = append(ticks, tick)
+		}
+		sub.Close()
+		delete(tc.subs, key)
+	}
+	return ticks
+}
+
 func (tc *tickManager) Run() {
 	// kick off a routine to listen for events in worlds and update our cache
 	go func() {
@@ -233,16 +364,31 @@ func (tc *tickManager) Run() {
 	tc.populateCache()
 }
 
+// seed sets what we know of a world we've just written, rather than waiting for the event
+func (tc *tickManager) seed(w *v1.World) error {
+	tc.cacheLock.Lock()
+	tc.history[w.Id] = w.History
+	tc.skipReferences[w.Id] = w.SkipReferenceChecks
+	tc.quotas[w.Id] = w.Quota
+	if w.Tick <= tc.cache[w.Id] {
+		tc.cacheLock.Unlock()
+		return nil
+	}
+	tc.cache[w.Id] = w.Tick
+	tc.cacheLock.Unlock()
+	return tc.maybeAlterSubscriptions(w.Id, w.Tick)
+}
+
 func (tc *tickManager) populateCache() {
 	ctx := context.Background()
 
 	var limit int64 = 1000
 
-	var offset int64
+	after := ""
 	for {
-		tc.log.Debug().Int("Offset", int(offset)).Int("Limit", int(limit)).Msg("Populating tick manager cache, listing worlds")
+		tc.log.Debug().Str("After", after).Int("Limit", int(limit)).Msg("Populating tick manager cache, listing worlds")
 		worlds := []*v1.World{}
-		err := tc.db.List(ctx, "", "world", nil, limit, offset, &worlds)
+		err := tc.db.ListAfter(ctx, "", "world", nil, after, limit, &worlds)
 		if err != nil {
 			tc.log.Warn().Err(err).Msg("Failed to list worlds")
 			time.Sleep(time.Second * 2)
@@ -252,6 +398,9 @@ func (tc *tickManager) populateCache() {
 		tc.cacheLock.Lock()
 		for _, w := range worlds {
 			tc.cache[w.Id] = w.Tick
+			tc.history[w.Id] = w.History
+			tc.skipReferences[w.Id] = w.SkipReferenceChecks
+			tc.quotas[w.Id] = w.Quota
 		}
 		tc.cacheLock.Unlock()
 
@@ -269,7 +418,7 @@ func (tc *tickManager) populateCache() {
 			return
 		}
 
-		offset += int64(len(worlds))
+		after = worlds[len(worlds)-1].Id
 	}
 }
 
@@ -2,7 +2,9 @@ package api
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"time"
 )
 
 func readJson(r *http.Request, v interface{}) error {
@@ -10,3 +12,42 @@ func readJson(r *http.Request, v interface{}) error {
 	defer r.Body.Close()
 	return decoder.Decode(v)
 }
+
+// streamWriter writes a streamed response body, the headers are sent on the first write so that
+// we can still reply with an error if we fail before writing anything.
+//
+// If a timeout is given the write deadline of the connection is pushed forward on each write, so
+// that a long stream is limited by how long each write takes rather than how long the whole takes.
+type streamWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	started     bool
+
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	if sw.rc != nil {
+		sw.rc.SetWriteDeadline(time.Now().Add(sw.timeout)) // nb. not every writer supports this
+	}
+	if !sw.started {
+		sw.w.Header().Set("Content-Type", sw.contentType)
+		sw.w.WriteHeader(http.StatusOK)
+		sw.started = true
+	}
+	return sw.w.Write(p)
+}
+
+// deadlineReader reads a streamed request body, pushing the read deadline of the connection
+// forward on each read (see streamWriter).
+type deadlineReader struct {
+	r       io.Reader
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+func (dr *deadlineReader) Read(p []byte) (int, error) {
+	dr.rc.SetReadDeadline(time.Now().Add(dr.timeout)) // nb. not every writer supports this
+	return dr.r.Read(p)
+}
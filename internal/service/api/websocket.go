@@ -43,6 +43,9 @@ var upgrader = websocket.Upgrader{
 
 // Client is a middleman between the websocket connection and the hub.
 type WebSocket struct {
+	// ctx of the request that opened the websocket, acks are authorized as its caller
+	ctx context.Context
+
 	// The service that this websocket is connected to.
 	svc *Service
 
@@ -56,8 +59,8 @@ type WebSocket struct {
 	killEvents chan<- bool
 }
 
-func newWebSocket(svc *Service, conn *websocket.Conn) *WebSocket {
-	return &WebSocket{svc: svc, conn: conn}
+func newWebSocket(ctx context.Context, svc *Service, conn *websocket.Conn) *WebSocket {
+	return &WebSocket{ctx: context.WithoutCancel(ctx), svc: svc, conn: conn}
 }
 
 // Close the connection, attempt to send an error message to the client
@@ -132,7 +135,7 @@ func (c *WebSocket) readPump() {
 			pan.End()
 			continue
 		}
-		err = c.svc.ackEvent(string(message))
+		err = c.svc.ackEvent(c.ctx, string(message))
 		if err != nil {
 			pan.Err(err)
 		}
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/voidshard/faction/pkg/kind"
 	"github.com/voidshard/faction/pkg/structs/api"
 	v1 "github.com/voidshard/faction/pkg/structs/v1"
 	"github.com/voidshard/faction/pkg/util/log"
+	"github.com/voidshard/faction/pkg/util/uuid"
 )
 
 var (
@@ -125,6 +127,16 @@ func (c *Client) Delete(kind, world string, ids []string) error {
 	})
 }
 
+// DeleteForeground deletes objects as Delete does, but first deletes their dependents (objects
+// that list them as Owners) rather than leaving them to be collected in the background.
+func (c *Client) DeleteForeground(kind, world string, ids []string) error {
+	return c.delete(kind, &api.DeleteRequest{
+		Ids:         ids,
+		World:       world,
+		Propagation: api.PropagationForeground,
+	})
+}
+
 func (c *Client) delete(k string, req *api.DeleteRequest) error {
 	resp, err := c.doRequest(k, "DELETE", req)
 	if err != nil {
@@ -150,63 +162,97 @@ func (c *Client) delete(k string, req *api.DeleteRequest) error {
 	return nil
 }
 
-func (c *Client) Set(in []v1.Object) error {
-	byKind := map[string]map[string][]interface{}{}
+// SetResult holds the outcome of a Set across all of the requests it made
+type SetResult struct {
+	// Etags maps Id -> new Etag of each object written
+	Etags map[string]string
+
+	// Conflicts lists Ids of objects not written because their Etag was out of date
+	Conflicts []string
+}
+
+// Set writes the given objects. Written objects have their Id & Etag updated in place
+// so that they can be edited & Set again without re-reading them.
+func (c *Client) Set(in []v1.Object) (*SetResult, error) {
+	byKind := map[string]map[string][]v1.Object{}
 	for _, obj := range in {
 		k := obj.GetKind()
 		byWorld, ok := byKind[k]
 		if !ok {
-			byWorld = map[string][]interface{}{}
+			byWorld = map[string][]v1.Object{}
 		}
 
 		objects, ok := byWorld[obj.GetWorld()]
 		if !ok {
-			objects = []interface{}{}
+			objects = []v1.Object{}
 		}
 
 		objects = append(objects, obj)
 		byWorld[obj.GetWorld()] = objects
 		byKind[k] = byWorld
 	}
+
+	result := &SetResult{Etags: map[string]string{}, Conflicts: []string{}}
 	for k, byWorld := range byKind {
 		for world, objects := range byWorld {
+			// new objects are given Ids here (where the kind uses UUIDs) so that we can
+			// match them to returned Etags
 			req := api.NewSetRequest()
-			req.Data = objects
+			for _, obj := range objects {
+				if obj.GetId() == "" && obj.GetEtag() == "" {
+					id := uuid.New()
+					if kind.IsValidId(k, id) {
+						obj.SetId(id)
+					}
+				}
+				req.Data = append(req.Data, obj)
+			}
 			if !kind.IsGlobal(k) {
 				req.World = world
 			}
-			err := c.set(k, req)
+
+			setresp, err := c.set(k, req)
+			if setresp != nil {
+				for _, obj := range objects {
+					etag, ok := setresp.Etags[obj.GetId()]
+					if ok {
+						obj.SetEtag(etag)
+						result.Etags[obj.GetId()] = etag
+					}
+				}
+				result.Conflicts = append(result.Conflicts, setresp.Conflicts...)
+			}
 			if err != nil {
-				return err
+				return result, err
 			}
 		}
 	}
-	return nil
+	return result, nil
 }
 
-func (c *Client) set(k string, req *api.SetRequest) error {
+func (c *Client) set(k string, req *api.SetRequest) (*api.SetResponse, error) {
 	resp, err := c.doRequest(k, "POST", req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	setresp := &api.SetResponse{}
 	err = json.NewDecoder(resp.Body).Decode(setresp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if setresp.Error != nil {
 		if setresp.Error.Code != 0 {
-			return fmt.Errorf("error code: %d, message: %s", setresp.Error.Code, setresp.Error.Message)
+			return setresp, fmt.Errorf("error code: %d, message: %s", setresp.Error.Code, setresp.Error.Message)
 		}
 	}
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return setresp, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	return nil
+	return setresp, nil
 }
 
 func (c *Client) Get() *getBuilder {
@@ -261,10 +307,11 @@ func (c *Client) doRequest(k, method string, req interface{}) (*http.Response, e
 	if err != nil {
 		return nil, err
 	}
+	httpreq.Header = c.cfg.header()
 	httpreq.Header.Set("Content-Type", "application/json")
 
 	log.Debug().Str("kind", k).Str("method", method).Str("url", u.String()).Msg("sending request")
-	resp, err := c.httpClient.Do(httpreq)
+	resp, err := c.do(httpreq)
 	if err != nil {
 		return nil, err
 	}
@@ -275,3 +322,98 @@ func (c *Client) doRequest(k, method string, req interface{}) (*http.Response, e
 
 	return resp, nil
 }
+
+// do sends a request, if the server says we're sending too many requests we wait as long as
+// it asks (in Retry-After) and send it again, up to Config.RateLimitRetries times.
+//
+// Requests with bodies that cannot be read again (ie. streamed) are not retried.
+func (c *Client) do(httpreq *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Do(httpreq)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= c.cfg.RateLimitRetries {
+			return resp, err
+		}
+		if httpreq.Body != nil && httpreq.GetBody == nil {
+			return resp, err
+		}
+
+		wait := retryAfter(resp.Header.Get("Retry-After"))
+		resp.Body.Close()
+		log.Debug().Str("url", httpreq.URL.String()).Dur("wait", wait).Int("attempt", attempt).Msg("rate limited, retrying")
+		time.Sleep(wait)
+
+		if httpreq.GetBody != nil {
+			httpreq.Body, err = httpreq.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// retryAfter reads a Retry-After header, which is either seconds or a date
+func retryAfter(header string) time.Duration {
+	secs, err := strconv.Atoi(header)
+	if err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	when, err := http.ParseTime(header)
+	if err == nil && time.Until(when) > 0 {
+		return time.Until(when)
+	}
+	return time.Second
+}
+
+// WorldDeletion returns the progress of deleting the given world.
+//
+// Deleting a world (& everything within it) happens in the background after Delete returns.
+func (c *Client) WorldDeletion(world string) (*v1.WorldDeletion, error) {
+	resp, err := c.doRequest(fmt.Sprintf("%s/deletion", world), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	delresp := &api.WorldDeletionResponse{}
+	err = json.NewDecoder(resp.Body).Decode(delresp)
+	if err != nil {
+		return nil, err
+	}
+
+	if delresp.Error != nil {
+		if delresp.Error.Code != 0 {
+			return nil, fmt.Errorf("error code: %d, message: %s", delresp.Error.Code, delresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return delresp.Data, nil
+}
+
+// QuotaUsage returns the current use of a world against its quota (see v1.World.Quota).
+func (c *Client) QuotaUsage(world string) (*v1.QuotaUsage, error) {
+	resp, err := c.doRequest(fmt.Sprintf("%s/usage", world), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	usageresp := &api.QuotaUsageResponse{}
+	err = json.NewDecoder(resp.Body).Decode(usageresp)
+	if err != nil {
+		return nil, err
+	}
+
+	if usageresp.Error != nil {
+		if usageresp.Error.Code != 0 {
+			return nil, fmt.Errorf("error code: %d, message: %s", usageresp.Error.Code, usageresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return usageresp.Data, nil
+}
@@ -1,15 +1,30 @@
 package client
 
 import (
+	"net/http"
 	"os"
 	"strconv"
 
 	"github.com/voidshard/faction/pkg/util/log"
 )
 
+const (
+	defaultRateLimitRetries = 5
+)
+
 type Config struct {
 	Host string
 	Port int
+
+	// GrpcPort is the port of the gRPC API (see NewGRPC)
+	GrpcPort int
+
+	// Token, if set, is sent as a bearer token to authenticate with the API
+	Token string
+
+	// RateLimitRetries is how many times a request is retried if the server rate limits us,
+	// waiting as long as the server asks each time.
+	RateLimitRetries int
 }
 
 func NewConfig() *Config {
@@ -28,5 +43,24 @@ func NewConfig() *Config {
 		log.Warn().Err(err).Msg("Failed to parse port, using default")
 	}
 
-	return &Config{Host: host, Port: portInt}
+	grpcPort, err := strconv.Atoi(os.Getenv("GRPC_PORT"))
+	if err != nil {
+		grpcPort = 5001
+	}
+
+	retries, err := strconv.Atoi(os.Getenv("RATE_LIMIT_RETRIES"))
+	if err != nil {
+		retries = defaultRateLimitRetries
+	}
+
+	return &Config{Host: host, Port: portInt, GrpcPort: grpcPort, Token: os.Getenv("TOKEN"), RateLimitRetries: retries}
+}
+
+// header returns the headers to send with each request
+func (c *Config) header() http.Header {
+	h := http.Header{}
+	if c.Token != "" {
+		h.Set("Authorization", "Bearer "+c.Token)
+	}
+	return h
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"sync"
 	"time"
@@ -35,7 +36,8 @@ var (
 )
 
 type EventStream struct {
-	url *url.URL
+	url    *url.URL
+	header http.Header
 
 	mu     sync.RWMutex
 	conn   *websocket.Conn
@@ -45,9 +47,9 @@ type EventStream struct {
 	acks   chan string
 }
 
-func newEventStream(u *url.URL) (*EventStream, error) {
-	me := &EventStream{url: u, events: make(chan *v1.Event), acks: make(chan string), mu: sync.RWMutex{}}
-	conn, err := connect(u)
+func newEventStream(u *url.URL, header http.Header) (*EventStream, error) {
+	me := &EventStream{url: u, header: header, events: make(chan *v1.Event), acks: make(chan string), mu: sync.RWMutex{}}
+	conn, err := connect(u, header)
 	if err != nil {
 		return me, err
 	}
@@ -62,7 +64,7 @@ func (e *EventStream) connectForever(u *url.URL) *websocket.Conn {
 		if e.killed {
 			return nil
 		}
-		conn, err := connect(u)
+		conn, err := connect(u, e.header)
 		if err == nil {
 			return conn
 		}
@@ -160,8 +162,8 @@ func (e *EventStream) Ack(id string) error {
 	return nil
 }
 
-func connect(u *url.URL) (*websocket.Conn, error) {
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+func connect(u *url.URL, header http.Header) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), header)
 	if err != nil {
 		log.Warn().Err(err).Str("url", u.String()).Msg("Failed to connect websocket")
 		return nil, err
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/voidshard/faction/pkg/proto/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewGRPC returns a client of the gRPC API, the connection should be closed when done.
+func NewGRPC(cfg *Config) (pb.APIClient, *grpc.ClientConn, error) {
+	if cfg == nil {
+		cfg = NewConfig()
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(retryRateLimited(cfg.RateLimitRetries)),
+	}
+	if cfg.Token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerCredentials(cfg.Token)))
+	}
+	conn, err := grpc.NewClient(fmt.Sprintf("%s:%d", cfg.Host, cfg.GrpcPort), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pb.NewAPIClient(conn), conn, nil
+}
+
+// bearerCredentials sends a bearer token with each call
+type bearerCredentials string
+
+func (b bearerCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(b)}, nil
+}
+
+func (b bearerCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// retryRateLimited retries unary calls that the server rate limits, waiting as long as it asks
+// (in the "retry-after" header), up to the given number of times.
+func retryRateLimited(retries int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for attempt := 0; ; attempt++ {
+			header := metadata.MD{}
+			err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+			wait := header.Get("retry-after")
+			if status.Code(err) != codes.ResourceExhausted || len(wait) == 0 || attempt >= retries {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(retryAfter(wait[0])):
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/voidshard/faction/pkg/structs/api"
+	"github.com/voidshard/faction/pkg/util/log"
+)
+
+const (
+	contentTypeTar    = "application/x-tar"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// Export writes a tar archive of everything in the given world to w.
+//
+// The archive can be given to Import to recreate the world, under the same or a new id. If the
+// export fails part way an error is returned, though w will have been given part of the archive.
+func (c *Client) Export(world string, w io.Writer) error {
+	resp, err := c.doStream(fmt.Sprintf("%s/export", world), "GET", contentTypeTar, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decodeStreamError(resp)
+	}
+
+	// we read the archive as we copy it, so that we know if it was cut short
+	tee := io.TeeReader(resp.Body, w)
+	tr := tar.NewReader(tee)
+	var end *api.ArchiveEnd
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if hdr.Name == api.ArchiveEndFile {
+			end = &api.ArchiveEnd{}
+			err = json.NewDecoder(tr).Decode(end)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	_, err = io.Copy(io.Discard, tee) // nb. anything after the archive still goes to w
+	if err != nil {
+		return err
+	}
+
+	if end == nil {
+		return fmt.Errorf("export of world %s is incomplete", world)
+	} else if end.Error != nil {
+		return fmt.Errorf("error code: %d, message: %s", end.Error.Code, end.Error.Message)
+	}
+	return nil
+}
+
+// Import recreates a world from an archive written by Export as the given world, which must
+// not already exist. Returns the number of objects imported of each kind.
+func (c *Client) Import(world string, r io.Reader) (map[string]int64, error) {
+	resp, err := c.doStream(fmt.Sprintf("%s/import", world), "POST", contentTypeTar, nil, r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	impresp := &api.ImportResponse{}
+	err = json.NewDecoder(resp.Body).Decode(impresp)
+	if err != nil {
+		return nil, err
+	}
+
+	if impresp.Error != nil {
+		if impresp.Error.Code != 0 {
+			return impresp.Counts, fmt.Errorf("error code: %d, message: %s", impresp.Error.Code, impresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return impresp.Counts, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return impresp.Counts, nil
+}
+
+// Fork copies a world into a new world `to` at the same Tick, including relations & events
+// deferred to future ticks, so that both can be run independently.
+func (c *Client) Fork(world, to string) (*api.ForkResponse, error) {
+	forkresp := &api.ForkResponse{}
+
+	resp, err := c.doRequest(fmt.Sprintf("%s/fork", world), "POST", &api.ForkRequest{To: to})
+	if err != nil {
+		return forkresp, err
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(forkresp)
+	if err != nil {
+		return forkresp, err
+	}
+
+	if forkresp.Error != nil {
+		if forkresp.Error.Code != 0 {
+			return forkresp, fmt.Errorf("error code: %d, message: %s", forkresp.Error.Code, forkresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return forkresp, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return forkresp, nil
+}
+
+// doStream is doRequest for bodies that aren't JSON
+func (c *Client) doStream(path, method, contentType string, query url.Values, body io.Reader) (*http.Response, error) {
+	u := url.URL{
+		Scheme:   "http",
+		Host:     fmt.Sprintf("%s:%d", c.cfg.Host, c.cfg.Port),
+		Path:     fmt.Sprintf("/v1/%s", path),
+		RawQuery: query.Encode(),
+	}
+
+	httpreq, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	httpreq.Header = c.cfg.header()
+	httpreq.Header.Set("Content-Type", contentType)
+
+	log.Debug().Str("method", method).Str("url", u.String()).Msg("sending request")
+	return c.do(httpreq)
+}
+
+// decodeStreamError returns the error of a streamed response that failed before it began
+func decodeStreamError(resp *http.Response) error {
+	errresp := &struct {
+		Error *api.ErrorResponse `json:"Error"`
+	}{}
+	err := json.NewDecoder(resp.Body).Decode(errresp)
+	if err == nil && errresp.Error != nil && errresp.Error.Code != 0 {
+		return fmt.Errorf("error code: %d, message: %s", errresp.Error.Code, errresp.Error.Message)
+	}
+	return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+}
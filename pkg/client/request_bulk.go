@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/voidshard/faction/pkg/structs/api"
+)
+
+type bulkBuilder struct {
+	client *Client
+	world  string
+	kind   string
+}
+
+// Bulk writes newline delimited objects in chunks, rather than holding them all
+// in memory as Set does.
+func (c *Client) Bulk() *bulkBuilder {
+	return &bulkBuilder{client: c}
+}
+
+// World sets the world objects are written to (ignored for global kinds)
+func (b *bulkBuilder) World(world string) *bulkBuilder {
+	b.world = world
+	return b
+}
+
+// Kind sets the kind of every object, otherwise each object's kind is read from its _kind
+func (b *bulkBuilder) Kind(kind string) *bulkBuilder {
+	b.kind = kind
+	return b
+}
+
+// Do streams one object per line from r to the server, calling fn with the result of each
+// line as it is written. Lines that fail are reported to fn rather than ending the write.
+func (b *bulkBuilder) Do(r io.Reader, fn func(*api.BulkResult) error) error {
+	query := url.Values{}
+	if b.world != "" {
+		query.Set("world", b.world)
+	}
+	if b.kind != "" {
+		query.Set("kind", b.kind)
+	}
+
+	resp, err := b.client.doStream("bulk", "POST", contentTypeNDJSON, query, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decodeStreamError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		res := &api.BulkResult{}
+		err = json.Unmarshal(scanner.Bytes(), res)
+		if err != nil {
+			return err
+		}
+		if res.Line == 0 && res.Error != nil {
+			// the write failed part way
+			return fmt.Errorf("error code: %d, message: %s", res.Error.Code, res.Error.Message)
+		}
+		err = fn(res)
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -14,6 +14,7 @@ type getBuilder struct {
 func (r *getBuilder) Do(k string) ([]v1.Object, error) {
 	if r.Req.Ids != nil && len(r.Req.Ids) > 0 {
 		r.Req.Labels = nil
+		r.Req.Selector = ""
 	}
 	resp, err := r.client.doGet(k, r.Req)
 	if err != nil {
@@ -72,7 +73,101 @@ func (r *getBuilder) Labels(labels map[string]string) *getBuilder {
 	return r
 }
 
+// Selector filters by label requirements, eg. "class in (noble,clergy),!exiled"
+func (r *getBuilder) Selector(selector string) *getBuilder {
+	r.Req.Selector = selector
+	return r
+}
+
 func (r *getBuilder) World(world string) *getBuilder {
 	r.Req.World = world
 	return r
 }
+
+// Token continues listing from a previous page (see Iterator)
+func (r *getBuilder) Token(token string) *getBuilder {
+	r.Req.Token = token
+	return r
+}
+
+// Iter returns an Iterator over every object of the kind, fetching pages of Limit objects
+// as required.
+func (r *getBuilder) Iter(k string) *Iterator {
+	r.Req.Ids = nil
+	r.Req.Offset = 0
+	return &Iterator{builder: r, kind: k}
+}
+
+// Iterator walks all pages of a list, ie.
+//
+//	iter := client.Get().World("narnia").Limit(500).Iter("actor")
+//	for iter.Next() {
+//		obj := iter.Object()
+//	}
+//	if err := iter.Err(); err != nil { ... }
+//
+// Changes made while listing can be caught by watching from the Sequence, ie.
+//
+//	stream, err := client.Watch().World("narnia").Kind("actor").FromSequence(iter.Sequence()).Do()
+type Iterator struct {
+	builder *getBuilder
+	kind    string
+
+	sequence uint64
+
+	page []v1.Object
+	obj  v1.Object
+	err  error
+	done bool
+}
+
+// Next advances to the next object, returning false when there are no more objects or
+// an error has occurred.
+func (i *Iterator) Next() bool {
+	for len(i.page) == 0 {
+		if i.done || i.err != nil {
+			return false
+		}
+		i.fetch()
+	}
+	i.obj, i.page = i.page[0], i.page[1:]
+	return true
+}
+
+// Object returns the current object
+func (i *Iterator) Object() v1.Object {
+	return i.obj
+}
+
+// Err returns the error (if any) that stopped iteration
+func (i *Iterator) Err() error {
+	return i.err
+}
+
+// Sequence returns the sequence of the world as of the first page, a watch from it sees
+// every change made since.
+func (i *Iterator) Sequence() uint64 {
+	return i.sequence
+}
+
+func (i *Iterator) fetch() {
+	first := i.builder.Req.Token == ""
+	resp, err := i.builder.client.doGet(i.kind, i.builder.Req)
+	if err != nil {
+		i.err = err
+		return
+	}
+	if first {
+		i.sequence = resp.Sequence
+	}
+	for _, d := range resp.Data {
+		obj, err := kind.New(i.kind, d)
+		if err != nil {
+			i.err = err
+			return
+		}
+		i.page = append(i.page, obj)
+	}
+	i.builder.Req.Token = resp.Token
+	i.done = resp.Token == ""
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+// History returns the recorded revisions of an object, most recent first.
+func (c *Client) History(k, world, id string) ([]*v1.Revision, error) {
+	req := &api.HistoryRequest{Id: id}
+	if !kind.IsGlobal(k) {
+		req.World = world
+	}
+
+	resp, err := c.doRequest(fmt.Sprintf("%s/history", k), "GET", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	histresp := &api.HistoryResponse{}
+	err = json.NewDecoder(resp.Body).Decode(histresp)
+	if err != nil {
+		return nil, err
+	}
+
+	if histresp.Error != nil {
+		if histresp.Error.Code != 0 {
+			return nil, fmt.Errorf("error code: %d, message: %s", histresp.Error.Code, histresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return histresp.Data, nil
+}
+
+// Rollback restores an object to the revision with Etag `toEtag`, provided the object is
+// currently at `etag` (or if `etag` is empty, the object has been deleted).
+//
+// Returns the new Etag of the object.
+func (c *Client) Rollback(k, world, id, etag, toEtag string) (string, error) {
+	req := &api.RollbackRequest{Id: id, Etag: etag, ToEtag: toEtag}
+	if !kind.IsGlobal(k) {
+		req.World = world
+	}
+
+	resp, err := c.doRequest(fmt.Sprintf("%s/rollback", k), "POST", req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	rbresp := &api.RollbackResponse{}
+	err = json.NewDecoder(resp.Body).Decode(rbresp)
+	if err != nil {
+		return "", err
+	}
+
+	if rbresp.Error != nil {
+		if rbresp.Error.Code != 0 {
+			return "", fmt.Errorf("error code: %d, message: %s", rbresp.Error.Code, rbresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return rbresp.Etag, nil
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
+)
+
+// JSONPatchOp is a single operation of a JSON patch (RFC 6902)
+type JSONPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type patchBuilder struct {
+	client *Client
+	kind   string
+	Req    *api.PatchRequest
+	err    error
+}
+
+func (c *Client) Patch(k, world string) *patchBuilder {
+	req := api.NewPatchRequest()
+	if !kind.IsGlobal(k) {
+		req.World = world
+	}
+	return &patchBuilder{client: c, kind: k, Req: req}
+}
+
+// Merge adds a JSON merge patch (RFC 7386) for the object with the given id, applied
+// only if the object is still at the given etag.
+func (b *patchBuilder) Merge(id, etag string, patch interface{}) *patchBuilder {
+	return b.add(id, etag, api.PatchMerge, patch)
+}
+
+// JSONPatch adds a JSON patch (RFC 6902) for the object with the given id, applied
+// only if the object is still at the given etag.
+func (b *patchBuilder) JSONPatch(id, etag string, ops []JSONPatchOp) *patchBuilder {
+	return b.add(id, etag, api.PatchJSON, ops)
+}
+
+func (b *patchBuilder) add(id, etag, ptype string, patch interface{}) *patchBuilder {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.Req.Data = append(b.Req.Data, &api.Patch{Id: id, Etag: etag, Type: ptype, Patch: data})
+	return b
+}
+
+func (b *patchBuilder) Do() (*SetResult, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	resp, err := b.client.doRequest(b.kind, "PATCH", b.Req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	patchresp := &api.PatchResponse{}
+	err = json.NewDecoder(resp.Body).Decode(patchresp)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SetResult{Etags: patchresp.Etags, Conflicts: patchresp.Conflicts}
+	if patchresp.Error != nil {
+		if patchresp.Error.Code != 0 {
+			return result, fmt.Errorf("error code: %d, message: %s", patchresp.Error.Code, patchresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+type tuplesBuilder struct {
+	client   *Client
+	world    string
+	relation string
+	Req      *api.GetTuplesRequest
+}
+
+func (c *Client) Tuples(world, relation, subject string) *tuplesBuilder {
+	return &tuplesBuilder{
+		client:   c,
+		world:    world,
+		relation: relation,
+		Req:      &api.GetTuplesRequest{Subject: subject},
+	}
+}
+
+// Object restricts results to the tuple (subject, object)
+func (b *tuplesBuilder) Object(object string) *tuplesBuilder {
+	b.Req.Object = object
+	return b
+}
+
+// Tick computes values as of the given tick (defaults to the current world tick)
+func (b *tuplesBuilder) Tick(tick uint64) *tuplesBuilder {
+	b.Req.Tick = tick
+	return b
+}
+
+func (b *tuplesBuilder) Do() ([]*v1.Tuple, error) {
+	resp, err := b.client.doRequest(relationPath(b.world, b.relation), "GET", b.Req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	getresp := &api.GetTuplesResponse{}
+	err = json.NewDecoder(resp.Body).Decode(getresp)
+	if err != nil {
+		return nil, err
+	}
+
+	if getresp.Error != nil {
+		if getresp.Error.Code != 0 {
+			return nil, fmt.Errorf("error code: %d, message: %s", getresp.Error.Code, getresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return getresp.Data, nil
+}
+
+func (c *Client) SetTuples(world, relation string, in []*v1.Tuple) error {
+	resp, err := c.doRequest(relationPath(world, relation), "POST", &api.SetTuplesRequest{Data: in})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	setresp := &api.SetTuplesResponse{}
+	err = json.NewDecoder(resp.Body).Decode(setresp)
+	if err != nil {
+		return err
+	}
+
+	if setresp.Error != nil {
+		if setresp.Error.Code != 0 {
+			return fmt.Errorf("error code: %d, message: %s", setresp.Error.Code, setresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *Client) AddModifiers(world, relation string, in []*v1.Modifier) error {
+	resp, err := c.doRequest(fmt.Sprintf("%s/modifier", relationPath(world, relation)), "POST", &api.AddModifiersRequest{Data: in})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	addresp := &api.AddModifiersResponse{}
+	err = json.NewDecoder(resp.Body).Decode(addresp)
+	if err != nil {
+		return err
+	}
+
+	if addresp.Error != nil {
+		if addresp.Error.Code != 0 {
+			return fmt.Errorf("error code: %d, message: %s", addresp.Error.Code, addresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func relationPath(world, relation string) string {
+	return fmt.Sprintf("%s/relation/%s", world, relation)
+}
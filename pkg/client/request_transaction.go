@@ -0,0 +1,63 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/voidshard/faction/pkg/kind"
+	"github.com/voidshard/faction/pkg/structs/api"
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/util/uuid"
+)
+
+// Transaction writes objects of any (non global) kinds within the given world, either
+// all of the objects are written or none are.
+//
+// As with Set, written objects have their Id & Etag updated in place.
+func (c *Client) Transaction(world string, in []v1.Object) (*SetResult, error) {
+	req := api.NewTransactionRequest()
+	for _, obj := range in {
+		if obj.GetId() == "" && obj.GetEtag() == "" {
+			id := uuid.New()
+			if kind.IsValidId(obj.GetKind(), id) {
+				obj.SetId(id)
+			}
+		}
+		req.Data = append(req.Data, obj)
+	}
+
+	result := &SetResult{Etags: map[string]string{}, Conflicts: []string{}}
+
+	resp, err := c.doRequest(fmt.Sprintf("%s/transaction", world), "POST", req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	txresp := &api.TransactionResponse{}
+	err = json.NewDecoder(resp.Body).Decode(txresp)
+	if err != nil {
+		return result, err
+	}
+
+	for _, obj := range in {
+		etag, ok := txresp.Etags[obj.GetId()]
+		if ok {
+			obj.SetEtag(etag)
+			result.Etags[obj.GetId()] = etag
+		}
+	}
+	result.Conflicts = append(result.Conflicts, txresp.Conflicts...)
+
+	if txresp.Error != nil {
+		if txresp.Error.Code != 0 {
+			return result, fmt.Errorf("error code: %d, message: %s", txresp.Error.Code, txresp.Error.Message)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return result, nil
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/voidshard/faction/pkg/structs/api"
 )
@@ -18,13 +19,17 @@ func (b *watchBuilder) Do() (*EventStream, error) {
 	v.Set("kind", b.Req.Kind)
 	v.Set("controller", b.Req.Controller)
 	v.Set("id", b.Req.Id)
+	v.Set("selector", b.Req.Selector)
 	v.Set("queue", b.Req.Queue)
+	if b.Req.FromSequence > 0 {
+		v.Set("sequence", strconv.FormatUint(b.Req.FromSequence, 10))
+	}
 	return newEventStream(&url.URL{
 		Scheme:   "ws",
 		Host:     fmt.Sprintf("%s:%d", b.client.cfg.Host, b.client.cfg.Port),
 		Path:     "/v1/event",
 		RawQuery: v.Encode(),
-	})
+	}, b.client.cfg.header())
 }
 
 func (b *watchBuilder) World(world string) *watchBuilder {
@@ -47,7 +52,20 @@ func (b *watchBuilder) Id(id string) *watchBuilder {
 	return b
 }
 
+// Selector filters events by the labels of the changed object (see getBuilder.Selector)
+func (b *watchBuilder) Selector(selector string) *watchBuilder {
+	b.Req.Selector = selector
+	return b
+}
+
 func (b *watchBuilder) Queue(queue string) *watchBuilder {
 	b.Req.Queue = queue
 	return b
 }
+
+// FromSequence replays changes after the given sequence (see Iterator.Sequence) before
+// live events, so that no change is missed between listing & watching.
+func (b *watchBuilder) FromSequence(seq uint64) *watchBuilder {
+	b.Req.FromSequence = seq
+	return b
+}
@@ -9,7 +9,7 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/voidshard/faction/pkg/structs/v1"
+	"github.com/voidshard/faction/pkg/proto/v1"
 	"github.com/voidshard/faction/pkg/util/lock"
 	"github.com/voidshard/faction/pkg/util/log"
 
@@ -33,6 +33,9 @@ type Manager struct {
 	streams    map[string]*stream
 	events     chan *v1.OnChangeResponse
 	ack        grpc.ClientStreamingClient[v1.AckRequest, v1.AckResponse]
+
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 func NewManager(name string, client v1.APIClient, locker lock.Locker) *Manager {
@@ -47,18 +50,29 @@ func NewManager(name string, client v1.APIClient, locker lock.Locker) *Manager {
 		streamlock: sync.Mutex{},
 		streams:    make(map[string]*stream),
 		events:     make(chan *v1.OnChangeResponse),
+		stop:       make(chan struct{}),
 	}
 }
 
+// Stop ends Run, as a SIGINT or SIGTERM would.
+func (m *Manager) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+}
+
+// close ends our watch streams & the ack stream, returning an error if the server did not
+// accept the close of the ack stream (in which case acks may have been lost).
 func (m *Manager) close() error {
 	m.streamlock.Lock()
 	defer m.streamlock.Unlock()
 	for _, st := range m.streams {
 		st.Close()
 	}
-	m.ack.CloseSend()
-	close(m.events)
-	return nil
+	if m.ack == nil {
+		return nil
+	}
+	_, err := m.ack.CloseAndRecv()
+	m.ack = nil
+	return err
 }
 
 func (m *Manager) Run(ctrl Controller) error {
@@ -72,29 +86,35 @@ func (m *Manager) Run(ctrl Controller) error {
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 
-	defer m.close()
+	defer signal.Stop(sigs)
+	defer func() {
+		err := m.close()
+		if err != nil {
+			m.l.Warn().Err(err).Msg("failed to close ack stream")
+		}
+	}()
 
 	for {
 		select {
-		case sig <- sigs:
+		case sig := <-sigs:
 			m.l.Info().Str("signal", sig.String()).Msg("received signal")
 			return nil
-		case req, ok := <-m.events:
-			if !ok {
-				continue
-			}
+		case <-m.stop:
+			m.l.Info().Msg("stopped")
+			return nil
+		case req := <-m.events:
 			ctx := context.Background()
 			pan := log.NewSpan(ctx, "controller.Event", map[string]interface{}{
 				"Controller": m.name,
-				"World":      req.Data.World,
-				"Area":       req.Data.Area,
-				"Key":        req.Data.Key,
-				"Id":         req.Data.Id,
+				"World":      req.Data.GetWorld(),
+				"Kind":       req.Data.GetKind(),
+				"Id":         req.Data.GetId(),
 				"Ack":        req.Ack,
 			})
+			ctx = pan.Context
 
 			key := changeKey(req.Data)
-			err := m.locker.Lock(key, defaultLockTTL)
+			err := m.locker.Lock(ctx, key, defaultLockTTL)
 			if err != nil {
 				m.l.Warn().Err(err).Msg("failed to lock event")
 				pan.Err(err)
@@ -102,9 +122,9 @@ func (m *Manager) Run(ctrl Controller) error {
 				continue
 			}
 
-			ok, err := ctrl.Handle(ctx, ch)
+			ok, err := ctrl.Handle(ctx, req.Data)
 
-			uerr := m.locker.Unlock(key)
+			uerr := m.locker.Unlock(ctx, key)
 			if uerr != nil {
 				m.l.Warn().Err(uerr).Msg("failed to unlock event")
 				// we will try to keep going
@@ -116,12 +136,12 @@ func (m *Manager) Run(ctrl Controller) error {
 				pan.End()
 				continue
 			}
-			if !ok {
+			if !ok || req.Ack == "" {
 				pan.End()
 				continue
 			}
 
-			err = m.ack.Send(&v1.AckRequest{Ack: []string{req.Ack}})
+			err = m.sendAck(req.Ack)
 			if err != nil {
 				m.l.Warn().Err(err).Msg("error sending ack")
 				pan.Err(err)
@@ -131,6 +151,26 @@ func (m *Manager) Run(ctrl Controller) error {
 	}
 }
 
+// sendAck acknowledges an event, opening the ack stream if required.
+func (m *Manager) sendAck(ack string) error {
+	m.streamlock.Lock()
+	defer m.streamlock.Unlock()
+
+	if m.ack == nil {
+		out, err := m.AckStream(context.Background())
+		if err != nil {
+			return err
+		}
+		m.ack = out
+	}
+
+	err := m.ack.Send(&v1.AckRequest{Ack: []string{ack}})
+	if err != nil {
+		m.ack = nil // reopen on the next ack
+	}
+	return err
+}
+
 func (m *Manager) Deregister(ch *v1.Change) error {
 	key := changeKey(ch)
 
@@ -155,18 +195,10 @@ func (m *Manager) Register(ch *v1.Change) error {
 		return nil // already registered
 	}
 
-	if m.ack == nil {
-		out, err := client.AckStream(context.Background())
-		if err != nil {
-			return err
-		}
-		m.ack = out
-	}
-
-	m.streams[key] = newStream(m.name, m, ch)
+	m.streams[key] = newStream(key, m, ch)
 	return nil
 }
 
 func changeKey(ch *v1.Change) string {
-	return fmt.Sprintf("%s_%s_%d_%s", ch.World, ch.Area, ch.Key, ch.Id)
+	return fmt.Sprintf("%s_%s_%s_%s", ch.GetWorld(), ch.GetKind(), ch.GetController(), ch.GetId())
 }
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/voidshard/faction/pkg/proto/v1"
+)
+
+// fakeAPI is a v1.APIClient that streams the changes sent to it to every watcher & records
+// acks, other calls are not expected.
+type fakeAPI struct {
+	v1.APIClient
+
+	changes chan *v1.OnChangeResponse
+
+	lock     sync.Mutex
+	watching []*v1.OnChangeRequest
+	acked    []string
+	closed   bool
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{changes: make(chan *v1.OnChangeResponse)}
+}
+
+func (f *fakeAPI) OnChange(ctx context.Context, in *v1.OnChangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[v1.OnChangeResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.watching = append(f.watching, in)
+	return &fakeChanges{ctx: ctx, api: f}, nil
+}
+
+func (f *fakeAPI) AckStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[v1.AckRequest, v1.AckResponse], error) {
+	return &fakeAcks{api: f}, nil
+}
+
+func (f *fakeAPI) acks() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]string{}, f.acked...)
+}
+
+type fakeChanges struct {
+	grpc.ClientStream
+	ctx context.Context
+	api *fakeAPI
+}
+
+func (f *fakeChanges) Recv() (*v1.OnChangeResponse, error) {
+	select {
+	case resp := <-f.api.changes:
+		return resp, nil
+	case <-f.ctx.Done():
+		return nil, f.ctx.Err()
+	}
+}
+
+type fakeAcks struct {
+	grpc.ClientStream
+	api *fakeAPI
+}
+
+func (f *fakeAcks) Send(req *v1.AckRequest) error {
+	f.api.lock.Lock()
+	defer f.api.lock.Unlock()
+	f.api.acked = append(f.api.acked, req.Ack...)
+	return nil
+}
+
+func (f *fakeAcks) CloseAndRecv() (*v1.AckResponse, error) {
+	f.api.lock.Lock()
+	defer f.api.lock.Unlock()
+	f.api.closed = true
+	return &v1.AckResponse{Acked: int64(len(f.api.acked))}, nil
+}
+
+// testController watches actors of a world, it fails changes to ids starting with "err" &
+// declines those starting with "no".
+type testController struct {
+	watch   *v1.Change
+	handled chan *v1.Change
+}
+
+func (c *testController) Init(m *Manager) error {
+	return m.Register(c.watch)
+}
+
+func (c *testController) Handle(ctx context.Context, ch *v1.Change) (bool, error) {
+	defer func() { c.handled <- ch }()
+	switch ch.GetId()[:2] {
+	case "er":
+		return false, fmt.Errorf("failed to handle %s", ch.GetId())
+	case "no":
+		return false, nil
+	}
+	return true, nil
+}
+
+func TestManager(t *testing.T) {
+	api := newFakeAPI()
+	ctrl := &testController{watch: &v1.Change{World: "narnia", Kind: "actor"}, handled: make(chan *v1.Change, 10)}
+	mgr := NewManager("test", api, nil)
+
+	done := make(chan error)
+	go func() { done <- mgr.Run(ctrl) }()
+
+	send := func(id, ack string) {
+		api.changes <- &v1.OnChangeResponse{Data: &v1.Change{World: "narnia", Kind: "actor", Id: id}, Ack: ack}
+		select {
+		case ch := <-ctrl.handled:
+			assert.Equal(t, id, ch.GetId())
+		case <-time.After(5 * time.Second):
+			t.Fatalf("change %s was not handled", id)
+		}
+	}
+	send("ok1", "ack1")
+	send("error", "ack2") // failed changes are not acked
+	send("no", "ack3")    // nor are those the controller declines
+	send("ok2", "")       // nor those without an ack id
+	send("ok3", "ack4")
+
+	mgr.Stop()
+	select {
+	case err := <-done:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("manager did not stop")
+	}
+
+	assert.Equal(t, []string{"ack1", "ack4"}, api.acks())
+	assert.True(t, api.closed)
+
+	// the watch is read from a durable queue, named for the manager & the watch
+	api.lock.Lock()
+	defer api.lock.Unlock()
+	if assert.Len(t, api.watching, 1) {
+		assert.Equal(t, "narnia", api.watching[0].GetData().GetWorld())
+		assert.Equal(t, "actor", api.watching[0].GetData().GetKind())
+		assert.Regexp(t, "^test[0-9a-f]{8}$", api.watching[0].GetQueue())
+	}
+}
+
+func TestManagerRegister(t *testing.T) {
+	api := newFakeAPI()
+	mgr := NewManager("test", api, &nullLocker{})
+	watch := &v1.Change{World: "narnia", Kind: "actor"}
+
+	assert.Nil(t, mgr.Register(watch))
+	assert.Nil(t, mgr.Register(watch)) // already registered
+	assert.Len(t, mgr.streams, 1)
+
+	assert.Nil(t, mgr.Deregister(watch))
+	assert.Nil(t, mgr.Deregister(watch)) // not registered
+	assert.Len(t, mgr.streams, 0)
+	assert.Nil(t, mgr.close())
+}
@@ -3,93 +3,101 @@ package controller
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"time"
 
-	"github.com/voidshard/faction/pkg/structs/v1"
-	"github.com/voidshard/faction/util/log"
+	"github.com/voidshard/faction/pkg/proto/v1"
+	"github.com/voidshard/faction/pkg/util/log"
 )
 
 type stream struct {
 	name    string
 	l       log.Logger
 	mgr     *Manager
-	kill    chan bool
+	ctx     context.Context
+	cancel  context.CancelFunc
 	watch   *v1.Change
 	fromAPI v1.API_OnChangeClient
-	closed  bool
 }
 
 func newStream(name string, mgr *Manager, watch *v1.Change) *stream {
+	ctx, cancel := context.WithCancel(context.Background())
 	me := &stream{
-		name:  name,
-		l:     log.Sublogger(name),
-		kill:  make(chan bool),
-		watch: watch,
+		name:   name,
+		l:      log.Sublogger(name),
+		mgr:    mgr,
+		ctx:    ctx,
+		cancel: cancel,
+		watch:  watch,
 	}
 	go me.readPump()
 	return me
 }
 
-func (s *stream) connect() {
-	queueName := fmt.Sprintf("%s_%s", s.mgr.name, s.name)
+// queueName returns the (durable) queue this stream reads from, queue names must be
+// alphanumeric so the stream name is hashed.
+func (s *stream) queueName() string {
+	h := fnv.New32a()
+	h.Write([]byte(s.name))
+	return fmt.Sprintf("%s%08x", s.mgr.name, h.Sum32())
+}
+
+// connect opens the stream, retrying until it succeeds or the stream is closed.
+func (s *stream) connect() bool {
 	attempt := -1
 	for {
 		attempt++
-		in, err := m.OnChange(
-			context.Background(),
-			&v1.OnChangeRequest{Data: s.watch, Queue: queueName},
-		)
-		if err != nil {
-			s.l.Warn().Int("Attempt", attempt).Err(err).Msg("error connecting to watch stream")
-			if attempt > 0 {
-				time.Sleep(2 * time.Second)
-			}
-			continue
+		in, err := s.mgr.OnChange(s.ctx, &v1.OnChangeRequest{Data: s.watch, Queue: s.queueName()})
+		if err == nil {
+			s.fromAPI = in
+			return true
+		}
+		s.l.Warn().Int("Attempt", attempt).Err(err).Msg("error connecting to watch stream")
+		select {
+		case <-s.ctx.Done():
+			return false
+		case <-time.After(2 * time.Second):
 		}
-		s.fromAPI = in
 	}
 }
 
 func (s *stream) readPump() {
-	defer close(s.kill)
-
-	s.connect() // no error, because we retry forever
+	if !s.connect() {
+		return
+	}
 
 	for {
+		resp, err := s.fromAPI.Recv()
+		if s.ctx.Err() != nil {
+			s.l.Info().Msg("watch stream closed")
+			return // we've been asked to close
+		} else if err == io.EOF {
+			s.l.Warn().Err(err).Msg("watch stream closed, reconnecting")
+			if !s.connect() {
+				return
+			}
+			continue
+		} else if err != nil {
+			s.l.Warn().Err(err).Msg("error reading watch stream response, reconnecting")
+			if !s.connect() {
+				return
+			}
+			continue
+		} else if resp.Error != nil {
+			s.l.Warn().Str("error", resp.Error.Message).Msg("server sent error on watch stream")
+			continue // error sent from server
+		}
+
 		select {
-		case <-s.kill:
-			l.Info().Msg("watch stream closed")
+		case s.mgr.events <- resp:
+		case <-s.ctx.Done():
 			return
-		default:
-			resp, err := s.fromAPI.Recv()
-			if err == io.EOF {
-				if s.closed {
-					return // we've been asked to close
-				}
-				l.Warn().Err(err).Msg("watch stream closed, reconnecting")
-				s.connect() // reconnect
-				continue
-			} else if err != nil {
-				l.Warn().Err(err).Msg("error reading watch stream response")
-				continue
-			} else if resp == nil {
-				l.Warn().Msg("watch stream got nil response")
-				continue // ???
-			} else if resp.Error != nil {
-				l.Warn().Str("error", resp.Error.Message).Msg("server sent error on watch stream")
-				continue // error sent from server
-			}
-			s.events <- resp
 		}
 	}
 }
 
 func (s *stream) Close() error {
-	if s.closed {
-		return nil
-	}
-	s.closed = true
-	s.kill <- true
-	return s.fromAPI.CloseSend()
+	s.cancel()
+	return nil
 }
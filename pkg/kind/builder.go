@@ -10,6 +10,20 @@ type kindBuilder struct {
 	allow_alphanumeric_ids bool
 	is_global              bool
 	searchable             bool
+
+	// indexes are fields (by json name, "." for nested) that the database should
+	// index, each entry being one (possibly compound) index.
+	indexes [][]string
+
+	// references are fields holding the id of another object in the same world
+	references []*Reference
+}
+
+// Reference is a field (by json name, "." for nested) of a kind that holds the id of an object
+// of another kind.
+type Reference struct {
+	Field string
+	Kind  string
 }
 
 func NewKind(obj v1.Object) *kindBuilder {
@@ -31,6 +45,23 @@ func (kb *kindBuilder) AllowAlphanumericIds() *kindBuilder {
 	return kb
 }
 
+// Index asks the database to index the given field(s) of the kind, in addition to the
+// indexes every kind has (ie. on Labels, _controller & _etag).
+func (kb *kindBuilder) Index(fields ...string) *kindBuilder {
+	if len(fields) > 0 {
+		kb.indexes = append(kb.indexes, fields)
+	}
+	return kb
+}
+
+// Reference declares that a field holds the id of an object of the given kind within the same
+// world (or a global object, if the kind is global). Writes are refused if the object does not
+// exist, an empty field references nothing.
+func (kb *kindBuilder) Reference(field, kind string) *kindBuilder {
+	kb.references = append(kb.references, &Reference{Field: field, Kind: kind})
+	return kb
+}
+
 func (kb *kindBuilder) Short(short string) *kindBuilder {
 	kb.short = short
 	return kb
@@ -78,6 +78,24 @@ func Kinds() []string {
 	return keys
 }
 
+// Type returns the (struct) type of objects of a kind, or nil if the kind is not registered
+func Type(kind string) reflect.Type {
+	kb, ok := manager.kinds[kind]
+	if !ok {
+		return nil
+	}
+	return reflect.TypeOf(kb.o).Elem()
+}
+
+// AlphanumericIds returns if objects of a kind have alphanumeric ids rather than UUIDs
+func AlphanumericIds(kind string) bool {
+	kb, ok := manager.kinds[kind]
+	if !ok {
+		return false
+	}
+	return kb.allow_alphanumeric_ids
+}
+
 func IsValid(kind string) bool {
 	_, ok := manager.kinds[kind]
 	return ok
@@ -211,6 +229,24 @@ func Doc(kind string) string {
 	return kb.doc
 }
 
+// Indexes returns the extra indexes declared for a kind (see kindBuilder.Index)
+func Indexes(kind string) [][]string {
+	kb, ok := manager.kinds[kind]
+	if !ok {
+		return nil
+	}
+	return kb.indexes
+}
+
+// References returns the fields of a kind that reference other objects (see kindBuilder.Reference)
+func References(kind string) []*Reference {
+	kb, ok := manager.kinds[kind]
+	if !ok {
+		return nil
+	}
+	return kb.references
+}
+
 func Register(kb *kindBuilder) error {
 	if kb.o.GetKind() == "" || kb.o.GetKind() == "event" {
 		return fmt.Errorf("kind %s is reserved", kb.o.GetKind())
@@ -259,10 +295,13 @@ func init() {
 	log.Debug().Err(Register(culture)).Msg("Registered culture kind")
 
 	actor := NewKind(&v1.Actor{Meta: v1.Meta{Kind: "actor"}})
+	actor.Index("Race").Index("Culture").Index("Area")
+	actor.Reference("Race", "race").Reference("Culture", "culture") // nb. there is no area kind to check Area against
 	actor.Short("ac").Doc("An actor in the world")
 	log.Debug().Err(Register(actor)).Msg("Registered actor kind")
 
 	faction := NewKind(&v1.Faction{Meta: v1.Meta{Kind: "faction"}})
+	faction.Index("Headquarters.Area")
 	faction.Short("fa").Doc("A faction in the world")
 	log.Debug().Err(Register(faction)).Msg("Registered faction kind")
 }
@@ -0,0 +1,3597 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        (unknown)
+// source: pkg/proto/v1/api.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,json=Code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,json=Message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Change is an event raised when an object is written, deleted or an event is deferred.
+type Change struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	World         string                 `protobuf:"bytes,1,opt,name=world,proto3" json:"world,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Controller    string                 `protobuf:"bytes,3,opt,name=controller,proto3" json:"controller,omitempty"`
+	Id            string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Deleted       bool                   `protobuf:"varint,6,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Change) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+func (x *Change) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Change) GetController() string {
+	if x != nil {
+		return x.Controller
+	}
+	return ""
+}
+
+func (x *Change) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Change) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Change) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Change) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	World         string                 `protobuf:"bytes,1,opt,name=world,json=World,proto3" json:"world,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,json=Kind,proto3" json:"kind,omitempty"`
+	Ids           []string               `protobuf:"bytes,3,rep,name=ids,json=Id,proto3" json:"ids,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,json=Labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Selector      string                 `protobuf:"bytes,5,opt,name=selector,json=Selector,proto3" json:"selector,omitempty"`
+	Limit         int64                  `protobuf:"varint,6,opt,name=limit,json=Limit,proto3" json:"limit,omitempty"`
+	Offset        int64                  `protobuf:"varint,7,opt,name=offset,json=Offset,proto3" json:"offset,omitempty"`
+	Token         string                 `protobuf:"bytes,8,opt,name=token,json=Token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+func (x *GetRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *GetRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *GetRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *GetRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  []*Object              `protobuf:"bytes,1,rep,name=data,json=Data,proto3" json:"data,omitempty"`
+	Token string                 `protobuf:"bytes,2,opt,name=token,json=Token,proto3" json:"token,omitempty"`
+	// Sequence of the world the objects reflect, from which a watch may start.
+	Sequence      uint64 `protobuf:"varint,3,opt,name=sequence,json=Sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetResponse) GetData() []*Object {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type SetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	World         string                 `protobuf:"bytes,1,opt,name=world,json=World,proto3" json:"world,omitempty"`
+	Data          []*Object              `protobuf:"bytes,2,rep,name=data,json=Data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRequest) Reset() {
+	*x = SetRequest{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRequest) ProtoMessage() {}
+
+func (x *SetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
+func (*SetRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+func (x *SetRequest) GetData() []*Object {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Etags         map[string]string      `protobuf:"bytes,1,rep,name=etags,json=Etags,proto3" json:"etags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Conflicts     []string               `protobuf:"bytes,2,rep,name=conflicts,json=Conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetResponse) Reset() {
+	*x = SetResponse{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetResponse) ProtoMessage() {}
+
+func (x *SetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetResponse.ProtoReflect.Descriptor instead.
+func (*SetResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetResponse) GetEtags() map[string]string {
+	if x != nil {
+		return x.Etags
+	}
+	return nil
+}
+
+func (x *SetResponse) GetConflicts() []string {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	World         string                 `protobuf:"bytes,1,opt,name=world,json=World,proto3" json:"world,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,json=Kind,proto3" json:"kind,omitempty"`
+	Ids           []string               `protobuf:"bytes,3,rep,name=ids,json=Id,proto3" json:"ids,omitempty"`
+	Propagation   string                 `protobuf:"bytes,4,opt,name=propagation,json=Propagation,proto3" json:"propagation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *DeleteRequest) GetPropagation() string {
+	if x != nil {
+		return x.Propagation
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pending       []string               `protobuf:"bytes,1,rep,name=pending,json=Pending,proto3" json:"pending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteResponse) GetPending() []string {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	World         string                 `protobuf:"bytes,1,opt,name=world,json=World,proto3" json:"world,omitempty"`
+	Query         *Query                 `protobuf:"bytes,2,opt,name=query,json=Query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*Object              `protobuf:"bytes,1,rep,name=data,json=Data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchResponse) GetData() []*Object {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DeferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	World         string                 `protobuf:"bytes,1,opt,name=world,json=World,proto3" json:"world,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,json=Kind,proto3" json:"kind,omitempty"`
+	Controller    string                 `protobuf:"bytes,3,opt,name=controller,json=Controller,proto3" json:"controller,omitempty"`
+	Id            string                 `protobuf:"bytes,4,opt,name=id,json=Id,proto3" json:"id,omitempty"`
+	ToTick        uint64                 `protobuf:"varint,5,opt,name=to_tick,json=ToTick,proto3" json:"to_tick,omitempty"`
+	ByTick        uint64                 `protobuf:"varint,6,opt,name=by_tick,json=ByTick,proto3" json:"by_tick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeferRequest) Reset() {
+	*x = DeferRequest{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeferRequest) ProtoMessage() {}
+
+func (x *DeferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeferRequest.ProtoReflect.Descriptor instead.
+func (*DeferRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeferRequest) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+func (x *DeferRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DeferRequest) GetController() string {
+	if x != nil {
+		return x.Controller
+	}
+	return ""
+}
+
+func (x *DeferRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeferRequest) GetToTick() uint64 {
+	if x != nil {
+		return x.ToTick
+	}
+	return 0
+}
+
+func (x *DeferRequest) GetByTick() uint64 {
+	if x != nil {
+		return x.ByTick
+	}
+	return 0
+}
+
+type DeferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ToTick        uint64                 `protobuf:"varint,1,opt,name=to_tick,json=ToTick,proto3" json:"to_tick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeferResponse) Reset() {
+	*x = DeferResponse{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeferResponse) ProtoMessage() {}
+
+func (x *DeferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeferResponse.ProtoReflect.Descriptor instead.
+func (*DeferResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeferResponse) GetToTick() uint64 {
+	if x != nil {
+		return x.ToTick
+	}
+	return 0
+}
+
+type OnChangeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Data     *Change                `protobuf:"bytes,1,opt,name=data,json=Data,proto3" json:"data,omitempty"`
+	Selector string                 `protobuf:"bytes,2,opt,name=selector,json=Selector,proto3" json:"selector,omitempty"`
+	// Queue name to listen on, if set implies durable subscription & that events
+	// must be acknowledged.
+	Queue string `protobuf:"bytes,3,opt,name=queue,json=Queue,proto3" json:"queue,omitempty"`
+	// FromSequence replays recent changes of the world after the given sequence
+	// (see GetResponse) before live events.
+	FromSequence  uint64 `protobuf:"varint,4,opt,name=from_sequence,json=FromSequence,proto3" json:"from_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChangeRequest) Reset() {
+	*x = OnChangeRequest{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChangeRequest) ProtoMessage() {}
+
+func (x *OnChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChangeRequest.ProtoReflect.Descriptor instead.
+func (*OnChangeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OnChangeRequest) GetData() *Change {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *OnChangeRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *OnChangeRequest) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *OnChangeRequest) GetFromSequence() uint64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
+
+type OnChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *Change                `protobuf:"bytes,1,opt,name=data,json=Data,proto3" json:"data,omitempty"`
+	Ack           string                 `protobuf:"bytes,2,opt,name=ack,json=Ack,proto3" json:"ack,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,json=Error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChangeResponse) Reset() {
+	*x = OnChangeResponse{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChangeResponse) ProtoMessage() {}
+
+func (x *OnChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChangeResponse.ProtoReflect.Descriptor instead.
+func (*OnChangeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OnChangeResponse) GetData() *Change {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *OnChangeResponse) GetAck() string {
+	if x != nil {
+		return x.Ack
+	}
+	return ""
+}
+
+func (x *OnChangeResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type AckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ack           []string               `protobuf:"bytes,1,rep,name=ack,json=Ack,proto3" json:"ack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckRequest) Reset() {
+	*x = AckRequest{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckRequest) ProtoMessage() {}
+
+func (x *AckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckRequest.ProtoReflect.Descriptor instead.
+func (*AckRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AckRequest) GetAck() []string {
+	if x != nil {
+		return x.Ack
+	}
+	return nil
+}
+
+type AckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Acked         int64                  `protobuf:"varint,1,opt,name=acked,json=Acked,proto3" json:"acked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckResponse) Reset() {
+	*x = AckResponse{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckResponse) ProtoMessage() {}
+
+func (x *AckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckResponse.ProtoReflect.Descriptor instead.
+func (*AckResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AckResponse) GetAcked() int64 {
+	if x != nil {
+		return x.Acked
+	}
+	return 0
+}
+
+// Object holds one object of any kind, the field set is named for the kind.
+type Object struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Object:
+	//
+	//	*Object_World
+	//	*Object_Config
+	//	*Object_Race
+	//	*Object_Culture
+	//	*Object_Actor
+	//	*Object_Faction
+	Object        isObject_Object `protobuf_oneof:"object"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Object) Reset() {
+	*x = Object{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Object) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Object) ProtoMessage() {}
+
+func (x *Object) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Object.ProtoReflect.Descriptor instead.
+func (*Object) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Object) GetObject() isObject_Object {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+func (x *Object) GetWorld() *World {
+	if x != nil {
+		if x, ok := x.Object.(*Object_World); ok {
+			return x.World
+		}
+	}
+	return nil
+}
+
+func (x *Object) GetConfig() *Config {
+	if x != nil {
+		if x, ok := x.Object.(*Object_Config); ok {
+			return x.Config
+		}
+	}
+	return nil
+}
+
+func (x *Object) GetRace() *Race {
+	if x != nil {
+		if x, ok := x.Object.(*Object_Race); ok {
+			return x.Race
+		}
+	}
+	return nil
+}
+
+func (x *Object) GetCulture() *Culture {
+	if x != nil {
+		if x, ok := x.Object.(*Object_Culture); ok {
+			return x.Culture
+		}
+	}
+	return nil
+}
+
+func (x *Object) GetActor() *Actor {
+	if x != nil {
+		if x, ok := x.Object.(*Object_Actor); ok {
+			return x.Actor
+		}
+	}
+	return nil
+}
+
+func (x *Object) GetFaction() *Faction {
+	if x != nil {
+		if x, ok := x.Object.(*Object_Faction); ok {
+			return x.Faction
+		}
+	}
+	return nil
+}
+
+type isObject_Object interface {
+	isObject_Object()
+}
+
+type Object_World struct {
+	World *World `protobuf:"bytes,1,opt,name=world,proto3,oneof"`
+}
+
+type Object_Config struct {
+	Config *Config `protobuf:"bytes,2,opt,name=config,proto3,oneof"`
+}
+
+type Object_Race struct {
+	Race *Race `protobuf:"bytes,3,opt,name=race,proto3,oneof"`
+}
+
+type Object_Culture struct {
+	Culture *Culture `protobuf:"bytes,4,opt,name=culture,proto3,oneof"`
+}
+
+type Object_Actor struct {
+	Actor *Actor `protobuf:"bytes,5,opt,name=actor,proto3,oneof"`
+}
+
+type Object_Faction struct {
+	Faction *Faction `protobuf:"bytes,6,opt,name=faction,proto3,oneof"`
+}
+
+func (*Object_World) isObject_Object() {}
+
+func (*Object_Config) isObject_Object() {}
+
+func (*Object_Race) isObject_Object() {}
+
+func (*Object_Culture) isObject_Object() {}
+
+func (*Object_Actor) isObject_Object() {}
+
+func (*Object_Faction) isObject_Object() {}
+
+// Meta is inlined into the JSON form of each kind.
+type Meta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,json=_id,proto3" json:"id,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,json=_etag,proto3" json:"etag,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,json=_kind,proto3" json:"kind,omitempty"`
+	Controller    string                 `protobuf:"bytes,4,opt,name=controller,json=_controller,proto3" json:"controller,omitempty"`
+	World         string                 `protobuf:"bytes,5,opt,name=world,json=World,proto3" json:"world,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,6,rep,name=labels,json=Labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Attributes    map[string]float64     `protobuf:"bytes,7,rep,name=attributes,json=Attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Owners        []*Owner               `protobuf:"bytes,8,rep,name=owners,json=Owners,proto3" json:"owners,omitempty"`
+	Finalizers    []string               `protobuf:"bytes,9,rep,name=finalizers,json=Finalizers,proto3" json:"finalizers,omitempty"`
+	DeletionTick  *uint64                `protobuf:"varint,10,opt,name=deletion_tick,json=DeletionTick,proto3,oneof" json:"deletion_tick,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,11,opt,name=sequence,json=_seq,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Meta) Reset() {
+	*x = Meta{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Meta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Meta) ProtoMessage() {}
+
+func (x *Meta) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Meta.ProtoReflect.Descriptor instead.
+func (*Meta) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Meta) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Meta) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *Meta) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Meta) GetController() string {
+	if x != nil {
+		return x.Controller
+	}
+	return ""
+}
+
+func (x *Meta) GetWorld() string {
+	if x != nil {
+		return x.World
+	}
+	return ""
+}
+
+func (x *Meta) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Meta) GetAttributes() map[string]float64 {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Meta) GetOwners() []*Owner {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *Meta) GetFinalizers() []string {
+	if x != nil {
+		return x.Finalizers
+	}
+	return nil
+}
+
+func (x *Meta) GetDeletionTick() uint64 {
+	if x != nil && x.DeletionTick != nil {
+		return *x.DeletionTick
+	}
+	return 0
+}
+
+func (x *Meta) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type Owner struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,json=Kind,proto3" json:"kind,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,json=Id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Owner) Reset() {
+	*x = Owner{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Owner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Owner) ProtoMessage() {}
+
+func (x *Owner) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Owner.ProtoReflect.Descriptor instead.
+func (*Owner) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Owner) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Owner) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SetMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,json=Labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Attributes    map[string]float64     `protobuf:"bytes,2,rep,name=attributes,json=Attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Controller    string                 `protobuf:"bytes,3,opt,name=controller,json=Controller,proto3" json:"controller,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMeta) Reset() {
+	*x = SetMeta{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMeta) ProtoMessage() {}
+
+func (x *SetMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMeta.ProtoReflect.Descriptor instead.
+func (*SetMeta) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetMeta) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SetMeta) GetAttributes() map[string]float64 {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *SetMeta) GetController() string {
+	if x != nil {
+		return x.Controller
+	}
+	return ""
+}
+
+type Distribution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Min           float64                `protobuf:"fixed64,1,opt,name=min,json=Min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,2,opt,name=max,json=Max,proto3" json:"max,omitempty"`
+	Mean          float64                `protobuf:"fixed64,3,opt,name=mean,json=Mean,proto3" json:"mean,omitempty"`
+	Deviation     float64                `protobuf:"fixed64,4,opt,name=deviation,json=Deviation,proto3" json:"deviation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Distribution) Reset() {
+	*x = Distribution{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Distribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Distribution) ProtoMessage() {}
+
+func (x *Distribution) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Distribution.ProtoReflect.Descriptor instead.
+func (*Distribution) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Distribution) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *Distribution) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *Distribution) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *Distribution) GetDeviation() float64 {
+	if x != nil {
+		return x.Deviation
+	}
+	return 0
+}
+
+type World struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Meta                *Meta                  `protobuf:"bytes,1,opt,name=meta,json=Meta,proto3" json:"meta,omitempty"`
+	Tick                uint64                 `protobuf:"varint,2,opt,name=tick,json=Tick,proto3" json:"tick,omitempty"`
+	History             int64                  `protobuf:"varint,3,opt,name=history,json=History,proto3" json:"history,omitempty"`
+	SkipReferenceChecks bool                   `protobuf:"varint,4,opt,name=skip_reference_checks,json=SkipReferenceChecks,proto3" json:"skip_reference_checks,omitempty"`
+	Quota               *Quota                 `protobuf:"bytes,5,opt,name=quota,json=Quota,proto3" json:"quota,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *World) Reset() {
+	*x = World{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *World) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*World) ProtoMessage() {}
+
+func (x *World) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use World.ProtoReflect.Descriptor instead.
+func (*World) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *World) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *World) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *World) GetHistory() int64 {
+	if x != nil {
+		return x.History
+	}
+	return 0
+}
+
+func (x *World) GetSkipReferenceChecks() bool {
+	if x != nil {
+		return x.SkipReferenceChecks
+	}
+	return false
+}
+
+func (x *World) GetQuota() *Quota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+type Quota struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Objects         map[string]int64       `protobuf:"bytes,1,rep,name=objects,json=Objects,proto3" json:"objects,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	DeferredPerTick int64                  `protobuf:"varint,2,opt,name=deferred_per_tick,json=DeferredPerTick,proto3" json:"deferred_per_tick,omitempty"`
+	Watchers        int64                  `protobuf:"varint,3,opt,name=watchers,json=Watchers,proto3" json:"watchers,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Quota) GetObjects() map[string]int64 {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+func (x *Quota) GetDeferredPerTick() int64 {
+	if x != nil {
+		return x.DeferredPerTick
+	}
+	return 0
+}
+
+func (x *Quota) GetWatchers() int64 {
+	if x != nil {
+		return x.Watchers
+	}
+	return 0
+}
+
+type Config struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *Meta                  `protobuf:"bytes,1,opt,name=meta,json=Meta,proto3" json:"meta,omitempty"`
+	Data          map[string]string      `protobuf:"bytes,2,rep,name=data,json=Data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Config) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Config) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type Race struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *Meta                  `protobuf:"bytes,1,opt,name=meta,json=Meta,proto3" json:"meta,omitempty"`
+	Base          *RaceCaste             `protobuf:"bytes,2,opt,name=base,json=Default,proto3" json:"base,omitempty"`
+	Castes        map[string]*RaceCaste  `protobuf:"bytes,3,rep,name=castes,json=Castes,proto3" json:"castes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Race) Reset() {
+	*x = Race{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Race) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Race) ProtoMessage() {}
+
+func (x *Race) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Race.ProtoReflect.Descriptor instead.
+func (*Race) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Race) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Race) GetBase() *RaceCaste {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *Race) GetCastes() map[string]*RaceCaste {
+	if x != nil {
+		return x.Castes
+	}
+	return nil
+}
+
+type RaceCaste struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Set           *SetMeta               `protobuf:"bytes,1,opt,name=set,json=Set,proto3" json:"set,omitempty"`
+	Probability   float64                `protobuf:"fixed64,2,opt,name=probability,json=Probability,proto3" json:"probability,omitempty"`
+	Lifespan      *Distribution          `protobuf:"bytes,3,opt,name=lifespan,json=Lifespan,proto3" json:"lifespan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RaceCaste) Reset() {
+	*x = RaceCaste{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RaceCaste) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaceCaste) ProtoMessage() {}
+
+func (x *RaceCaste) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaceCaste.ProtoReflect.Descriptor instead.
+func (*RaceCaste) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RaceCaste) GetSet() *SetMeta {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *RaceCaste) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *RaceCaste) GetLifespan() *Distribution {
+	if x != nil {
+		return x.Lifespan
+	}
+	return nil
+}
+
+type Culture struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Meta          *Meta                    `protobuf:"bytes,1,opt,name=meta,json=Meta,proto3" json:"meta,omitempty"`
+	Base          *CultureCaste            `protobuf:"bytes,2,opt,name=base,json=Base,proto3" json:"base,omitempty"`
+	Castes        map[string]*CultureCaste `protobuf:"bytes,3,rep,name=castes,json=Castes,proto3" json:"castes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Culture) Reset() {
+	*x = Culture{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Culture) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Culture) ProtoMessage() {}
+
+func (x *Culture) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Culture.ProtoReflect.Descriptor instead.
+func (*Culture) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Culture) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Culture) GetBase() *CultureCaste {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *Culture) GetCastes() map[string]*CultureCaste {
+	if x != nil {
+		return x.Castes
+	}
+	return nil
+}
+
+type CultureCaste struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Set           *SetMeta                    `protobuf:"bytes,1,opt,name=set,json=Set,proto3" json:"set,omitempty"`
+	NamingScheme  string                      `protobuf:"bytes,2,opt,name=naming_scheme,json=NamingScheme,proto3" json:"naming_scheme,omitempty"`
+	Family        map[string]*FamilyStructure `protobuf:"bytes,3,rep,name=family,json=Family,proto3" json:"family,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Actions       *ActionSelection            `protobuf:"bytes,4,opt,name=actions,json=Actions,proto3" json:"actions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CultureCaste) Reset() {
+	*x = CultureCaste{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CultureCaste) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CultureCaste) ProtoMessage() {}
+
+func (x *CultureCaste) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CultureCaste.ProtoReflect.Descriptor instead.
+func (*CultureCaste) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CultureCaste) GetSet() *SetMeta {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *CultureCaste) GetNamingScheme() string {
+	if x != nil {
+		return x.NamingScheme
+	}
+	return ""
+}
+
+func (x *CultureCaste) GetFamily() map[string]*FamilyStructure {
+	if x != nil {
+		return x.Family
+	}
+	return nil
+}
+
+func (x *CultureCaste) GetActions() *ActionSelection {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+type FamilyStructure struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Set                *SetMeta               `protobuf:"bytes,1,opt,name=set,json=Set,proto3" json:"set,omitempty"`
+	Probability        float64                `protobuf:"fixed64,2,opt,name=probability,json=Probability,proto3" json:"probability,omitempty"`
+	Adults             map[string]*FamilyUnit `protobuf:"bytes,3,rep,name=adults,json=Adults,proto3" json:"adults,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Lifespan           *Distribution          `protobuf:"bytes,4,opt,name=lifespan,json=Lifespan,proto3" json:"lifespan,omitempty"`
+	ChildrenRace       string                 `protobuf:"bytes,5,opt,name=children_race,json=ChildrenRace,proto3" json:"children_race,omitempty"`
+	ChildrenPerCycle   *Distribution          `protobuf:"bytes,6,opt,name=children_per_cycle,json=ChildrenPerCycle,proto3" json:"children_per_cycle,omitempty"`
+	BreedingCycles     *Distribution          `protobuf:"bytes,7,opt,name=breeding_cycles,json=BreedingCycles,proto3" json:"breeding_cycles,omitempty"`
+	TicksBetweenCycles *Distribution          `protobuf:"bytes,8,opt,name=ticks_between_cycles,json=TicksBetweenCycles,proto3" json:"ticks_between_cycles,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *FamilyStructure) Reset() {
+	*x = FamilyStructure{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FamilyStructure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FamilyStructure) ProtoMessage() {}
+
+func (x *FamilyStructure) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FamilyStructure.ProtoReflect.Descriptor instead.
+func (*FamilyStructure) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FamilyStructure) GetSet() *SetMeta {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *FamilyStructure) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *FamilyStructure) GetAdults() map[string]*FamilyUnit {
+	if x != nil {
+		return x.Adults
+	}
+	return nil
+}
+
+func (x *FamilyStructure) GetLifespan() *Distribution {
+	if x != nil {
+		return x.Lifespan
+	}
+	return nil
+}
+
+func (x *FamilyStructure) GetChildrenRace() string {
+	if x != nil {
+		return x.ChildrenRace
+	}
+	return ""
+}
+
+func (x *FamilyStructure) GetChildrenPerCycle() *Distribution {
+	if x != nil {
+		return x.ChildrenPerCycle
+	}
+	return nil
+}
+
+func (x *FamilyStructure) GetBreedingCycles() *Distribution {
+	if x != nil {
+		return x.BreedingCycles
+	}
+	return nil
+}
+
+func (x *FamilyStructure) GetTicksBetweenCycles() *Distribution {
+	if x != nil {
+		return x.TicksBetweenCycles
+	}
+	return nil
+}
+
+type FamilyUnit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Select        *Query                 `protobuf:"bytes,1,opt,name=select,json=Select,proto3" json:"select,omitempty"`
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,json=Weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FamilyUnit) Reset() {
+	*x = FamilyUnit{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FamilyUnit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FamilyUnit) ProtoMessage() {}
+
+func (x *FamilyUnit) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FamilyUnit.ProtoReflect.Descriptor instead.
+func (*FamilyUnit) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FamilyUnit) GetSelect() *Query {
+	if x != nil {
+		return x.Select
+	}
+	return nil
+}
+
+func (x *FamilyUnit) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type Actor struct {
+	state         protoimpl.MessageState           `protogen:"open.v1"`
+	Meta          *Meta                            `protobuf:"bytes,1,opt,name=meta,json=Meta,proto3" json:"meta,omitempty"`
+	Firstname     string                           `protobuf:"bytes,2,opt,name=firstname,json=Firstname,proto3" json:"firstname,omitempty"`
+	Lastname      string                           `protobuf:"bytes,3,opt,name=lastname,json=Lastname,proto3" json:"lastname,omitempty"`
+	Race          string                           `protobuf:"bytes,4,opt,name=race,json=Race,proto3" json:"race,omitempty"`
+	Culture       string                           `protobuf:"bytes,5,opt,name=culture,json=Culture,proto3" json:"culture,omitempty"`
+	Area          string                           `protobuf:"bytes,6,opt,name=area,json=Area,proto3" json:"area,omitempty"`
+	Ethos         map[string]float64               `protobuf:"bytes,7,rep,name=ethos,json=Ethos,proto3" json:"ethos,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Professions   map[string]*ActorValueProfession `protobuf:"bytes,8,rep,name=professions,json=Professions,proto3" json:"professions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Ranks         map[string]*ActorValueRank       `protobuf:"bytes,9,rep,name=ranks,json=Ranks,proto3" json:"ranks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Actor) Reset() {
+	*x = Actor{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Actor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Actor) ProtoMessage() {}
+
+func (x *Actor) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Actor.ProtoReflect.Descriptor instead.
+func (*Actor) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Actor) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Actor) GetFirstname() string {
+	if x != nil {
+		return x.Firstname
+	}
+	return ""
+}
+
+func (x *Actor) GetLastname() string {
+	if x != nil {
+		return x.Lastname
+	}
+	return ""
+}
+
+func (x *Actor) GetRace() string {
+	if x != nil {
+		return x.Race
+	}
+	return ""
+}
+
+func (x *Actor) GetCulture() string {
+	if x != nil {
+		return x.Culture
+	}
+	return ""
+}
+
+func (x *Actor) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *Actor) GetEthos() map[string]float64 {
+	if x != nil {
+		return x.Ethos
+	}
+	return nil
+}
+
+func (x *Actor) GetProfessions() map[string]*ActorValueProfession {
+	if x != nil {
+		return x.Professions
+	}
+	return nil
+}
+
+func (x *Actor) GetRanks() map[string]*ActorValueRank {
+	if x != nil {
+		return x.Ranks
+	}
+	return nil
+}
+
+type ActorValueProfession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,json=Name,proto3" json:"name,omitempty"`
+	Level         int64                  `protobuf:"varint,2,opt,name=level,json=Level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActorValueProfession) Reset() {
+	*x = ActorValueProfession{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorValueProfession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorValueProfession) ProtoMessage() {}
+
+func (x *ActorValueProfession) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorValueProfession.ProtoReflect.Descriptor instead.
+func (*ActorValueProfession) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ActorValueProfession) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ActorValueProfession) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type ActorValueRank struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,json=Name,proto3" json:"name,omitempty"`
+	Level         int64                  `protobuf:"varint,2,opt,name=level,json=Level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActorValueRank) Reset() {
+	*x = ActorValueRank{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorValueRank) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorValueRank) ProtoMessage() {}
+
+func (x *ActorValueRank) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorValueRank.ProtoReflect.Descriptor instead.
+func (*ActorValueRank) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ActorValueRank) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ActorValueRank) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type Faction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *Meta                  `protobuf:"bytes,1,opt,name=meta,json=Meta,proto3" json:"meta,omitempty"`
+	Health        *FactionHealth         `protobuf:"bytes,2,opt,name=health,json=Health,proto3" json:"health,omitempty"`
+	Bonus         *FactionBonus          `protobuf:"bytes,3,opt,name=bonus,json=Bonus,proto3" json:"bonus,omitempty"`
+	Headquarters  *Headquarters          `protobuf:"bytes,4,opt,name=headquarters,json=Headquarters,proto3" json:"headquarters,omitempty"`
+	Actions       *ActionSelection       `protobuf:"bytes,5,opt,name=actions,json=Actions,proto3" json:"actions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Faction) Reset() {
+	*x = Faction{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Faction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Faction) ProtoMessage() {}
+
+func (x *Faction) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Faction.ProtoReflect.Descriptor instead.
+func (*Faction) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Faction) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Faction) GetHealth() *FactionHealth {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+func (x *Faction) GetBonus() *FactionBonus {
+	if x != nil {
+		return x.Bonus
+	}
+	return nil
+}
+
+func (x *Faction) GetHeadquarters() *Headquarters {
+	if x != nil {
+		return x.Headquarters
+	}
+	return nil
+}
+
+func (x *Faction) GetActions() *ActionSelection {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+type FactionBonus struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	YieldByProfession map[string]float64     `protobuf:"bytes,1,rep,name=yield_by_profession,json=YieldByProfession,proto3" json:"yield_by_profession,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Secrecy           *Distribution          `protobuf:"bytes,2,opt,name=secrecy,json=Secrecy,proto3" json:"secrecy,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FactionBonus) Reset() {
+	*x = FactionBonus{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FactionBonus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactionBonus) ProtoMessage() {}
+
+func (x *FactionBonus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FactionBonus.ProtoReflect.Descriptor instead.
+func (*FactionBonus) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FactionBonus) GetYieldByProfession() map[string]float64 {
+	if x != nil {
+		return x.YieldByProfession
+	}
+	return nil
+}
+
+func (x *FactionBonus) GetSecrecy() *Distribution {
+	if x != nil {
+		return x.Secrecy
+	}
+	return nil
+}
+
+type FactionHealth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wealth        float64                `protobuf:"fixed64,1,opt,name=wealth,json=Wealth,proto3" json:"wealth,omitempty"`
+	Corruption    float64                `protobuf:"fixed64,2,opt,name=corruption,json=Corruption,proto3" json:"corruption,omitempty"`
+	Cohesion      float64                `protobuf:"fixed64,3,opt,name=cohesion,json=Cohesion,proto3" json:"cohesion,omitempty"`
+	Secrecy       float64                `protobuf:"fixed64,4,opt,name=secrecy,json=Secrecy,proto3" json:"secrecy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FactionHealth) Reset() {
+	*x = FactionHealth{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FactionHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactionHealth) ProtoMessage() {}
+
+func (x *FactionHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FactionHealth.ProtoReflect.Descriptor instead.
+func (*FactionHealth) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *FactionHealth) GetWealth() float64 {
+	if x != nil {
+		return x.Wealth
+	}
+	return 0
+}
+
+func (x *FactionHealth) GetCorruption() float64 {
+	if x != nil {
+		return x.Corruption
+	}
+	return 0
+}
+
+func (x *FactionHealth) GetCohesion() float64 {
+	if x != nil {
+		return x.Cohesion
+	}
+	return 0
+}
+
+func (x *FactionHealth) GetSecrecy() float64 {
+	if x != nil {
+		return x.Secrecy
+	}
+	return 0
+}
+
+type Headquarters struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Area          string                 `protobuf:"bytes,1,opt,name=area,json=Area,proto3" json:"area,omitempty"`
+	Building      string                 `protobuf:"bytes,2,opt,name=building,json=Building,proto3" json:"building,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Headquarters) Reset() {
+	*x = Headquarters{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Headquarters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Headquarters) ProtoMessage() {}
+
+func (x *Headquarters) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Headquarters.ProtoReflect.Descriptor instead.
+func (*Headquarters) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Headquarters) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *Headquarters) GetBuilding() string {
+	if x != nil {
+		return x.Building
+	}
+	return ""
+}
+
+type ActionSelection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tags maps target id -> tag -> Tag
+	Tags          map[string]*structpb.Struct `protobuf:"bytes,1,rep,name=tags,json=Tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Actions       map[string]*ActionWeight    `protobuf:"bytes,2,rep,name=actions,json=Actions,proto3" json:"actions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Hook          *Hook                       `protobuf:"bytes,3,opt,name=hook,json=Hook,proto3" json:"hook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionSelection) Reset() {
+	*x = ActionSelection{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionSelection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionSelection) ProtoMessage() {}
+
+func (x *ActionSelection) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionSelection.ProtoReflect.Descriptor instead.
+func (*ActionSelection) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ActionSelection) GetTags() map[string]*structpb.Struct {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ActionSelection) GetActions() map[string]*ActionWeight {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *ActionSelection) GetHook() *Hook {
+	if x != nil {
+		return x.Hook
+	}
+	return nil
+}
+
+type Hook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Protocol      string                 `protobuf:"bytes,1,opt,name=protocol,json=Protocol,proto3" json:"protocol,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,json=Address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hook) Reset() {
+	*x = Hook{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hook) ProtoMessage() {}
+
+func (x *Hook) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hook.ProtoReflect.Descriptor instead.
+func (*Hook) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Hook) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *Hook) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type ActionWeight struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Probability   float64                `protobuf:"fixed64,1,opt,name=probability,json=Probability,proto3" json:"probability,omitempty"`
+	Tags          map[string]float64     `protobuf:"bytes,2,rep,name=tags,json=Tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionWeight) Reset() {
+	*x = ActionWeight{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionWeight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionWeight) ProtoMessage() {}
+
+func (x *ActionWeight) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionWeight.ProtoReflect.Descriptor instead.
+func (*ActionWeight) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ActionWeight) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *ActionWeight) GetTags() map[string]float64 {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Query is a query to search for results, the fields of the Filter are inlined.
+type Query struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	All           []*Match               `protobuf:"bytes,1,rep,name=all,json=All,proto3" json:"all,omitempty"`
+	Any           []*Match               `protobuf:"bytes,2,rep,name=any,json=Any,proto3" json:"any,omitempty"`
+	Not           []*Match               `protobuf:"bytes,3,rep,name=not,json=Not,proto3" json:"not,omitempty"`
+	Score         []*Score               `protobuf:"bytes,4,rep,name=score,json=Score,proto3" json:"score,omitempty"`
+	RandomWeight  float64                `protobuf:"fixed64,5,opt,name=random_weight,json=RandomWeight,proto3" json:"random_weight,omitempty"`
+	Limit         int64                  `protobuf:"varint,6,opt,name=limit,json=Limit,proto3" json:"limit,omitempty"`
+	Kind          string                 `protobuf:"bytes,7,opt,name=kind,json=Kind,proto3" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Query) Reset() {
+	*x = Query{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Query) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Query) ProtoMessage() {}
+
+func (x *Query) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Query.ProtoReflect.Descriptor instead.
+func (*Query) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Query) GetAll() []*Match {
+	if x != nil {
+		return x.All
+	}
+	return nil
+}
+
+func (x *Query) GetAny() []*Match {
+	if x != nil {
+		return x.Any
+	}
+	return nil
+}
+
+func (x *Query) GetNot() []*Match {
+	if x != nil {
+		return x.Not
+	}
+	return nil
+}
+
+func (x *Query) GetScore() []*Score {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+func (x *Query) GetRandomWeight() float64 {
+	if x != nil {
+		return x.RandomWeight
+	}
+	return 0
+}
+
+func (x *Query) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Query) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type Match struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,json=Field,proto3" json:"field,omitempty"`
+	Op            string                 `protobuf:"bytes,2,opt,name=op,json=Op,proto3" json:"op,omitempty"`
+	Value         *structpb.Value        `protobuf:"bytes,3,opt,name=value,json=Value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Match) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Match) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Match) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Score is a weight to apply to a match, the fields of the Match are inlined.
+type Score struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,json=Field,proto3" json:"field,omitempty"`
+	Op            string                 `protobuf:"bytes,2,opt,name=op,json=Op,proto3" json:"op,omitempty"`
+	Value         *structpb.Value        `protobuf:"bytes,3,opt,name=value,json=Value,proto3" json:"value,omitempty"`
+	Weight        float64                `protobuf:"fixed64,4,opt,name=weight,json=Weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Score) Reset() {
+	*x = Score{}
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Score) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Score) ProtoMessage() {}
+
+func (x *Score) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_v1_api_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Score.ProtoReflect.Descriptor instead.
+func (*Score) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_v1_api_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Score) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Score) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Score) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Score) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+var File_pkg_proto_v1_api_proto protoreflect.FileDescriptor
+
+var file_pkg_proto_v1_api_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x35, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x8b, 0x02, 0x0a, 0x06, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x36,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x1a, 0x39, 0x0a, 0x0b,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9e, 0x02, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4b, 0x69, 0x6e, 0x64,
+	0x12, 0x0f, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x49,
+	0x64, 0x12, 0x3a, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x39, 0x0a,
+	0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x67, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x22, 0x4a, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x26, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x22, 0x9f, 0x01,
+	0x0a, 0x0b, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a,
+	0x05, 0x65, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x74, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x05, 0x45, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x6c,
+	0x69, 0x63, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x43, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x45, 0x74, 0x61, 0x67, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x6c, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0f, 0x0a, 0x03, 0x69, 0x64,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x70,
+	0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x50, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2a, 0x0a,
+	0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x4e, 0x0a, 0x0d, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f,
+	0x72, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x57, 0x6f, 0x72, 0x6c, 0x64,
+	0x12, 0x27, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x22, 0x38, 0x0a, 0x0e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x04, 0x44,
+	0x61, 0x74, 0x61, 0x22, 0x9a, 0x01, 0x0a, 0x0c, 0x44, 0x65, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x54, 0x6f, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x79, 0x5f, 0x74, 0x69,
+	0x63, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x42, 0x79, 0x54, 0x69, 0x63, 0x6b,
+	0x22, 0x28, 0x0a, 0x0d, 0x44, 0x65, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x54, 0x6f, 0x54, 0x69, 0x63, 0x6b, 0x22, 0x90, 0x01, 0x0a, 0x0f, 0x4f,
+	0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x75, 0x0a,
+	0x10, 0x4f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x26, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x41, 0x63, 0x6b, 0x12, 0x27, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0x1e, 0x0a, 0x0a, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x03, 0x41, 0x63, 0x6b, 0x22, 0x23, 0x0a, 0x0b, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x41, 0x63, 0x6b, 0x65, 0x64, 0x22, 0xa0, 0x02, 0x0a, 0x06, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x48, 0x00, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x12,
+	0x2c, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x26, 0x0a,
+	0x04, 0x72, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x48, 0x00, 0x52,
+	0x04, 0x72, 0x61, 0x63, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x75, 0x6c, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x6c, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07, 0x63,
+	0x75, 0x6c, 0x74, 0x75, 0x72, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x2f, 0x0a, 0x07, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x46, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x66, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x08, 0x0a, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x89, 0x04, 0x0a,
+	0x04, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x0f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x5f, 0x69, 0x64, 0x12, 0x13, 0x0a, 0x04, 0x65, 0x74, 0x61, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x5f, 0x65, 0x74, 0x61, 0x67, 0x12, 0x13, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x5f, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x1f, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x34, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x40, 0x0a,
+	0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x65, 0x74, 0x61, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0a, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12,
+	0x29, 0x0a, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x52, 0x06, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x72, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x0d, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x04, 0x48, 0x00, 0x52, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63,
+	0x6b, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x5f, 0x73, 0x65, 0x71, 0x1a, 0x39, 0x0a, 0x0b,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x22, 0x2b, 0x0a, 0x05, 0x4f, 0x77, 0x6e, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x49, 0x64, 0x22, 0xa1, 0x02, 0x0a, 0x07, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x74,
+	0x61, 0x12, 0x37, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x43, 0x0a, 0x0a, 0x61, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4d,
+	0x65, 0x74, 0x61, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0a, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x1a,
+	0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x64, 0x0a, 0x0c, 0x44, 0x69, 0x73,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4d, 0x61, 0x78, 0x12, 0x12, 0x0a,
+	0x04, 0x6d, 0x65, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x4d, 0x65, 0x61,
+	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x44, 0x65, 0x76, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0xb8, 0x01, 0x0a, 0x05, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x6d, 0x65, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x54,
+	0x69, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x32, 0x0a,
+	0x15, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x53, 0x6b,
+	0x69, 0x70, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x12, 0x27, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75,
+	0x6f, 0x74, 0x61, 0x52, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x22, 0xc5, 0x01, 0x0a, 0x05, 0x51,
+	0x75, 0x6f, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x2a,
+	0x0a, 0x11, 0x64, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x74,
+	0x69, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x44, 0x65, 0x66, 0x65, 0x72,
+	0x72, 0x65, 0x64, 0x50, 0x65, 0x72, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x72, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x99, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x0a,
+	0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x4d,
+	0x65, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x04, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x37, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe2,
+	0x01, 0x0a, 0x04, 0x52, 0x61, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x2c, 0x0a,
+	0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x43, 0x61, 0x73,
+	0x74, 0x65, 0x52, 0x07, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x34, 0x0a, 0x06, 0x63,
+	0x61, 0x73, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x66, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x63, 0x65, 0x2e, 0x43, 0x61,
+	0x73, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x43, 0x61, 0x73, 0x74, 0x65,
+	0x73, 0x1a, 0x50, 0x0a, 0x0b, 0x43, 0x61, 0x73, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x61, 0x63, 0x65, 0x43, 0x61, 0x73, 0x74, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x8a, 0x01, 0x0a, 0x09, 0x52, 0x61, 0x63, 0x65, 0x43, 0x61, 0x73, 0x74,
+	0x65, 0x12, 0x25, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4d,
+	0x65, 0x74, 0x61, 0x52, 0x03, 0x53, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x50,
+	0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x34, 0x0a, 0x08, 0x6c, 0x69,
+	0x66, 0x65, 0x73, 0x70, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x4c, 0x69, 0x66, 0x65, 0x73, 0x70, 0x61, 0x6e,
+	0x22, 0xeb, 0x01, 0x0a, 0x07, 0x43, 0x75, 0x6c, 0x74, 0x75, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x04,
+	0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x4d, 0x65,
+	0x74, 0x61, 0x12, 0x2c, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75,
+	0x6c, 0x74, 0x75, 0x72, 0x65, 0x43, 0x61, 0x73, 0x74, 0x65, 0x52, 0x04, 0x42, 0x61, 0x73, 0x65,
+	0x12, 0x37, 0x0a, 0x06, 0x63, 0x61, 0x73, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75,
+	0x6c, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x43, 0x61, 0x73, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x43, 0x61, 0x73, 0x74, 0x65, 0x73, 0x1a, 0x53, 0x0a, 0x0b, 0x43, 0x61, 0x73,
+	0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x6c, 0x74, 0x75, 0x72, 0x65, 0x43, 0x61,
+	0x73, 0x74, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa7,
+	0x02, 0x0a, 0x0c, 0x43, 0x75, 0x6c, 0x74, 0x75, 0x72, 0x65, 0x43, 0x61, 0x73, 0x74, 0x65, 0x12,
+	0x25, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x74,
+	0x61, 0x52, 0x03, 0x53, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x61, 0x6d, 0x69, 0x6e, 0x67,
+	0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x4e,
+	0x61, 0x6d, 0x69, 0x6e, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x06, 0x66,
+	0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x66, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x6c, 0x74, 0x75, 0x72, 0x65,
+	0x43, 0x61, 0x73, 0x74, 0x65, 0x2e, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x35, 0x0a, 0x07, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x1a, 0x56, 0x0a, 0x0b, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61,
+	0x6d, 0x69, 0x6c, 0x79, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa0, 0x04, 0x0a, 0x0f, 0x46, 0x61, 0x6d,
+	0x69, 0x6c, 0x79, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x25, 0x0a, 0x03,
+	0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x03,
+	0x53, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x06, 0x61, 0x64, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x2e, 0x41, 0x64, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x41, 0x64, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x6c, 0x69, 0x66, 0x65, 0x73, 0x70,
+	0x61, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x08, 0x4c, 0x69, 0x66, 0x65, 0x73, 0x70, 0x61, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x5f, 0x72, 0x61, 0x63, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x52, 0x61, 0x63,
+	0x65, 0x12, 0x46, 0x0a, 0x12, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x5f, 0x70, 0x65,
+	0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65,
+	0x6e, 0x50, 0x65, 0x72, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x41, 0x0a, 0x0f, 0x62, 0x72, 0x65,
+	0x65, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x42, 0x72,
+	0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x4a, 0x0a, 0x14,
+	0x74, 0x69, 0x63, 0x6b, 0x73, 0x5f, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x5f, 0x63, 0x79,
+	0x63, 0x6c, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x42, 0x65, 0x74, 0x77, 0x65,
+	0x65, 0x6e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x1a, 0x51, 0x0a, 0x0b, 0x41, 0x64, 0x75, 0x6c,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x55, 0x6e, 0x69, 0x74,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4f, 0x0a, 0x0a, 0x46,
+	0x61, 0x6d, 0x69, 0x6c, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x06, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xc9, 0x04, 0x0a,
+	0x05, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x24, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x46, 0x69, 0x72, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4c, 0x61,
+	0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x52, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75,
+	0x6c, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x75, 0x6c,
+	0x74, 0x75, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x65, 0x61, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x41, 0x72, 0x65, 0x61, 0x12, 0x32, 0x0a, 0x05, 0x65, 0x74, 0x68, 0x6f,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x45, 0x74, 0x68, 0x6f, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x45, 0x74, 0x68, 0x6f, 0x73, 0x12, 0x44, 0x0a, 0x0b,
+	0x70, 0x72, 0x6f, 0x66, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x63, 0x74, 0x6f, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x50, 0x72, 0x6f, 0x66, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x32, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x63, 0x74, 0x6f, 0x72, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x05, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x45, 0x74, 0x68, 0x6f, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x60, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x66, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x36, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x50, 0x72, 0x6f,
+	0x66, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x54, 0x0a, 0x0a, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x63, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x14, 0x41, 0x63, 0x74, 0x6f,
+	0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x3a, 0x0a, 0x0e, 0x41, 0x63,
+	0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x87, 0x02, 0x0a, 0x07, 0x46, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65,
+	0x74, 0x61, 0x52, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x31, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x52, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x2e, 0x0a, 0x05, 0x62,
+	0x6f, 0x6e, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x6f, 0x6e, 0x75, 0x73, 0x52, 0x05, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x68,
+	0x65, 0x61, 0x64, 0x71, 0x75, 0x61, 0x72, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x65, 0x61, 0x64, 0x71, 0x75, 0x61, 0x72, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0c, 0x48, 0x65, 0x61,
+	0x64, 0x71, 0x75, 0x61, 0x72, 0x74, 0x65, 0x72, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x66, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0xe9, 0x01, 0x0a, 0x0c, 0x46, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6f, 0x6e, 0x75,
+	0x73, 0x12, 0x5f, 0x0a, 0x13, 0x79, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x62, 0x79, 0x5f, 0x70, 0x72,
+	0x6f, 0x66, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f,
+	0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x2e, 0x59, 0x69, 0x65, 0x6c, 0x64, 0x42, 0x79,
+	0x50, 0x72, 0x6f, 0x66, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x11, 0x59, 0x69, 0x65, 0x6c, 0x64, 0x42, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x63, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x63, 0x79, 0x1a, 0x44, 0x0a, 0x16, 0x59, 0x69, 0x65, 0x6c, 0x64, 0x42,
+	0x79, 0x50, 0x72, 0x6f, 0x66, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7d, 0x0a, 0x0d,
+	0x46, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x77, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x57,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x43, 0x6f, 0x72, 0x72, 0x75,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x68, 0x65, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x43, 0x6f, 0x68, 0x65, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x07, 0x53, 0x65, 0x63, 0x72, 0x65, 0x63, 0x79, 0x22, 0x3e, 0x0a, 0x0c, 0x48,
+	0x65, 0x61, 0x64, 0x71, 0x75, 0x61, 0x72, 0x74, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61,
+	0x72, 0x65, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x41, 0x72, 0x65, 0x61, 0x12,
+	0x1a, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x22, 0xde, 0x02, 0x0a, 0x0f,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x39, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x42, 0x0a, 0x07, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x66, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24,
+	0x0a, 0x04, 0x68, 0x6f, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6f, 0x6b, 0x52, 0x04,
+	0x48, 0x6f, 0x6f, 0x6b, 0x1a, 0x50, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x54, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3c, 0x0a, 0x04,
+	0x48, 0x6f, 0x6f, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xa1, 0x01, 0x0a, 0x0c, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70,
+	0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x36, 0x0a,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x66, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x04, 0x54, 0x61, 0x67, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xee,
+	0x01, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x03, 0x41, 0x6c, 0x6c, 0x12, 0x23, 0x0a,
+	0x03, 0x61, 0x6e, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x03, 0x41,
+	0x6e, 0x79, 0x12, 0x23, 0x0a, 0x03, 0x6e, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x03, 0x4e, 0x6f, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x57,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x22,
+	0x5b, 0x0a, 0x05, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x4f, 0x70, 0x12, 0x2c,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x73, 0x0a, 0x05,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x4f, 0x70, 0x12, 0x2c, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x32, 0xbe, 0x03, 0x0a, 0x03, 0x41, 0x50, 0x49, 0x12, 0x36, 0x0a, 0x03, 0x47, 0x65, 0x74,
+	0x12, 0x16, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x36, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x16, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x12, 0x19, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x05, 0x44,
+	0x65, 0x66, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x08, 0x4f, 0x6e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1b, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x12, 0x3e, 0x0a, 0x09, 0x41, 0x63, 0x6b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x16, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x28, 0x01, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x76, 0x6f, 0x69, 0x64, 0x73, 0x68, 0x61, 0x72, 0x64, 0x2f, 0x66, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x3b,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_proto_v1_api_proto_rawDescOnce sync.Once
+	file_pkg_proto_v1_api_proto_rawDescData = file_pkg_proto_v1_api_proto_rawDesc
+)
+
+func file_pkg_proto_v1_api_proto_rawDescGZIP() []byte {
+	file_pkg_proto_v1_api_proto_rawDescOnce.Do(func() {
+		file_pkg_proto_v1_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_proto_v1_api_proto_rawDescData)
+	})
+	return file_pkg_proto_v1_api_proto_rawDescData
+}
+
+var file_pkg_proto_v1_api_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
+var file_pkg_proto_v1_api_proto_goTypes = []any{
+	(*Error)(nil),                // 0: faction.v1.Error
+	(*Change)(nil),               // 1: faction.v1.Change
+	(*GetRequest)(nil),           // 2: faction.v1.GetRequest
+	(*GetResponse)(nil),          // 3: faction.v1.GetResponse
+	(*SetRequest)(nil),           // 4: faction.v1.SetRequest
+	(*SetResponse)(nil),          // 5: faction.v1.SetResponse
+	(*DeleteRequest)(nil),        // 6: faction.v1.DeleteRequest
+	(*DeleteResponse)(nil),       // 7: faction.v1.DeleteResponse
+	(*SearchRequest)(nil),        // 8: faction.v1.SearchRequest
+	(*SearchResponse)(nil),       // 9: faction.v1.SearchResponse
+	(*DeferRequest)(nil),         // 10: faction.v1.DeferRequest
+	(*DeferResponse)(nil),        // 11: faction.v1.DeferResponse
+	(*OnChangeRequest)(nil),      // 12: faction.v1.OnChangeRequest
+	(*OnChangeResponse)(nil),     // 13: faction.v1.OnChangeResponse
+	(*AckRequest)(nil),           // 14: faction.v1.AckRequest
+	(*AckResponse)(nil),          // 15: faction.v1.AckResponse
+	(*Object)(nil),               // 16: faction.v1.Object
+	(*Meta)(nil),                 // 17: faction.v1.Meta
+	(*Owner)(nil),                // 18: faction.v1.Owner
+	(*SetMeta)(nil),              // 19: faction.v1.SetMeta
+	(*Distribution)(nil),         // 20: faction.v1.Distribution
+	(*World)(nil),                // 21: faction.v1.World
+	(*Quota)(nil),                // 22: faction.v1.Quota
+	(*Config)(nil),               // 23: faction.v1.Config
+	(*Race)(nil),                 // 24: faction.v1.Race
+	(*RaceCaste)(nil),            // 25: faction.v1.RaceCaste
+	(*Culture)(nil),              // 26: faction.v1.Culture
+	(*CultureCaste)(nil),         // 27: faction.v1.CultureCaste
+	(*FamilyStructure)(nil),      // 28: faction.v1.FamilyStructure
+	(*FamilyUnit)(nil),           // 29: faction.v1.FamilyUnit
+	(*Actor)(nil),                // 30: faction.v1.Actor
+	(*ActorValueProfession)(nil), // 31: faction.v1.ActorValueProfession
+	(*ActorValueRank)(nil),       // 32: faction.v1.ActorValueRank
+	(*Faction)(nil),              // 33: faction.v1.Faction
+	(*FactionBonus)(nil),         // 34: faction.v1.FactionBonus
+	(*FactionHealth)(nil),        // 35: faction.v1.FactionHealth
+	(*Headquarters)(nil),         // 36: faction.v1.Headquarters
+	(*ActionSelection)(nil),      // 37: faction.v1.ActionSelection
+	(*Hook)(nil),                 // 38: faction.v1.Hook
+	(*ActionWeight)(nil),         // 39: faction.v1.ActionWeight
+	(*Query)(nil),                // 40: faction.v1.Query
+	(*Match)(nil),                // 41: faction.v1.Match
+	(*Score)(nil),                // 42: faction.v1.Score
+	nil,                          // 43: faction.v1.Change.LabelsEntry
+	nil,                          // 44: faction.v1.GetRequest.LabelsEntry
+	nil,                          // 45: faction.v1.SetResponse.EtagsEntry
+	nil,                          // 46: faction.v1.Meta.LabelsEntry
+	nil,                          // 47: faction.v1.Meta.AttributesEntry
+	nil,                          // 48: faction.v1.SetMeta.LabelsEntry
+	nil,                          // 49: faction.v1.SetMeta.AttributesEntry
+	nil,                          // 50: faction.v1.Quota.ObjectsEntry
+	nil,                          // 51: faction.v1.Config.DataEntry
+	nil,                          // 52: faction.v1.Race.CastesEntry
+	nil,                          // 53: faction.v1.Culture.CastesEntry
+	nil,                          // 54: faction.v1.CultureCaste.FamilyEntry
+	nil,                          // 55: faction.v1.FamilyStructure.AdultsEntry
+	nil,                          // 56: faction.v1.Actor.EthosEntry
+	nil,                          // 57: faction.v1.Actor.ProfessionsEntry
+	nil,                          // 58: faction.v1.Actor.RanksEntry
+	nil,                          // 59: faction.v1.FactionBonus.YieldByProfessionEntry
+	nil,                          // 60: faction.v1.ActionSelection.TagsEntry
+	nil,                          // 61: faction.v1.ActionSelection.ActionsEntry
+	nil,                          // 62: faction.v1.ActionWeight.TagsEntry
+	(*structpb.Value)(nil),       // 63: google.protobuf.Value
+	(*structpb.Struct)(nil),      // 64: google.protobuf.Struct
+}
+var file_pkg_proto_v1_api_proto_depIdxs = []int32{
+	43, // 0: faction.v1.Change.labels:type_name -> faction.v1.Change.LabelsEntry
+	44, // 1: faction.v1.GetRequest.labels:type_name -> faction.v1.GetRequest.LabelsEntry
+	16, // 2: faction.v1.GetResponse.data:type_name -> faction.v1.Object
+	16, // 3: faction.v1.SetRequest.data:type_name -> faction.v1.Object
+	45, // 4: faction.v1.SetResponse.etags:type_name -> faction.v1.SetResponse.EtagsEntry
+	40, // 5: faction.v1.SearchRequest.query:type_name -> faction.v1.Query
+	16, // 6: faction.v1.SearchResponse.data:type_name -> faction.v1.Object
+	1,  // 7: faction.v1.OnChangeRequest.data:type_name -> faction.v1.Change
+	1,  // 8: faction.v1.OnChangeResponse.data:type_name -> faction.v1.Change
+	0,  // 9: faction.v1.OnChangeResponse.error:type_name -> faction.v1.Error
+	21, // 10: faction.v1.Object.world:type_name -> faction.v1.World
+	23, // 11: faction.v1.Object.config:type_name -> faction.v1.Config
+	24, // 12: faction.v1.Object.race:type_name -> faction.v1.Race
+	26, // 13: faction.v1.Object.culture:type_name -> faction.v1.Culture
+	30, // 14: faction.v1.Object.actor:type_name -> faction.v1.Actor
+	33, // 15: faction.v1.Object.faction:type_name -> faction.v1.Faction
+	46, // 16: faction.v1.Meta.labels:type_name -> faction.v1.Meta.LabelsEntry
+	47, // 17: faction.v1.Meta.attributes:type_name -> faction.v1.Meta.AttributesEntry
+	18, // 18: faction.v1.Meta.owners:type_name -> faction.v1.Owner
+	48, // 19: faction.v1.SetMeta.labels:type_name -> faction.v1.SetMeta.LabelsEntry
+	49, // 20: faction.v1.SetMeta.attributes:type_name -> faction.v1.SetMeta.AttributesEntry
+	17, // 21: faction.v1.World.meta:type_name -> faction.v1.Meta
+	22, // 22: faction.v1.World.quota:type_name -> faction.v1.Quota
+	50, // 23: faction.v1.Quota.objects:type_name -> faction.v1.Quota.ObjectsEntry
+	17, // 24: faction.v1.Config.meta:type_name -> faction.v1.Meta
+	51, // 25: faction.v1.Config.data:type_name -> faction.v1.Config.DataEntry
+	17, // 26: faction.v1.Race.meta:type_name -> faction.v1.Meta
+	25, // 27: faction.v1.Race.base:type_name -> faction.v1.RaceCaste
+	52, // 28: faction.v1.Race.castes:type_name -> faction.v1.Race.CastesEntry
+	19, // 29: faction.v1.RaceCaste.set:type_name -> faction.v1.SetMeta
+	20, // 30: faction.v1.RaceCaste.lifespan:type_name -> faction.v1.Distribution
+	17, // 31: faction.v1.Culture.meta:type_name -> faction.v1.Meta
+	27, // 32: faction.v1.Culture.base:type_name -> faction.v1.CultureCaste
+	53, // 33: faction.v1.Culture.castes:type_name -> faction.v1.Culture.CastesEntry
+	19, // 34: faction.v1.CultureCaste.set:type_name -> faction.v1.SetMeta
+	54, // 35: faction.v1.CultureCaste.family:type_name -> faction.v1.CultureCaste.FamilyEntry
+	37, // 36: faction.v1.CultureCaste.actions:type_name -> faction.v1.ActionSelection
+	19, // 37: faction.v1.FamilyStructure.set:type_name -> faction.v1.SetMeta
+	55, // 38: faction.v1.FamilyStructure.adults:type_name -> faction.v1.FamilyStructure.AdultsEntry
+	20, // 39: faction.v1.FamilyStructure.lifespan:type_name -> faction.v1.Distribution
+	20, // 40: faction.v1.FamilyStructure.children_per_cycle:type_name -> faction.v1.Distribution
+	20, // 41: faction.v1.FamilyStructure.breeding_cycles:type_name -> faction.v1.Distribution
+	20, // 42: faction.v1.FamilyStructure.ticks_between_cycles:type_name -> faction.v1.Distribution
+	40, // 43: faction.v1.FamilyUnit.select:type_name -> faction.v1.Query
+	17, // 44: faction.v1.Actor.meta:type_name -> faction.v1.Meta
+	56, // 45: faction.v1.Actor.ethos:type_name -> faction.v1.Actor.EthosEntry
+	57, // 46: faction.v1.Actor.professions:type_name -> faction.v1.Actor.ProfessionsEntry
+	58, // 47: faction.v1.Actor.ranks:type_name -> faction.v1.Actor.RanksEntry
+	17, // 48: faction.v1.Faction.meta:type_name -> faction.v1.Meta
+	35, // 49: faction.v1.Faction.health:type_name -> faction.v1.FactionHealth
+	34, // 50: faction.v1.Faction.bonus:type_name -> faction.v1.FactionBonus
+	36, // 51: faction.v1.Faction.headquarters:type_name -> faction.v1.Headquarters
+	37, // 52: faction.v1.Faction.actions:type_name -> faction.v1.ActionSelection
+	59, // 53: faction.v1.FactionBonus.yield_by_profession:type_name -> faction.v1.FactionBonus.YieldByProfessionEntry
+	20, // 54: faction.v1.FactionBonus.secrecy:type_name -> faction.v1.Distribution
+	60, // 55: faction.v1.ActionSelection.tags:type_name -> faction.v1.ActionSelection.TagsEntry
+	61, // 56: faction.v1.ActionSelection.actions:type_name -> faction.v1.ActionSelection.ActionsEntry
+	38, // 57: faction.v1.ActionSelection.hook:type_name -> faction.v1.Hook
+	62, // 58: faction.v1.ActionWeight.tags:type_name -> faction.v1.ActionWeight.TagsEntry
+	41, // 59: faction.v1.Query.all:type_name -> faction.v1.Match
+	41, // 60: faction.v1.Query.any:type_name -> faction.v1.Match
+	41, // 61: faction.v1.Query.not:type_name -> faction.v1.Match
+	42, // 62: faction.v1.Query.score:type_name -> faction.v1.Score
+	63, // 63: faction.v1.Match.value:type_name -> google.protobuf.Value
+	63, // 64: faction.v1.Score.value:type_name -> google.protobuf.Value
+	25, // 65: faction.v1.Race.CastesEntry.value:type_name -> faction.v1.RaceCaste
+	27, // 66: faction.v1.Culture.CastesEntry.value:type_name -> faction.v1.CultureCaste
+	28, // 67: faction.v1.CultureCaste.FamilyEntry.value:type_name -> faction.v1.FamilyStructure
+	29, // 68: faction.v1.FamilyStructure.AdultsEntry.value:type_name -> faction.v1.FamilyUnit
+	31, // 69: faction.v1.Actor.ProfessionsEntry.value:type_name -> faction.v1.ActorValueProfession
+	32, // 70: faction.v1.Actor.RanksEntry.value:type_name -> faction.v1.ActorValueRank
+	64, // 71: faction.v1.ActionSelection.TagsEntry.value:type_name -> google.protobuf.Struct
+	39, // 72: faction.v1.ActionSelection.ActionsEntry.value:type_name -> faction.v1.ActionWeight
+	2,  // 73: faction.v1.API.Get:input_type -> faction.v1.GetRequest
+	4,  // 74: faction.v1.API.Set:input_type -> faction.v1.SetRequest
+	6,  // 75: faction.v1.API.Delete:input_type -> faction.v1.DeleteRequest
+	8,  // 76: faction.v1.API.Search:input_type -> faction.v1.SearchRequest
+	10, // 77: faction.v1.API.Defer:input_type -> faction.v1.DeferRequest
+	12, // 78: faction.v1.API.OnChange:input_type -> faction.v1.OnChangeRequest
+	14, // 79: faction.v1.API.AckStream:input_type -> faction.v1.AckRequest
+	3,  // 80: faction.v1.API.Get:output_type -> faction.v1.GetResponse
+	5,  // 81: faction.v1.API.Set:output_type -> faction.v1.SetResponse
+	7,  // 82: faction.v1.API.Delete:output_type -> faction.v1.DeleteResponse
+	9,  // 83: faction.v1.API.Search:output_type -> faction.v1.SearchResponse
+	11, // 84: faction.v1.API.Defer:output_type -> faction.v1.DeferResponse
+	13, // 85: faction.v1.API.OnChange:output_type -> faction.v1.OnChangeResponse
+	15, // 86: faction.v1.API.AckStream:output_type -> faction.v1.AckResponse
+	80, // [80:87] is the sub-list for method output_type
+	73, // [73:80] is the sub-list for method input_type
+	73, // [73:73] is the sub-list for extension type_name
+	73, // [73:73] is the sub-list for extension extendee
+	0,  // [0:73] is the sub-list for field type_name
+}
+
+func init() { file_pkg_proto_v1_api_proto_init() }
+func file_pkg_proto_v1_api_proto_init() {
+	if File_pkg_proto_v1_api_proto != nil {
+		return
+	}
+	file_pkg_proto_v1_api_proto_msgTypes[16].OneofWrappers = []any{
+		(*Object_World)(nil),
+		(*Object_Config)(nil),
+		(*Object_Race)(nil),
+		(*Object_Culture)(nil),
+		(*Object_Actor)(nil),
+		(*Object_Faction)(nil),
+	}
+	file_pkg_proto_v1_api_proto_msgTypes[17].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_proto_v1_api_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   63,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_proto_v1_api_proto_goTypes,
+		DependencyIndexes: file_pkg_proto_v1_api_proto_depIdxs,
+		MessageInfos:      file_pkg_proto_v1_api_proto_msgTypes,
+	}.Build()
+	File_pkg_proto_v1_api_proto = out.File
+	file_pkg_proto_v1_api_proto_rawDesc = nil
+	file_pkg_proto_v1_api_proto_goTypes = nil
+	file_pkg_proto_v1_api_proto_depIdxs = nil
+}
@@ -0,0 +1,374 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: pkg/proto/v1/api.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	API_Get_FullMethodName       = "/faction.v1.API/Get"
+	API_Set_FullMethodName       = "/faction.v1.API/Set"
+	API_Delete_FullMethodName    = "/faction.v1.API/Delete"
+	API_Search_FullMethodName    = "/faction.v1.API/Search"
+	API_Defer_FullMethodName     = "/faction.v1.API/Defer"
+	API_OnChange_FullMethodName  = "/faction.v1.API/OnChange"
+	API_AckStream_FullMethodName = "/faction.v1.API/AckStream"
+)
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// API is the gRPC equivalent of the HTTP API served by `faction api`.
+//
+// Messages mirror the structs of pkg/structs/v1, json_name is set to the JSON names of the
+// struct fields so objects convert between the two (see convert.go).
+type APIClient interface {
+	// Get objects of a kind by id, or list them by labels.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// Set writes objects, which must all be of the same kind.
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
+	// Delete objects of a kind by id.
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Search for objects of a kind matching a query.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// Defer a change event until some tick of the world.
+	Defer(ctx context.Context, in *DeferRequest, opts ...grpc.CallOption) (*DeferResponse, error)
+	// OnChange streams change events matching the given Change, where fields are
+	// set they must match.
+	OnChange(ctx context.Context, in *OnChangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OnChangeResponse], error)
+	// AckStream acknowledges events received from OnChange with a Queue set, so
+	// that they are not redelivered.
+	AckStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AckRequest, AckResponse], error)
+}
+
+type aPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPIClient(cc grpc.ClientConnInterface) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, API_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetResponse)
+	err := c.cc.Invoke(ctx, API_Set_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, API_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, API_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Defer(ctx context.Context, in *DeferRequest, opts ...grpc.CallOption) (*DeferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeferResponse)
+	err := c.cc.Invoke(ctx, API_Defer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) OnChange(ctx context.Context, in *OnChangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OnChangeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[0], API_OnChange_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[OnChangeRequest, OnChangeResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type API_OnChangeClient = grpc.ServerStreamingClient[OnChangeResponse]
+
+func (c *aPIClient) AckStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AckRequest, AckResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], API_AckStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AckRequest, AckResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type API_AckStreamClient = grpc.ClientStreamingClient[AckRequest, AckResponse]
+
+// APIServer is the server API for API service.
+// All implementations must embed UnimplementedAPIServer
+// for forward compatibility.
+//
+// API is the gRPC equivalent of the HTTP API served by `faction api`.
+//
+// Messages mirror the structs of pkg/structs/v1, json_name is set to the JSON names of the
+// struct fields so objects convert between the two (see convert.go).
+type APIServer interface {
+	// Get objects of a kind by id, or list them by labels.
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// Set writes objects, which must all be of the same kind.
+	Set(context.Context, *SetRequest) (*SetResponse, error)
+	// Delete objects of a kind by id.
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Search for objects of a kind matching a query.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// Defer a change event until some tick of the world.
+	Defer(context.Context, *DeferRequest) (*DeferResponse, error)
+	// OnChange streams change events matching the given Change, where fields are
+	// set they must match.
+	OnChange(*OnChangeRequest, grpc.ServerStreamingServer[OnChangeResponse]) error
+	// AckStream acknowledges events received from OnChange with a Queue set, so
+	// that they are not redelivered.
+	AckStream(grpc.ClientStreamingServer[AckRequest, AckResponse]) error
+	mustEmbedUnimplementedAPIServer()
+}
+
+// UnimplementedAPIServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAPIServer struct{}
+
+func (UnimplementedAPIServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedAPIServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (UnimplementedAPIServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedAPIServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedAPIServer) Defer(context.Context, *DeferRequest) (*DeferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Defer not implemented")
+}
+func (UnimplementedAPIServer) OnChange(*OnChangeRequest, grpc.ServerStreamingServer[OnChangeResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method OnChange not implemented")
+}
+func (UnimplementedAPIServer) AckStream(grpc.ClientStreamingServer[AckRequest, AckResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method AckStream not implemented")
+}
+func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
+func (UnimplementedAPIServer) testEmbeddedByValue()             {}
+
+// UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIServer will
+// result in compilation errors.
+type UnsafeAPIServer interface {
+	mustEmbedUnimplementedAPIServer()
+}
+
+func RegisterAPIServer(s grpc.ServiceRegistrar, srv APIServer) {
+	// If the following call pancis, it indicates UnimplementedAPIServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&API_ServiceDesc, srv)
+}
+
+func _API_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_Set_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Set(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_Defer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).Defer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_Defer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).Defer(ctx, req.(*DeferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_OnChange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OnChangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).OnChange(m, &grpc.GenericServerStream[OnChangeRequest, OnChangeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type API_OnChangeServer = grpc.ServerStreamingServer[OnChangeResponse]
+
+func _API_AckStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).AckStream(&grpc.GenericServerStream[AckRequest, AckResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type API_AckStreamServer = grpc.ClientStreamingServer[AckRequest, AckResponse]
+
+// API_ServiceDesc is the grpc.ServiceDesc for API service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var API_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "faction.v1.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _API_Get_Handler,
+		},
+		{
+			MethodName: "Set",
+			Handler:    _API_Set_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _API_Delete_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _API_Search_Handler,
+		},
+		{
+			MethodName: "Defer",
+			Handler:    _API_Defer_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "OnChange",
+			Handler:       _API_OnChange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AckStream",
+			Handler:       _API_AckStream_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/proto/v1/api.proto",
+}
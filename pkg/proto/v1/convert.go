@@ -0,0 +1,360 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/voidshard/faction/pkg/kind"
+	structs "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+const (
+	// fieldMeta is the name of fields that are inlined into the JSON form of a message,
+	// as Meta is in the structs of each kind.
+	fieldMeta = "meta"
+)
+
+// NewObject converts an object (or the map[string]interface{} form of one, as the database
+// returns) into an Object, the kind is read from the "_kind" field.
+func NewObject(in interface{}) (*Object, error) {
+	data, err := toMap(in)
+	if err != nil {
+		return nil, err
+	}
+	k, _ := data["_kind"].(string)
+	if !kind.IsValid(k) {
+		return nil, fmt.Errorf("kind %s not registered", k)
+	}
+
+	out := &Object{}
+	msg := out.ProtoReflect()
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(k))
+	if fd == nil {
+		return nil, fmt.Errorf("kind %s has no proto message", k)
+	}
+	err = setMessage(msg.Mutable(fd).Message(), data)
+	return out, err
+}
+
+// Kind returns the kind of the object held, or "" if none is set.
+func (x *Object) Kind() string {
+	msg := x.ProtoReflect()
+	fd := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("object"))
+	if fd == nil {
+		return ""
+	}
+	return string(fd.Name())
+}
+
+// Data returns the JSON form of the object as a map, as accepted by kind.New.
+func (x *Object) Data() map[string]interface{} {
+	msg := x.ProtoReflect()
+	fd := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("object"))
+	if fd == nil {
+		return map[string]interface{}{}
+	}
+	return fromMessage(msg.Get(fd).Message())
+}
+
+// ToObject converts the Object into the struct of its kind.
+func (x *Object) ToObject() (structs.Object, error) {
+	k := x.Kind()
+	if k == "" {
+		return nil, fmt.Errorf("object has no kind set")
+	}
+	return kind.New(k, x.Data())
+}
+
+// NewQuery converts a search query into a Query.
+func NewQuery(q *structs.Query) (*Query, error) {
+	data, err := toMap(q)
+	if err != nil {
+		return nil, err
+	}
+	out := &Query{}
+	return out, setMessage(out.ProtoReflect(), data)
+}
+
+// ToQuery converts the Query into a search query.
+func (x *Query) ToQuery() (*structs.Query, error) {
+	data, err := json.Marshal(fromMessage(x.ProtoReflect()))
+	if err != nil {
+		return nil, err
+	}
+	out := structs.NewQuery()
+	return out, json.Unmarshal(data, out)
+}
+
+// NewChange converts an event into a Change.
+func NewChange(e *structs.Event) *Change {
+	return &Change{
+		World:      e.World,
+		Kind:       e.Kind,
+		Controller: e.Controller,
+		Id:         e.Id,
+		Labels:     e.Labels,
+		Deleted:    e.Deleted,
+		Sequence:   e.Sequence,
+	}
+}
+
+// ToEvent converts the Change into an event.
+func (x *Change) ToEvent() *structs.Event {
+	return &structs.Event{
+		World:      x.GetWorld(),
+		Kind:       x.GetKind(),
+		Controller: x.GetController(),
+		Id:         x.GetId(),
+		Labels:     x.GetLabels(),
+		Deleted:    x.GetDeleted(),
+		Sequence:   x.GetSequence(),
+	}
+}
+
+// toMap returns the JSON form of the input as a map, numbers are kept as json.Number
+// so that large integers survive.
+func toMap(in interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	out := map[string]interface{}{}
+	return out, dec.Decode(&out)
+}
+
+// setMessage sets the fields of a message from a JSON map, matching on the json_name of
+// each field.
+func setMessage(msg protoreflect.Message, data map[string]interface{}) error {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Name() == fieldMeta && fd.Message() != nil {
+			err := setMessage(msg.Mutable(fd).Message(), data)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		v, ok := data[fd.JSONName()]
+		if !ok || v == nil {
+			continue
+		}
+
+		var err error
+		switch {
+		case fd.IsMap():
+			err = setMap(msg.Mutable(fd).Map(), fd.MapValue(), v)
+		case fd.IsList():
+			err = setList(msg.Mutable(fd).List(), fd, v)
+		case fd.Message() != nil:
+			err = setValue(msg.Mutable(fd).Message(), v)
+		default:
+			var val protoreflect.Value
+			val, err = scalar(fd, v)
+			if err == nil {
+				msg.Set(fd, val)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %w", fd.JSONName(), err)
+		}
+	}
+	return nil
+}
+
+func setMap(m protoreflect.Map, fd protoreflect.FieldDescriptor, v interface{}) error {
+	in, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected object, got %T", v)
+	}
+	for key, ev := range in {
+		if ev == nil {
+			continue
+		}
+		mk := protoreflect.ValueOfString(key).MapKey()
+		if fd.Message() != nil {
+			val := m.NewValue()
+			err := setValue(val.Message(), ev)
+			if err != nil {
+				return err
+			}
+			m.Set(mk, val)
+			continue
+		}
+		val, err := scalar(fd, ev)
+		if err != nil {
+			return err
+		}
+		m.Set(mk, val)
+	}
+	return nil
+}
+
+func setList(l protoreflect.List, fd protoreflect.FieldDescriptor, v interface{}) error {
+	in, ok := v.([]interface{})
+	if !ok {
+		return fmt.Errorf("expected array, got %T", v)
+	}
+	for _, ev := range in {
+		if fd.Message() != nil {
+			val := l.NewElement()
+			err := setValue(val.Message(), ev)
+			if err != nil {
+				return err
+			}
+			l.Append(val)
+			continue
+		}
+		val, err := scalar(fd, ev)
+		if err != nil {
+			return err
+		}
+		l.Append(val)
+	}
+	return nil
+}
+
+// setValue sets a message from a JSON value, google.protobuf.Struct & Value hold
+// arbitrary JSON.
+func setValue(msg protoreflect.Message, v interface{}) error {
+	switch msg.Interface().(type) {
+	case *structpb.Struct:
+		in, ok := plain(v).(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected object, got %T", v)
+		}
+		st, err := structpb.NewStruct(in)
+		if err != nil {
+			return err
+		}
+		proto.Merge(msg.Interface(), st)
+		return nil
+	case *structpb.Value:
+		val, err := structpb.NewValue(plain(v))
+		if err != nil {
+			return err
+		}
+		proto.Merge(msg.Interface(), val)
+		return nil
+	}
+	in, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected object, got %T", v)
+	}
+	return setMessage(msg, in)
+}
+
+// scalar returns the value of a non message field from a JSON value.
+func scalar(fd protoreflect.FieldDescriptor, v interface{}) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		s, ok := v.(string)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("expected string, got %T", v)
+		}
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BoolKind:
+		b, ok := v.(bool)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("expected bool, got %T", v)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	}
+
+	n, ok := v.(json.Number)
+	if !ok {
+		return protoreflect.Value{}, fmt.Errorf("expected number, got %T", v)
+	}
+	switch fd.Kind() {
+	case protoreflect.DoubleKind:
+		f, err := n.Float64()
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.Int64Kind:
+		i, err := n.Int64()
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Int32Kind:
+		i, err := strconv.ParseInt(n.String(), 10, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Uint64Kind:
+		i, err := strconv.ParseUint(n.String(), 10, 64)
+		return protoreflect.ValueOfUint64(i), err
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+}
+
+// plain replaces json.Number(s) with float64(s), which structpb expects.
+func plain(v interface{}) interface{} {
+	switch x := v.(type) {
+	case json.Number:
+		f, _ := x.Float64()
+		return f
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(x))
+		for k, ev := range x {
+			out[k] = plain(ev)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, ev := range x {
+			out[i] = plain(ev)
+		}
+		return out
+	}
+	return v
+}
+
+// fromMessage returns the JSON form of a message as a map (the inverse of setMessage).
+func fromMessage(msg protoreflect.Message) map[string]interface{} {
+	out := map[string]interface{}{}
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Name() == fieldMeta && fd.Message() != nil {
+			for k, mv := range fromMessage(v.Message()) {
+				out[k] = mv
+			}
+			return true
+		}
+
+		switch {
+		case fd.IsMap():
+			m := map[string]interface{}{}
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				m[k.String()] = fromValue(fd.MapValue(), mv)
+				return true
+			})
+			out[fd.JSONName()] = m
+		case fd.IsList():
+			l := make([]interface{}, v.List().Len())
+			for i := range l {
+				l[i] = fromValue(fd, v.List().Get(i))
+			}
+			out[fd.JSONName()] = l
+		default:
+			out[fd.JSONName()] = fromValue(fd, v)
+		}
+		return true
+	})
+	return out
+}
+
+func fromValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	if fd.Message() == nil {
+		return v.Interface()
+	}
+	switch x := v.Message().Interface().(type) {
+	case *structpb.Struct:
+		return x.AsMap()
+	case *structpb.Value:
+		return x.AsInterface()
+	}
+	return fromMessage(v.Message())
+}
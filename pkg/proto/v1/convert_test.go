@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	structs "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+func TestObjectRoundTrip(t *testing.T) {
+	tick := uint64(1 << 60)
+	in := &structs.Actor{
+		Meta: structs.Meta{
+			Id:           "4a3e8b8e-5d6a-4f4b-9a53-6f1b8b7c2d10",
+			Kind:         "actor",
+			World:        "narnia",
+			Labels:       map[string]string{"class": "noble"},
+			Attributes:   map[string]float64{"wit": 0.5},
+			Owners:       []*structs.Owner{{Kind: "faction", Id: "f1"}},
+			Finalizers:   []string{"example.com/cleanup"},
+			DeletionTick: &tick,
+		},
+		Firstname:   "Edmund",
+		Race:        "human",
+		Culture:     "telmarine",
+		Ethos:       map[string]float64{"honour": 12},
+		Professions: map[string]structs.ActorValueProfession{"smith": {Name: "smith", Level: 40}},
+	}
+
+	obj, err := NewObject(in)
+	assert.Nil(t, err)
+	assert.Equal(t, "actor", obj.Kind())
+	assert.Equal(t, in.Id, obj.GetActor().GetMeta().GetId())
+	assert.Equal(t, int64(40), obj.GetActor().GetProfessions()["smith"].GetLevel())
+
+	out, err := obj.ToObject()
+	assert.Nil(t, err)
+	assert.Equal(t, in, out)
+}
+
+func TestWorldRoundTrip(t *testing.T) {
+	in := &structs.World{
+		Meta:    structs.Meta{Id: "narnia", Kind: "world"},
+		Tick:    12,
+		History: 3,
+		Quota:   &structs.Quota{Objects: map[string]int64{"actor": 1000}, DeferredPerTick: 50, Watchers: 4},
+	}
+
+	obj, err := NewObject(in)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1000), obj.GetWorld().GetQuota().GetObjects()["actor"])
+
+	out, err := obj.ToObject()
+	assert.Nil(t, err)
+	assert.Equal(t, in, out)
+}
+
+func TestObjectUnknownKind(t *testing.T) {
+	_, err := NewObject(map[string]interface{}{"_kind": "dragon"})
+	assert.NotNil(t, err)
+}
+
+func TestQueryRoundTrip(t *testing.T) {
+	in := structs.NewQuery()
+	in.Kind = "actor"
+	in.Limit = 10
+	in.All = append(in.All, structs.Match{Field: "race", Value: "human"})
+	in.Score = append(in.Score, structs.Score{Match: structs.Match{Field: "ethos.honour", Op: "gt", Value: 5.0}, Weight: 2})
+
+	q, err := NewQuery(in)
+	assert.Nil(t, err)
+	assert.Equal(t, "human", q.GetAll()[0].GetValue().GetStringValue())
+
+	out, err := q.ToQuery()
+	assert.Nil(t, err)
+	assert.Equal(t, in, out)
+}
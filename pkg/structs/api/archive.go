@@ -0,0 +1,25 @@
+package api
+
+// ArchiveEndFile is the last file of a world archive, which holds an ArchiveEnd. An archive
+// without it was cut short.
+const ArchiveEndFile = "end.json"
+
+// ArchiveEnd ends a world archive, reporting what it holds or the error that ended the
+// export early.
+type ArchiveEnd struct {
+	// Counts is the number of objects the archive holds of each kind
+	Counts map[string]int64 `json:"Counts"`
+
+	Error *ErrorResponse `json:"Error"`
+}
+
+// ImportResponse reports a world recreated from an archive (see the world export).
+type ImportResponse struct {
+	// World is the id the world was imported as
+	World string `json:"World"`
+
+	// Counts is the number of objects imported of each kind
+	Counts map[string]int64 `json:"Counts"`
+
+	Error *ErrorResponse `json:"Error"`
+}
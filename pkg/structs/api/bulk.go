@@ -0,0 +1,19 @@
+package api
+
+// BulkResult reports the outcome of one line of a bulk write, which streams newline delimited
+// objects in and a BulkResult per line back out.
+//
+// If the write fails part way a final result with Line 0 carries the error, lines after the
+// failure have no result.
+type BulkResult struct {
+	// Line is the (1 based) line of the input this result is for
+	Line int64 `json:"Line"`
+
+	Kind string `json:"Kind,omitempty"`
+	Id   string `json:"Id,omitempty"`
+
+	// Etag is the new Etag of the object if it was written
+	Etag string `json:"Etag,omitempty"`
+
+	Error *ErrorResponse `json:"Error,omitempty"`
+}
@@ -1,8 +1,29 @@
 package api
 
+import (
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+const (
+	// PropagationBackground deletes objects immediately, their dependents (see v1.Meta.Owners)
+	// are deleted after in the background. This is the default.
+	PropagationBackground = "background"
+
+	// PropagationForeground deletes the dependents of objects before the objects themselves.
+	PropagationForeground = "foreground"
+)
+
 type DeleteRequest struct {
 	Ids   []string `json:"Id" validate:"required,min=1,max=5000,dive,valid_id"`
 	World string   `json:"World" validate:"alphanum-if-non-global"`
+
+	// Propagation is how dependents of the objects are deleted, either "background" (default)
+	// or "foreground"
+	Propagation string `json:"Propagation" validate:"omitempty,oneof=background foreground"`
+
+	// Etag, if set, deletes the object only if it is at this Etag, in which case only a
+	// single Id may be given.
+	Etag string `json:"Etag" validate:"uuid4-or-empty"`
 }
 
 func NewDeleteRequest() *DeleteRequest {
@@ -12,5 +33,14 @@ func NewDeleteRequest() *DeleteRequest {
 }
 
 type DeleteResponse struct {
+	// Pending lists Ids of objects whose deletion continues in the background (ie. worlds),
+	// the progress of which can be polled.
+	Pending []string `json:"Pending"`
+
 	Error *ErrorResponse `json:"Error"`
 }
+
+type WorldDeletionResponse struct {
+	Data  *v1.WorldDeletion `json:"Data"`
+	Error *ErrorResponse    `json:"Error"`
+}
@@ -7,8 +7,15 @@ type StreamEvents struct {
 	Controller string `json:"Controller" validate:"alphanum-or-empty"`
 	Id         string `json:"Id" validate:"alphanum-or-empty"`
 
+	// Selector filters on the labels of changed objects (see GetRequest)
+	Selector string `json:"Selector"`
+
 	// Queue name to listen on, if set implies durable subscription
 	Queue string `json:"Queue" validate:"alphanum-or-empty"`
+
+	// FromSequence replays recent changes of the World after the given sequence (see
+	// GetResponse.Sequence) before live events. Replayed events have no AckId.
+	FromSequence uint64 `json:"FromSequence"`
 }
 
 type DeferEventRequest struct {
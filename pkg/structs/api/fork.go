@@ -0,0 +1,21 @@
+package api
+
+// ForkRequest asks that a world be copied into a new world.
+type ForkRequest struct {
+	// To is the id of the new world, which must not already exist
+	To string `json:"To"`
+}
+
+// ForkResponse reports a world copied from another (see ForkRequest).
+type ForkResponse struct {
+	// World is the id of the new world
+	World string `json:"World"`
+
+	// Tick is the tick both worlds were at when forked
+	Tick uint64 `json:"Tick"`
+
+	// Counts is the number of objects copied of each kind
+	Counts map[string]int64 `json:"Counts"`
+
+	Error *ErrorResponse `json:"Error"`
+}
@@ -6,6 +6,14 @@ type GetRequest struct {
 	Offset int64             `json:"Offset" validate:"gte=0"`
 	Labels map[string]string `json:"Labels" validate:"max=10`
 	World  string            `json:"World" validate:"alphanum-if-non-global"`
+
+	// Selector filters by label requirements, eg. "class in (noble,clergy),!exiled",
+	// in addition to any Labels.
+	Selector string `json:"Selector"`
+
+	// Token continues listing from where a previous GetResponse left off, it cannot
+	// be used along with Offset.
+	Token string `json:"Token"`
 }
 
 func NewGetRequest() *GetRequest {
@@ -18,6 +26,15 @@ func NewGetRequest() *GetRequest {
 }
 
 type GetResponse struct {
-	Data  []interface{}  `json:"Data"`
+	Data []interface{} `json:"Data"`
+
+	// Token is set if there may be more objects to list, it can be passed in the next
+	// GetRequest to fetch the following page.
+	Token string `json:"Token"`
+
+	// Sequence is the change sequence of the world read before the objects, so that a watch
+	// from it (see StreamEvents) sees every change the objects may not reflect.
+	Sequence uint64 `json:"Sequence"`
+
 	Error *ErrorResponse `json:"Error"`
 }
@@ -0,0 +1,36 @@
+package api
+
+import (
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+type HistoryRequest struct {
+	Id    string `json:"Id" validate:"required,valid_id"`
+	World string `json:"World" validate:"alphanum-if-non-global"`
+}
+
+type HistoryResponse struct {
+	// Data holds revisions of the object, most recent first
+	Data  []*v1.Revision `json:"Data"`
+	Error *ErrorResponse `json:"Error"`
+}
+
+// RollbackRequest restores an object to a previous revision.
+type RollbackRequest struct {
+	Id    string `json:"Id" validate:"required,valid_id"`
+	World string `json:"World" validate:"alphanum-if-non-global"`
+
+	// Etag is the current Etag of the object, if empty the object is expected to have
+	// been deleted and is recreated.
+	Etag string `json:"Etag" validate:"uuid4-or-empty"`
+
+	// ToEtag is the Etag of the revision to restore
+	ToEtag string `json:"ToEtag" validate:"required,uuid4"`
+}
+
+type RollbackResponse struct {
+	// Etag is the new Etag of the object
+	Etag string `json:"Etag"`
+
+	Error *ErrorResponse `json:"Error"`
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+const (
+	// PatchMerge is a JSON merge patch (RFC 7386), this is the default
+	PatchMerge = "merge"
+
+	// PatchJSON is a JSON patch (RFC 6902)
+	PatchJSON = "json"
+)
+
+type PatchRequest struct {
+	Data  []*Patch `json:"Data" validate:"required,min=1,max=5000,dive"`
+	World string   `json:"World" validate:"alphanum-if-non-global"`
+}
+
+// Patch is an edit to a single object, which is only applied if the object
+// is still at the given Etag.
+type Patch struct {
+	Id   string `json:"Id" validate:"required,valid_id"`
+	Etag string `json:"Etag" validate:"required,uuid4"`
+
+	// Type of patch, either PatchMerge (default) or PatchJSON
+	Type string `json:"Type" validate:"oneof=merge json ''"`
+
+	// Patch is the patch document to apply to the object
+	Patch json.RawMessage `json:"Patch" validate:"required"`
+}
+
+func NewPatchRequest() *PatchRequest {
+	return &PatchRequest{
+		Data: []*Patch{},
+	}
+}
+
+type PatchResponse struct {
+	// Etags maps Id -> new Etag of each object written
+	Etags map[string]string `json:"Etags"`
+
+	// Conflicts lists Ids of objects not written because their Etag was out of date
+	Conflicts []string `json:"Conflicts"`
+
+	Error *ErrorResponse `json:"Error"`
+}
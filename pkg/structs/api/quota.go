@@ -0,0 +1,10 @@
+package api
+
+import (
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+type QuotaUsageResponse struct {
+	Data  *v1.QuotaUsage `json:"Data"`
+	Error *ErrorResponse `json:"Error"`
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	v1 "github.com/voidshard/faction/pkg/structs/v1"
+)
+
+type SetTuplesRequest struct {
+	Data []*v1.Tuple `json:"Data" validate:"required,min=1,max=5000,dive"`
+}
+
+type SetTuplesResponse struct {
+	Error *ErrorResponse `json:"Error"`
+}
+
+type AddModifiersRequest struct {
+	Data []*v1.Modifier `json:"Data" validate:"required,min=1,max=5000,dive"`
+}
+
+type AddModifiersResponse struct {
+	Error *ErrorResponse `json:"Error"`
+}
+
+type GetTuplesRequest struct {
+	Subject string `json:"Subject" validate:"required,alphanumsymbol"`
+
+	// Object, if set, restricts the result to the (Subject, Object) tuple
+	Object string `json:"Object" validate:"alphanumsymbol"`
+
+	// Tick to compute values at, if not set the world's current tick is used
+	Tick uint64 `json:"Tick" validate:"gte=0"`
+}
+
+type GetTuplesResponse struct {
+	Data  []*v1.Tuple    `json:"Data"`
+	Error *ErrorResponse `json:"Error"`
+}
@@ -12,5 +12,11 @@ func NewSetRequest() *SetRequest {
 }
 
 type SetResponse struct {
+	// Etags maps Id -> new Etag of each object written
+	Etags map[string]string `json:"Etags"`
+
+	// Conflicts lists Ids of objects not written because their Etag was out of date
+	Conflicts []string `json:"Conflicts"`
+
 	Error *ErrorResponse `json:"Error"`
 }
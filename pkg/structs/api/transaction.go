@@ -0,0 +1,24 @@
+package api
+
+// TransactionRequest writes objects of any (non global) kinds within a single world,
+// either all objects are written or none are.
+type TransactionRequest struct {
+	Data []interface{} `json:"Data" validate:"required,min=1,max=5000"`
+}
+
+func NewTransactionRequest() *TransactionRequest {
+	return &TransactionRequest{
+		Data: []interface{}{},
+	}
+}
+
+type TransactionResponse struct {
+	// Etags maps Id -> new Etag of each object written
+	Etags map[string]string `json:"Etags"`
+
+	// Conflicts lists Ids of objects whose Etag was out of date, if there are any
+	// then nothing was written
+	Conflicts []string `json:"Conflicts"`
+
+	Error *ErrorResponse `json:"Error"`
+}
@@ -6,5 +6,16 @@ type Event struct {
 	Controller string `json:"controller"`
 	Id         string `json:"id"`
 
+	// Labels of the object at the time of the event
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Deleted is set if the event is for the deletion of the object
+	Deleted bool `json:"deleted,omitempty"`
+
+	// Sequence orders changes within a world, each change is given a greater sequence than
+	// the last (though not necessarily the next number). Events that aren't changes (ie.
+	// deferred events) have none.
+	Sequence uint64 `json:"sequence,omitempty"`
+
 	AckId string `json:"ack_id,omitempty"`
 }
@@ -7,14 +7,19 @@ type Object interface {
 	GetKind() string
 	GetId() string
 	GetEtag() string
+	GetSequence() uint64
 	GetWorld() string
 	GetController() string
 	GetLabels() map[string]string
 	GetAttributes() map[string]float64
+	GetFinalizers() []string
+	GetDeletionTick() *uint64
 
 	SetId(v string)
 	SetEtag(v string)
+	SetSequence(v uint64)
 	SetWorld(v string)
+	SetDeletionTick(v *uint64)
 }
 
 /*
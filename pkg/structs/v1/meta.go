@@ -7,10 +7,32 @@ type Meta struct {
 	Kind       string `json:"_kind" yaml:"_kind" validate:"alphanum,required"`
 	Controller string `json:"_controller" yaml:"_controller" validate:"alphanum-or-empty"`
 
+	// Sequence is the change sequence of the world at which the object was last written, it is
+	// set by the database on each write (see Event.Sequence).
+	Sequence uint64 `json:"_seq" yaml:"_seq"`
+
 	World string `json:"World" yaml:"World" validate:"alphanum-if-non-global"`
 
 	Labels     map[string]string  `json:"Labels" yaml:"Labels" validate:"max=500,dive,keys,alphanumsymbol,endkeys"`
 	Attributes map[string]float64 `json:"Attributes" yaml:"Attributes" validate:"max=500,dive,keys,alphanumsymbol,endkeys"`
+
+	// Owners are objects (within the same world) that this object depends on, when all
+	// of them are deleted this object is deleted too.
+	Owners []*Owner `json:"Owners,omitempty" yaml:"Owners,omitempty" validate:"max=20,dive"`
+
+	// Finalizers block deletion of the object, when it is deleted the object is marked with a
+	// DeletionTick and remains until controllers have cleaned up & removed their finalizers.
+	Finalizers []string `json:"Finalizers,omitempty" yaml:"Finalizers,omitempty" validate:"max=20,dive,alphanumsymbol"`
+
+	// DeletionTick is set (to the tick of the world) when an object with finalizers is deleted.
+	// Writes may leave this out, in which case it is kept as it was, but cannot change it.
+	DeletionTick *uint64 `json:"DeletionTick,omitempty" yaml:"DeletionTick,omitempty"`
+}
+
+// Owner refers to an object that owns another (see Meta.Owners).
+type Owner struct {
+	Kind string `json:"Kind" yaml:"Kind" validate:"alphanum,required"`
+	Id   string `json:"Id" yaml:"Id" validate:"alphanumsymbol,required"`
 }
 
 func (x *Meta) GetKind() string {
@@ -33,6 +55,14 @@ func (x *Meta) SetEtag(v string) {
 	x.Etag = v
 }
 
+func (x *Meta) GetSequence() uint64 {
+	return x.Sequence
+}
+
+func (x *Meta) SetSequence(v uint64) {
+	x.Sequence = v
+}
+
 func (x *Meta) GetWorld() string {
 	return x.World
 }
@@ -49,6 +79,22 @@ func (x *Meta) GetAttributes() map[string]float64 {
 	return x.Attributes
 }
 
+func (x *Meta) GetOwners() []*Owner {
+	return x.Owners
+}
+
+func (x *Meta) GetFinalizers() []string {
+	return x.Finalizers
+}
+
+func (x *Meta) GetDeletionTick() *uint64 {
+	return x.DeletionTick
+}
+
+func (x *Meta) SetDeletionTick(v *uint64) {
+	x.DeletionTick = v
+}
+
 func (x *Meta) GetController() string {
 	if x.Controller == "" {
 		return "default"
@@ -0,0 +1,17 @@
+package v1
+
+// Revision is a past version of an object, recorded on each write.
+type Revision struct {
+	// Id & Etag of the object at this revision
+	Id   string `json:"Id" yaml:"Id"`
+	Etag string `json:"Etag" yaml:"Etag"`
+
+	// Tick of the world when the object was written
+	Tick uint64 `json:"Tick" yaml:"Tick"`
+
+	// Time the object was written (unix nanoseconds)
+	Time int64 `json:"Time" yaml:"Time"`
+
+	// Object is the full object as written
+	Object map[string]interface{} `json:"Object" yaml:"Object"`
+}
@@ -0,0 +1,26 @@
+package v1
+
+const (
+	// MinTuple and MaxTuple are the limits of any Tuple value, computed values
+	// (the Tuple plus any Modifiers) are clamped to this range.
+	MinTuple int64 = -10000
+	MaxTuple int64 = 10000
+)
+
+// Tuple holds the value of some relation between a Subject and an Object.
+//
+// Ie. the trust (relation) faction A (subject) has in faction B (object).
+type Tuple struct {
+	Subject string `json:"Subject" yaml:"Subject" validate:"required,alphanumsymbol"`
+	Object  string `json:"Object" yaml:"Object" validate:"required,alphanumsymbol"`
+	Value   int64  `json:"Value" yaml:"Value" validate:"gte=-10000,lte=10000"`
+}
+
+// Modifier applies a temporary alteration to the value of a Tuple, until the
+// world reaches the Expires tick.
+type Modifier struct {
+	Subject string `json:"Subject" yaml:"Subject" validate:"required,alphanumsymbol"`
+	Object  string `json:"Object" yaml:"Object" validate:"required,alphanumsymbol"`
+	Value   int64  `json:"Value" yaml:"Value" validate:"gte=-20000,lte=20000"`
+	Expires uint64 `json:"Expires" yaml:"Expires" validate:"gt=0"`
+}
@@ -4,6 +4,46 @@ type World struct {
 	Meta `json:",inline" yaml:",inline"`
 
 	Tick uint64 `json:"Tick" yaml:"Tick" validate:"gte=0"`
+
+	// History is the number of revisions kept of each object in the world,
+	// if not set the server default is used.
+	History int `json:"History" yaml:"History" validate:"gte=0,lte=1000"`
+
+	// SkipReferenceChecks turns off checking that objects referenced by writes exist,
+	// ie. for bulk loads where objects may be written before those they reference.
+	SkipReferenceChecks bool `json:"SkipReferenceChecks" yaml:"SkipReferenceChecks"`
+
+	// Quota limits what may be held or done within the world, if not set there are no limits.
+	Quota *Quota `json:"Quota,omitempty" yaml:"Quota,omitempty"`
+}
+
+// Quota limits the use of a world, where a limit of 0 means no limit.
+type Quota struct {
+	// Objects is the maximum number of objects of each kind (by kind name)
+	Objects map[string]int64 `json:"Objects" yaml:"Objects" validate:"max=100,dive,keys,alphanum,endkeys,gte=0"`
+
+	// DeferredPerTick is the maximum number of events deferred to any one tick
+	DeferredPerTick int64 `json:"DeferredPerTick" yaml:"DeferredPerTick" validate:"gte=0"`
+
+	// Watchers is the maximum number of concurrent event streams watching the world, across
+	// all API servers. Streams that watch every world count against every world.
+	Watchers int64 `json:"Watchers" yaml:"Watchers" validate:"gte=0"`
+}
+
+// QuotaUsage reports the current use of a world against its Quota.
+type QuotaUsage struct {
+	World string `json:"World" yaml:"World"`
+	Quota *Quota `json:"Quota" yaml:"Quota"`
+
+	// Objects is the number of objects of each kind within the world
+	Objects map[string]int64 `json:"Objects" yaml:"Objects"`
+
+	// Deferred is the number of events deferred to each pending tick
+	Deferred map[uint64]int64 `json:"Deferred" yaml:"Deferred"`
+
+	// Watchers is the number of event streams watching the world, including those that watch
+	// every world
+	Watchers int64 `json:"Watchers" yaml:"Watchers"`
 }
 
 func (x *World) New(in interface{}) (Object, error) {
@@ -20,3 +60,29 @@ func (x *World) GetWorld() string {
 func (x *World) SetWorld(v string) {
 	x.SetId(v)
 }
+
+const (
+	WorldDeletionRunning  = "running"
+	WorldDeletionComplete = "complete"
+	WorldDeletionFailed   = "failed"
+)
+
+// WorldDeletion reports the progress of deleting a world & everything within it, which
+// happens in the background. The Id is that of the world being deleted.
+type WorldDeletion struct {
+	Meta `json:",inline" yaml:",inline"`
+
+	Status  string `json:"Status" yaml:"Status"`
+	Message string `json:"Message" yaml:"Message"`
+
+	// Started & Finished are unix timestamps (seconds)
+	Started  int64 `json:"Started" yaml:"Started"`
+	Finished int64 `json:"Finished" yaml:"Finished"`
+}
+
+func (x *WorldDeletion) New(in interface{}) (Object, error) {
+	i := &WorldDeletion{}
+	err := unmarshalObject(in, i)
+	i.Kind = "worlddeletion"
+	return i, err
+}
@@ -3,11 +3,13 @@ package lock
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
-	"github.com/go-redis/redis/extra/redisotel/v9"
 	"github.com/go-redsync/redsync/v4"
+	"github.com/go-redsync/redsync/v4/redis"
 	"github.com/go-redsync/redsync/v4/redis/goredis/v9"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	goredislib "github.com/redis/go-redis/v9"
 )
 
@@ -18,8 +20,12 @@ type RedisConfig struct {
 
 type RedisLocker struct {
 	cfg  *RedisConfig
-	pool *goredis.Pool
+	pool redis.Pool
 	sync *redsync.Redsync
+
+	// held are the mutexes we have locked by key, a mutex is only unlocked by whoever locked it
+	heldLock sync.Mutex
+	held     map[string]*redsync.Mutex
 }
 
 func NewRedisLocker(cfg *RedisConfig) (*RedisLocker, error) {
@@ -48,15 +54,30 @@ func NewRedisLocker(cfg *RedisConfig) (*RedisLocker, error) {
 		cfg:  cfg,
 		pool: pool,
 		sync: redsync.New(pool),
+		held: map[string]*redsync.Mutex{},
 	}, nil
 }
 
 func (r *RedisLocker) Lock(ctx context.Context, key string, ttl time.Duration) error {
 	mutex := r.sync.NewMutex(key, redsync.WithExpiry(ttl))
-	return mutex.TryLockContext(ctx)
+	err := mutex.TryLockContext(ctx)
+	if err != nil {
+		return err
+	}
+	r.heldLock.Lock()
+	defer r.heldLock.Unlock()
+	r.held[key] = mutex
+	return nil
 }
 
 func (r *RedisLocker) Unlock(ctx context.Context, key string) error {
-	mutex := r.sync.NewMutex(key)
-	return mutex.UnlockContext(ctx)
+	r.heldLock.Lock()
+	mutex, ok := r.held[key]
+	delete(r.held, key)
+	r.heldLock.Unlock()
+	if !ok {
+		return fmt.Errorf("lock %s is not held", key)
+	}
+	_, err := mutex.UnlockContext(ctx)
+	return err
 }
@@ -0,0 +1,210 @@
+package selector
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Operator is how a Requirement compares a label
+type Operator string
+
+const (
+	Equals       Operator = "="
+	NotEquals    Operator = "!="
+	In           Operator = "in"
+	NotIn        Operator = "notin"
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!exists"
+)
+
+var (
+	token = regexp.MustCompile("^[a-zA-Z0-9\\-_./]+$")
+	set   = regexp.MustCompile("^([^\\s]+)\\s+(in|notin)\\s*\\((.*)\\)$")
+)
+
+// Requirement is a single condition on a label.
+type Requirement struct {
+	Key    string
+	Op     Operator
+	Values []string
+}
+
+// Selector is a set of Requirements, all of which must match.
+type Selector []*Requirement
+
+// Parse reads a comma separated list of requirements, each of which is one of
+//
+//	key=value, key==value, key!=value
+//	key in (a,b), key notin (a,b)
+//	key, !key
+//
+// An empty string parses to an empty selector (matches everything).
+func Parse(in string) (Selector, error) {
+	terms, err := split(in)
+	if err != nil {
+		return nil, err
+	}
+	sel := Selector{}
+	for _, term := range terms {
+		req, err := parseRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, req)
+	}
+	return sel, nil
+}
+
+// FromLabels returns a selector requiring each of the given labels to be set to the given value.
+func FromLabels(labels map[string]string) Selector {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sel := Selector{}
+	for _, k := range keys {
+		sel = append(sel, &Requirement{Key: k, Op: Equals, Values: []string{labels[k]}})
+	}
+	return sel
+}
+
+// Matches returns if all requirements are satisfied by the given labels.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		if !req.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in a form that Parse understands.
+func (s Selector) String() string {
+	terms := make([]string, len(s))
+	for i, req := range s {
+		terms[i] = req.String()
+	}
+	return strings.Join(terms, ",")
+}
+
+// Matches returns if the requirement is satisfied by the given labels. As with
+// the usual set semantics a missing label satisfies NotEquals and NotIn.
+func (r *Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Op {
+	case Equals, In:
+		return ok && r.has(value)
+	case NotEquals, NotIn:
+		return !ok || !r.has(value)
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+func (r *Requirement) String() string {
+	switch r.Op {
+	case Exists:
+		return r.Key
+	case DoesNotExist:
+		return "!" + r.Key
+	case In, NotIn:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Op, strings.Join(r.Values, ","))
+	}
+	return fmt.Sprintf("%s%s%s", r.Key, r.Op, strings.Join(r.Values, ""))
+}
+
+func (r *Requirement) has(value string) bool {
+	for _, v := range r.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// split breaks the input on commas that are not within a set
+func split(in string) ([]string, error) {
+	terms := []string{}
+	depth := 0
+	start := 0
+	for i, c := range in {
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("nested parentheses in selector %q", in)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in selector %q", in)
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, in[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in selector %q", in)
+	}
+	terms = append(terms, in[start:])
+
+	if len(terms) == 1 && strings.TrimSpace(terms[0]) == "" {
+		return []string{}, nil
+	}
+	return terms, nil
+}
+
+func parseRequirement(term string) (*Requirement, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, fmt.Errorf("empty requirement in selector")
+	}
+
+	req := &Requirement{}
+	if m := set.FindStringSubmatch(term); m != nil {
+		req.Key = m[1]
+		req.Op = Operator(m[2])
+		for _, v := range strings.Split(m[3], ",") {
+			v = strings.TrimSpace(v)
+			if !token.MatchString(v) {
+				return nil, fmt.Errorf("invalid value %q in requirement %q", v, term)
+			}
+			req.Values = append(req.Values, v)
+		}
+	} else if strings.HasPrefix(term, "!") && !strings.Contains(term, "=") {
+		req.Key = strings.TrimSpace(term[1:])
+		req.Op = DoesNotExist
+	} else if i := strings.Index(term, "!="); i >= 0 {
+		req.Key = strings.TrimSpace(term[:i])
+		req.Op = NotEquals
+		req.Values = []string{strings.TrimSpace(term[i+2:])}
+	} else if i := strings.Index(term, "="); i >= 0 {
+		value := strings.TrimPrefix(term[i+1:], "=")
+		req.Key = strings.TrimSpace(term[:i])
+		req.Op = Equals
+		req.Values = []string{strings.TrimSpace(value)}
+	} else {
+		req.Key = term
+		req.Op = Exists
+	}
+
+	if !token.MatchString(req.Key) {
+		return nil, fmt.Errorf("invalid key %q in requirement %q", req.Key, term)
+	}
+	for _, v := range req.Values {
+		if strings.ContainsAny(v, "=!() ") {
+			return nil, fmt.Errorf("invalid value %q in requirement %q", v, term)
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,68 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		In     string
+		Expect Selector
+	}{
+		{"", Selector{}},
+		{"class=noble", Selector{{Key: "class", Op: Equals, Values: []string{"noble"}}}},
+		{"class==noble", Selector{{Key: "class", Op: Equals, Values: []string{"noble"}}}},
+		{"class != noble", Selector{{Key: "class", Op: NotEquals, Values: []string{"noble"}}}},
+		{
+			"culture/class in (noble, clergy),!exiled",
+			Selector{
+				{Key: "culture/class", Op: In, Values: []string{"noble", "clergy"}},
+				{Key: "exiled", Op: DoesNotExist},
+			},
+		},
+		{
+			"rank notin (1,2),titled",
+			Selector{
+				{Key: "rank", Op: NotIn, Values: []string{"1", "2"}},
+				{Key: "titled", Op: Exists},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		sel, err := Parse(c.In)
+		assert.Nil(t, err, c.In)
+		assert.Equal(t, c.Expect, sel, c.In)
+	}
+
+	for _, bad := range []string{"a in (b", "a,,b", "a in ()", "a b", "=b", "a=(b)"} {
+		_, err := Parse(bad)
+		assert.NotNil(t, err, bad)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	labels := map[string]string{"culture/class": "noble", "rank": "3"}
+
+	cases := map[string]bool{
+		"":                                true,
+		"culture/class=noble":             true,
+		"culture/class!=noble":            false,
+		"culture/class in (clergy,noble)": true,
+		"rank notin (1,2)":                true,
+		"rank notin (3)":                  false,
+		"missing!=x":                      true,
+		"missing notin (x)":               true,
+		"missing in (x)":                  false,
+		"rank,!missing":                   true,
+		"!rank":                           false,
+	}
+
+	for in, expect := range cases {
+		sel, err := Parse(in)
+		assert.Nil(t, err, in)
+		assert.Equal(t, expect, sel.Matches(labels), in)
+	}
+}
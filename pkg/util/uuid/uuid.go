@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/rand"
 	"regexp"
+	"sync"
 	"time"
 )
 
@@ -21,7 +22,9 @@ var (
 	// checks if we match a UUID like 123e4567-e89b-12d3-a456-426655440000
 	validUUID = regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$")
 
-	rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	// rng is not safe for concurrent use, so is guarded by rngLock
+	rng     = rand.New(rand.NewSource(time.Now().UnixNano()))
+	rngLock sync.Mutex
 )
 
 type UUID [16]byte
@@ -30,13 +33,10 @@ type UUID [16]byte
 func New(args ...interface{}) string {
 	if len(args) == 0 {
 		// random
-		return newUUID(
-			rng.Int(),
-			rng.Int(),
-			rng.Int(),
-			rng.Int(),
-			rng.Int(),
-		).string()
+		rngLock.Lock()
+		a, b, c, d, e := rng.Int(), rng.Int(), rng.Int(), rng.Int(), rng.Int()
+		rngLock.Unlock()
+		return newUUID(a, b, c, d, e).string()
 	}
 	return newUUID(args...).string()
 }